@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ArubaDefaultsName is the name of the ArubaDefaults read in each namespace
+	ArubaDefaultsName = "default"
+	// DefaultedFieldsAnnotation lists the spec fields filled from the ArubaDefaults on creation
+	DefaultedFieldsAnnotation = "arubacloud.com/defaulted-fields"
+)
+
+// ArubaDefaultsSpec defines the values used for the fields left empty by the
+// resources created in the namespace.
+type ArubaDefaultsSpec struct {
+	// Tenant is the default owning account/tenant
+	// +kubebuilder:validation:Optional
+	Tenant string `json:"tenant,omitempty"`
+
+	// Location is the default location
+	// +kubebuilder:validation:Optional
+	Location *Location `json:"location,omitempty"`
+
+	// DataCenter is the default data center of block storages and cloud servers
+	// +kubebuilder:validation:Optional
+	DataCenter string `json:"dataCenter,omitempty"`
+
+	// ProjectReference references the default Project
+	// +kubebuilder:validation:Optional
+	ProjectReference *ResourceReference `json:"projectReference,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=arubadefault
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="the ArubaDefaults of a namespace must be named default"
+// +kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".spec.tenant"
+// +kubebuilder:printcolumn:name="Location",type="string",JSONPath=".spec.location.value"
+// +kubebuilder:printcolumn:name="Data Center",type="string",JSONPath=".spec.dataCenter"
+// +kubebuilder:printcolumn:name="Project",type="string",JSONPath=".spec.projectReference.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ArubaDefaults is the Schema for the arubadefaults API. The ArubaDefaults named
+// "default" fills the tenant, location, data center and project reference of the
+// resources created in its namespace that don't set them.
+type ArubaDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ArubaDefaultsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ArubaDefaultsList contains a list of ArubaDefaults.
+type ArubaDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArubaDefaults `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ArubaDefaults{}, &ArubaDefaultsList{})
+}
@@ -21,6 +21,13 @@ import (
 )
 
 // BlockStorageSpec defines the desired state of BlockStorage.
+// +kubebuilder:validation:XValidation:rule="has(self.tenant) == has(oldSelf.tenant) && (!has(self.tenant) || self.tenant == oldSelf.tenant)",message="tenant is immutable"
+// +kubebuilder:validation:XValidation:rule="!has(self.bootable) || !self.bootable || (has(self.image) && size(self.image) > 0)",message="image is required when bootable is true"
+// +kubebuilder:validation:XValidation:rule="(has(self.bootable) && self.bootable) == (has(oldSelf.bootable) && oldSelf.bootable)",message="bootable is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.image) == has(oldSelf.image) && (!has(self.image) || self.image == oldSelf.image)",message="image is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.dataSource) == has(oldSelf.dataSource) && (!has(self.dataSource) || self.dataSource == oldSelf.dataSource)",message="dataSource is immutable"
+// +kubebuilder:validation:XValidation:rule="!has(self.dataSource) || !has(self.image)",message="dataSource and image are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
 type BlockStorageSpec struct {
 	// Tenant is the owning account/tenant of this block storage
 	Tenant string `json:"tenant,omitempty"`
@@ -31,12 +38,14 @@ type BlockStorageSpec struct {
 
 	// Location specifies the location for the block storage
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="location is immutable"
 	Location Location `json:"location"`
 
 	// SizeGb specifies the size of the block storage in GB
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=16384
+	// +kubebuilder:validation:XValidation:rule="self >= oldSelf",message="sizeGb can only be increased"
 	SizeGb int32 `json:"sizeGb"`
 
 	// BillingPeriod defines the billing period (Hour, Month, etc.)
@@ -46,6 +55,7 @@ type BlockStorageSpec struct {
 
 	// DataCenter specifies the data center for the block storage
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dataCenter is immutable"
 	DataCenter string `json:"dataCenter"`
 
 	// Type specifies the type of the block storage
@@ -61,9 +71,45 @@ type BlockStorageSpec struct {
 	// +kubebuilder:validation:Optional
 	Image string `json:"image,omitempty"`
 
+	// DataSource is the snapshot or block storage the block storage is created from, it is
+	// created empty when not set. SizeGb must be at least the size of the source.
+	// +kubebuilder:validation:Optional
+	DataSource *BlockStorageDataSource `json:"dataSource,omitempty"`
+
 	// ProjectReference references the Project that owns this block storage
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
 	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote block storage is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
+}
+
+// BlockStorageDataSourceKind is the kind of resource a block storage is created from
+// +kubebuilder:validation:Enum=BlockStorageSnapshot;BlockStorage
+type BlockStorageDataSourceKind string
+
+const (
+	// BlockStorageDataSourceSnapshot restores a BlockStorageSnapshot
+	BlockStorageDataSourceSnapshot BlockStorageDataSourceKind = "BlockStorageSnapshot"
+	// BlockStorageDataSourceBlockStorage clones another BlockStorage
+	BlockStorageDataSourceBlockStorage BlockStorageDataSourceKind = "BlockStorage"
+)
+
+// BlockStorageDataSource references the resource a block storage is created from
+type BlockStorageDataSource struct {
+	// Kind is the kind of the source
+	// +kubebuilder:validation:Required
+	Kind BlockStorageDataSourceKind `json:"kind"`
+
+	ResourceReference `json:",inline"`
 }
 
 // BlockStorageStatus defines the observed state of BlockStorage.
@@ -73,14 +119,71 @@ type BlockStorageStatus struct {
 	// ProjectID is the project ID where this block storage is created
 	// +kubebuilder:validation:Optional
 	ProjectID string `json:"projectID,omitempty"`
+
+	// CapacityGb is the size of the block storage reported by Aruba Cloud
+	// +kubebuilder:validation:Optional
+	CapacityGb int32 `json:"capacityGb,omitempty"`
+
+	// Expansion reports the last expansion of the block storage to spec.sizeGb
+	// +kubebuilder:validation:Optional
+	Expansion *BlockStorageExpansion `json:"expansion,omitempty"`
+}
+
+// BlockStorageExpansionPhase is the progress of a block storage expansion
+// +kubebuilder:validation:Enum=Expanding;Completed
+type BlockStorageExpansionPhase string
+
+const (
+	// BlockStorageExpansionExpanding means the new size was requested and is being applied
+	BlockStorageExpansionExpanding BlockStorageExpansionPhase = "Expanding"
+	// BlockStorageExpansionCompleted means Aruba Cloud reports the new size
+	BlockStorageExpansionCompleted BlockStorageExpansionPhase = "Completed"
+)
+
+// BlockStorageExpansionAction is the action needed on the attached cloud servers to use the new size
+// +kubebuilder:validation:Enum=None;GrowFilesystem;Reboot
+type BlockStorageExpansionAction string
+
+const (
+	// BlockStorageExpansionActionNone means the block storage isn't attached to any cloud server
+	BlockStorageExpansionActionNone BlockStorageExpansionAction = "None"
+	// BlockStorageExpansionActionGrowFilesystem means the partition and filesystem of the data volume must be grown
+	BlockStorageExpansionActionGrowFilesystem BlockStorageExpansionAction = "GrowFilesystem"
+	// BlockStorageExpansionActionReboot means the cloud server booting from the block storage must be rebooted
+	BlockStorageExpansionActionReboot BlockStorageExpansionAction = "Reboot"
+)
+
+// BlockStorageExpansion reports the expansion of a block storage
+type BlockStorageExpansion struct {
+	// Phase is the progress of the expansion
+	Phase BlockStorageExpansionPhase `json:"phase"`
+
+	// FromGb is the size before the expansion
+	// +kubebuilder:validation:Optional
+	FromGb int32 `json:"fromGb,omitempty"`
+
+	// ToGb is the requested size
+	ToGb int32 `json:"toGb"`
+
+	// CloudServers are the namespace/name of the cloud servers the block storage is attached to once expanded
+	// +kubebuilder:validation:Optional
+	CloudServers []string `json:"cloudServers,omitempty"`
+
+	// RequiredAction is the action needed on the attached cloud servers to use the new size, set once expanded
+	// +kubebuilder:validation:Optional
+	RequiredAction BlockStorageExpansionAction `json:"requiredAction,omitempty"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=bs
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Resource ID",type="string",JSONPath=".status.resourceID"
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="Capacity",type="integer",JSONPath=".status.capacityGb"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // BlockStorage is the Schema for the blockstorages API.
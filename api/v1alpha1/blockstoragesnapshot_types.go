@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BlockStorageSnapshotSpec defines the desired state of BlockStorageSnapshot.
+// +kubebuilder:validation:XValidation:rule="has(self.tenant) == has(oldSelf.tenant) && (!has(self.tenant) || self.tenant == oldSelf.tenant)",message="tenant is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
+type BlockStorageSnapshotSpec struct {
+	// Tenant is the owning account/tenant of this snapshot
+	Tenant string `json:"tenant,omitempty"`
+
+	// Tags are labels associated with the snapshot
+	// +kubebuilder:validation:Optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Location specifies the location for the snapshot
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="location is immutable"
+	Location Location `json:"location"`
+
+	// BlockStorageReference references the BlockStorage the snapshot is taken of
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="blockStorageReference is immutable"
+	BlockStorageReference ResourceReference `json:"blockStorageReference"`
+
+	// ProjectReference references the Project that owns this snapshot
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
+	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote snapshot is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
+}
+
+// BlockStorageSnapshotStatus defines the observed state of BlockStorageSnapshot.
+type BlockStorageSnapshotStatus struct {
+	ResourceStatus `json:",inline"`
+
+	// ProjectID is the project ID where this snapshot is created
+	// +kubebuilder:validation:Optional
+	ProjectID string `json:"projectID,omitempty"`
+
+	// BlockStorageID is the ID of the block storage the snapshot is taken of
+	// +kubebuilder:validation:Optional
+	BlockStorageID string `json:"blockStorageID,omitempty"`
+
+	// SizeGb is the size of the snapshot reported by Aruba Cloud
+	// +kubebuilder:validation:Optional
+	SizeGb int32 `json:"sizeGb,omitempty"`
+
+	// CreationTime is when the snapshot was taken
+	// +kubebuilder:validation:Optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=bss
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Resource ID",type="string",JSONPath=".status.resourceID"
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".status.sizeGb"
+// +kubebuilder:printcolumn:name="Created",type="date",JSONPath=".status.creationTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="Block Storage ID",type="string",JSONPath=".status.blockStorageID",priority=1
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BlockStorageSnapshot is the Schema for the blockstoragesnapshots API.
+type BlockStorageSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BlockStorageSnapshotSpec   `json:"spec,omitempty"`
+	Status BlockStorageSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BlockStorageSnapshotList contains a list of BlockStorageSnapshot.
+type BlockStorageSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlockStorageSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BlockStorageSnapshot{}, &BlockStorageSnapshotList{})
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// v1alpha1 is the storage version and the conversion hub of the API group,
+// every other version converts to and from these types.
+
+// Hub marks this type as a conversion hub.
+func (*Project) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ElasticIp) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*BlockStorage) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*CloudServer) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*KeyPair) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Vpc) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Subnet) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*SecurityGroup) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*SecurityRule) Hub() {}
@@ -29,6 +29,8 @@ type BillingPlan struct {
 }
 
 // ElasticIpSpec defines the desired state of ElasticIp.
+// +kubebuilder:validation:XValidation:rule="has(self.tenant) == has(oldSelf.tenant) && (!has(self.tenant) || self.tenant == oldSelf.tenant)",message="tenant is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
 type ElasticIpSpec struct {
 	// Tenant is the owning account/tenant of this elastic IP
 	Tenant string `json:"tenant,omitempty"`
@@ -39,6 +41,7 @@ type ElasticIpSpec struct {
 
 	// Location specifies the location for the elastic IP
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="location is immutable"
 	Location Location `json:"location"`
 
 	// BillingPlan specifies the billing configuration
@@ -47,7 +50,23 @@ type ElasticIpSpec struct {
 
 	// ProjectReference references the Project that owns this elastic IP
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
 	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// WriteConnectionSecretToRef names the Secret the connection details are written to.
+	// The Secret is owned by this resource and deleted with it.
+	// +kubebuilder:validation:Optional
+	WriteConnectionSecretToRef *ConnectionSecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote elastic IP is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
 }
 
 // ElasticIpStatus defines the observed state of ElasticIp.
@@ -57,14 +76,22 @@ type ElasticIpStatus struct {
 	// ProjectID is the project ID where this elastic IP is created
 	// +kubebuilder:validation:Optional
 	ProjectID string `json:"projectID,omitempty"`
+
+	// Address is the public IP address of the elastic IP
+	// +kubebuilder:validation:Optional
+	Address string `json:"address,omitempty"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=eip
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Resource ID",type="string",JSONPath=".status.resourceID"
+// +kubebuilder:printcolumn:name="Address",type="string",JSONPath=".status.address"
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ElasticIp is the Schema for the elasticips API.
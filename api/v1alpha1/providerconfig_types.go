@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CredentialSource defines where the API client credentials of a ProviderConfig are read from
+// +kubebuilder:validation:Enum=Secret;Vault;Static
+type CredentialSource string
+
+const (
+	// CredentialSourceSecret reads the client credentials from a Kubernetes Secret
+	CredentialSourceSecret CredentialSource = "Secret"
+	// CredentialSourceVault reads the client credentials of each tenant from Vault
+	CredentialSourceVault CredentialSource = "Vault"
+	// CredentialSourceStatic uses the client credentials set in the ProviderConfig
+	CredentialSourceStatic CredentialSource = "Static"
+)
+
+// ConditionTypeAuthenticated indicates whether the ProviderConfig credentials were accepted
+const ConditionTypeAuthenticated = "Authenticated"
+
+// SecretKeySelector selects a key of a Secret
+type SecretKeySelector struct {
+	// Name is the name of the Secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Key is the key of the value in the Secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// SecretCredentials reads the client credentials from a Kubernetes Secret
+type SecretCredentials struct {
+	// Name is the name of the Secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// ClientIDKey is the key of the client ID in the Secret
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=client-id
+	ClientIDKey string `json:"clientIdKey,omitempty"`
+
+	// ClientSecretKey is the key of the client secret in the Secret
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=client-secret
+	ClientSecretKey string `json:"clientSecretKey,omitempty"`
+}
+
+// VaultCredentials reads the client credentials of each tenant from Vault
+type VaultCredentials struct {
+	// Address is the URL of the Vault server
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Address string `json:"address"`
+
+	// Namespace is the Vault namespace
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// RolePath is the mount path of the AppRole auth method
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	RolePath string `json:"rolePath"`
+
+	// KVMount is the mount path of the KV secrets engine holding a secret per tenant
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	KVMount string `json:"kvMount"`
+
+	// RoleIDRef selects the AppRole role ID
+	// +kubebuilder:validation:Required
+	RoleIDRef SecretKeySelector `json:"roleIdRef"`
+
+	// SecretIDRef selects the AppRole secret ID
+	// +kubebuilder:validation:Required
+	SecretIDRef SecretKeySelector `json:"secretIdRef"`
+}
+
+// StaticCredentials are client credentials set in the ProviderConfig
+type StaticCredentials struct {
+	// ClientID is the API client ID
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientId"`
+
+	// ClientSecret is the API client secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ClientSecret string `json:"clientSecret"`
+}
+
+// ProviderCredentials defines the source of the API client credentials
+// +kubebuilder:validation:XValidation:rule="self.source != 'Secret' || has(self.secretRef)",message="secretRef is required when source is Secret"
+// +kubebuilder:validation:XValidation:rule="self.source != 'Vault' || has(self.vault)",message="vault is required when source is Vault"
+// +kubebuilder:validation:XValidation:rule="self.source != 'Static' || has(self.static)",message="static is required when source is Static"
+type ProviderCredentials struct {
+	// Source selects where the credentials are read from
+	// +kubebuilder:validation:Required
+	Source CredentialSource `json:"source"`
+
+	// SecretRef reads the credentials from a Kubernetes Secret
+	// +kubebuilder:validation:Optional
+	SecretRef *SecretCredentials `json:"secretRef,omitempty"`
+
+	// Vault reads the credentials of each tenant from Vault
+	// +kubebuilder:validation:Optional
+	Vault *VaultCredentials `json:"vault,omitempty"`
+
+	// Static holds the credentials in the ProviderConfig itself
+	// +kubebuilder:validation:Optional
+	Static *StaticCredentials `json:"static,omitempty"`
+}
+
+// ProviderConfigNamespaces selects the namespaces whose resources may reference a ProviderConfig
+// +kubebuilder:validation:XValidation:rule="has(self.namespaces) || has(self.namespaceSelector)",message="namespaces or namespaceSelector is required"
+type ProviderConfigNamespaces struct {
+	// Namespaces are the names of the allowed namespaces
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector allows the namespaces matching its labels, an empty selector allows every namespace
+	// +kubebuilder:validation:Optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ProviderConfigSpec defines the Aruba Cloud endpoints and credentials used by the resources referencing it.
+type ProviderConfigSpec struct {
+	// APIGateway is the URL of the Aruba Cloud API gateway
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	APIGateway string `json:"apiGateway"`
+
+	// KeycloakURL is the URL of the Keycloak server issuing the API tokens
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	KeycloakURL string `json:"keycloakUrl"`
+
+	// Realm is the Keycloak realm of the API clients
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Realm string `json:"realm"`
+
+	// Credentials defines the source of the API client credentials
+	// +kubebuilder:validation:Required
+	Credentials ProviderCredentials `json:"credentials"`
+
+	// AllowedNamespaces restricts the namespaces whose resources may reference the ProviderConfig,
+	// since they are managed with its credentials
+	// +kubebuilder:validation:Required
+	AllowedNamespaces ProviderConfigNamespaces `json:"allowedNamespaces"`
+}
+
+// ProviderConfigStatus defines the observed state of ProviderConfig.
+type ProviderConfigStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAuthenticationTime is when the credentials were last accepted
+	// +kubebuilder:validation:Optional
+	LastAuthenticationTime *metav1.Time `json:"lastAuthenticationTime,omitempty"`
+
+	// Conditions represent the latest available observations of the ProviderConfig state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// ProviderConfigReference references a ProviderConfig
+type ProviderConfigReference struct {
+	// Name is the name of the ProviderConfig
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=pc
+// +kubebuilder:printcolumn:name="API Gateway",type="string",JSONPath=".spec.apiGateway"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.credentials.source"
+// +kubebuilder:printcolumn:name="Authenticated",type="string",JSONPath=".status.conditions[?(@.type==\"Authenticated\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ProviderConfig is the Schema for the providerconfigs API. It defines an Aruba Cloud
+// API endpoint and its credentials, so that resources referencing it through
+// spec.providerConfigRef can be managed in different accounts, environments or regions.
+type ProviderConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderConfigSpec   `json:"spec,omitempty"`
+	Status ProviderConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigList contains a list of ProviderConfig.
+type ProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReferenceGrantFrom selects the resources allowed to reference the ones of the grant namespace
+type ReferenceGrantFrom struct {
+	// Kind is the kind of the referencing resources
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Project;ElasticIp;BlockStorage;KeyPair;Vpc;Subnet;SecurityGroup;SecurityRule;CloudServer;BlockStorageSnapshot
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the referencing resources
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// ReferenceGrantTo selects the resources of the grant namespace that can be referenced
+type ReferenceGrantTo struct {
+	// Kind is the kind of the referenced resources
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Project;ElasticIp;BlockStorage;KeyPair;Vpc;Subnet;SecurityGroup;BlockStorageSnapshot
+	Kind string `json:"kind"`
+
+	// Name restricts the grant to the resource with this name, every resource of Kind is granted otherwise
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+// ReferenceGrantSpec defines which resources of other namespaces may reference the resources of this namespace.
+type ReferenceGrantSpec struct {
+	// From lists the referencing resources that are allowed
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	From []ReferenceGrantFrom `json:"from"`
+
+	// To lists the resources of this namespace that can be referenced
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	To []ReferenceGrantTo `json:"to"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=rg
+
+// ReferenceGrant is the Schema for the referencegrants API. A resource can only reference
+// a resource of another namespace when a ReferenceGrant in the namespace of the referenced
+// resource allows it.
+type ReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReferenceGrantSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReferenceGrantList contains a list of ReferenceGrant.
+type ReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReferenceGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReferenceGrant{}, &ReferenceGrantList{})
+}
@@ -24,6 +24,7 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // SecurityRuleTarget defines the target of a security rule
+// +kubebuilder:validation:XValidation:rule="self.kind != 'Ip' || isCIDR(self.value) || isIP(self.value)",message="value must be an IP address or a CIDR when kind is Ip"
 type SecurityRuleTarget struct {
 	// Kind specifies the type of target (e.g., "Ip", "SecurityGroup")
 	// +kubebuilder:validation:Required
@@ -36,6 +37,9 @@ type SecurityRuleTarget struct {
 }
 
 // SecurityRuleSpec defines the desired state of SecurityRule.
+// +kubebuilder:validation:XValidation:rule="has(self.tenant) == has(oldSelf.tenant) && (!has(self.tenant) || self.tenant == oldSelf.tenant)",message="tenant is immutable"
+// +kubebuilder:validation:XValidation:rule="!(self.protocol in ['ICMP', 'ALL']) || self.port == 'ALL'",message="port must be ALL when protocol is ICMP or ALL"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
 type SecurityRuleSpec struct {
 	// Tenant is the owning account/tenant of this security rule
 	Tenant string `json:"tenant,omitempty"`
@@ -46,6 +50,7 @@ type SecurityRuleSpec struct {
 
 	// Location specifies the location for the security rule
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="location is immutable"
 	Location Location `json:"location"`
 
 	// Protocol specifies the network protocol (TCP, UDP, ICMP, etc.)
@@ -68,15 +73,28 @@ type SecurityRuleSpec struct {
 
 	// SecurityGroupReference references the ArubaSecurityGroup that owns this rule
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="securityGroupReference is immutable"
 	SecurityGroupReference ResourceReference `json:"securityGroupReference"`
 
 	// VpcReference references the ArubaVpc that contains the security group
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="vpcReference is immutable"
 	VpcReference ResourceReference `json:"vpcReference"`
 
 	// ProjectReference references the Project that owns this security rule
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
 	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote security rule is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
 }
 
 // SecurityRuleStatus defines the observed state of SecurityRule.
@@ -97,6 +115,7 @@ type SecurityRuleStatus struct {
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=sr
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
@@ -104,6 +123,8 @@ type SecurityRuleStatus struct {
 // +kubebuilder:printcolumn:name="Protocol",type="string",JSONPath=".spec.protocol"
 // +kubebuilder:printcolumn:name="Direction",type="string",JSONPath=".spec.direction"
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SecurityRule is the Schema for the securityrules API.
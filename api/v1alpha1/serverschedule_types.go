@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduleOptOutAnnotation, set to "true" on a CloudServer, keeps its power state out of every ServerSchedule
+const ScheduleOptOutAnnotation = "arubacloud.com/schedule-opt-out"
+
+// ConditionTypeScheduleValid indicates whether the time zone and windows of a ServerSchedule can be parsed
+const ConditionTypeScheduleValid = "Valid"
+
+// ServerScheduleWindow is a period the selected cloud servers are powered off
+type ServerScheduleWindow struct {
+	// PowerOff is the cron expression, "minute hour day-of-month month day-of-week", of the start of the window
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	PowerOff string `json:"powerOff"`
+
+	// PowerOn is the cron expression of the end of the window
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	PowerOn string `json:"powerOn"`
+}
+
+// ServerScheduleSpec defines when the selected cloud servers are powered off and on.
+type ServerScheduleSpec struct {
+	// Selector selects the CloudServers of the namespace the schedule applies to
+	// +kubebuilder:validation:Required
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// TimeZone is the IANA time zone of the cron expressions, e.g. "Europe/Rome", UTC when not set
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows are the periods the selected cloud servers are powered off
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Windows []ServerScheduleWindow `json:"windows"`
+
+	// Suspend stops the schedule from changing the power state of the cloud servers
+	// +kubebuilder:validation:Optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// ScheduledPowerAction is a power state change of the schedule
+type ScheduledPowerAction struct {
+	// PowerState is the power state the cloud servers are set to
+	PowerState CloudServerPowerState `json:"powerState"`
+
+	// Time is the time of the change
+	Time metav1.Time `json:"time"`
+
+	// CloudServers are the names of the cloud servers changed, only reported for past changes
+	// +kubebuilder:validation:Optional
+	CloudServers []string `json:"cloudServers,omitempty"`
+}
+
+// ServerScheduleStatus defines the observed state of ServerSchedule.
+type ServerScheduleStatus struct {
+	// LastScheduleTime is the time the last change of the schedule was handled; a change due after it, e.g. while no manager was running, is applied next
+	// +kubebuilder:validation:Optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastAction is the last power state change applied
+	// +kubebuilder:validation:Optional
+	LastAction *ScheduledPowerAction `json:"lastAction,omitempty"`
+
+	// NextAction is the next power state change
+	// +kubebuilder:validation:Optional
+	NextAction *ScheduledPowerAction `json:"nextAction,omitempty"`
+
+	// Conditions report whether the schedule is valid
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ss
+// +kubebuilder:printcolumn:name="Time Zone",type="string",JSONPath=".spec.timeZone"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Last",type="string",JSONPath=".status.lastAction.powerState"
+// +kubebuilder:printcolumn:name="Next",type="string",JSONPath=".status.nextAction.powerState"
+// +kubebuilder:printcolumn:name="Next Time",type="date",JSONPath=".status.nextAction.time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ServerSchedule is the Schema for the serverschedules API. It powers off the selected
+// CloudServers at the start of its windows and back on at their end.
+type ServerSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerScheduleSpec   `json:"spec,omitempty"`
+	Status ServerScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerScheduleList contains a list of ServerSchedule.
+type ServerScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerSchedule{}, &ServerScheduleList{})
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SnapshotPolicyLabel is set on the BlockStorageSnapshots taken by a SnapshotPolicy to its name
+	SnapshotPolicyLabel = "arubacloud.com/snapshot-policy"
+	// SnapshotScheduledAtAnnotation is set on the BlockStorageSnapshots taken by a SnapshotPolicy to
+	// the RFC 3339 time of the run they were taken for
+	SnapshotScheduledAtAnnotation = "arubacloud.com/scheduled-at"
+)
+
+// SnapshotRetention defines which snapshots taken by a policy are kept. A snapshot is kept when
+// any of the rules set keeps it, and the latest snapshot of each block storage is always kept.
+// The rules stop applying once the block storage is deleted: all its snapshots are then deleted.
+// +kubebuilder:validation:XValidation:rule="has(self.keepLast) || has(self.keepDays)",message="keepLast or keepDays is required"
+type SnapshotRetention struct {
+	// KeepLast keeps the last N snapshots of each block storage
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	KeepLast int32 `json:"keepLast,omitempty"`
+
+	// KeepDays keeps the snapshots taken in the last N days
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	KeepDays int32 `json:"keepDays,omitempty"`
+}
+
+// SnapshotPolicySpec defines when the selected block storages are snapshotted and for how long the snapshots are kept.
+type SnapshotPolicySpec struct {
+	// Selector selects the BlockStorages of the namespace the policy applies to
+	// +kubebuilder:validation:Required
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Schedule is the cron expression, "minute hour day-of-month month day-of-week", of the snapshots
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone of the schedule, e.g. "Europe/Rome", UTC when not set
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Retention defines which snapshots are kept, the older ones are deleted
+	// +kubebuilder:validation:Required
+	Retention SnapshotRetention `json:"retention"`
+
+	// Tags are labels associated with the snapshots taken
+	// +kubebuilder:validation:Optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Suspend stops the policy from taking snapshots, the retention still applies
+	// +kubebuilder:validation:Optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// SnapshotPolicyFailure reports a run of a policy that failed
+type SnapshotPolicyFailure struct {
+	// Time is the time of the run
+	Time metav1.Time `json:"time"`
+
+	// Message describes the failure
+	Message string `json:"message"`
+}
+
+// SnapshotPolicyStatus defines the observed state of SnapshotPolicy.
+type SnapshotPolicyStatus struct {
+	// LastScheduleTime is the time the last run of the policy was handled; a run due after it, e.g. while no manager was running, is taken next
+	// +kubebuilder:validation:Optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the time of the last run whose snapshots were all taken
+	// +kubebuilder:validation:Optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// LastFailure is the last run a snapshot couldn't be taken for
+	// +kubebuilder:validation:Optional
+	LastFailure *SnapshotPolicyFailure `json:"lastFailure,omitempty"`
+
+	// NextScheduleTime is the time of the next run
+	// +kubebuilder:validation:Optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// Snapshots is the number of snapshots taken by the policy that are kept
+	// +kubebuilder:validation:Optional
+	Snapshots int32 `json:"snapshots,omitempty"`
+
+	// Conditions report whether the schedule is valid
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=sp
+// +kubebuilder:validation:XValidation:rule="size(self.metadata.name) <= 63",message="name must be no more than 63 characters, it labels the snapshots taken"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Snapshots",type="integer",JSONPath=".status.snapshots"
+// +kubebuilder:printcolumn:name="Last Success",type="date",JSONPath=".status.lastSuccessfulTime"
+// +kubebuilder:printcolumn:name="Next",type="date",JSONPath=".status.nextScheduleTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SnapshotPolicy is the Schema for the snapshotpolicies API. It snapshots the selected
+// BlockStorages on a schedule and deletes the snapshots it took once out of retention.
+type SnapshotPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SnapshotPolicySpec   `json:"spec,omitempty"`
+	Status SnapshotPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SnapshotPolicyList contains a list of SnapshotPolicy.
+type SnapshotPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SnapshotPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SnapshotPolicy{}, &SnapshotPolicyList{})
+}
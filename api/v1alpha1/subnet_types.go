@@ -21,6 +21,7 @@ import (
 )
 
 // SubnetNetwork defines the network configuration for a subnet
+// +kubebuilder:validation:XValidation:rule="isCIDR(self.address) && cidr(self.address).masked() == cidr(self.address)",message="address must be a CIDR without host bits set"
 type SubnetNetwork struct {
 	// Address specifies the network address in CIDR notation
 	// +kubebuilder:validation:Required
@@ -36,6 +37,8 @@ type SubnetDHCP struct {
 }
 
 // SubnetSpec defines the desired state of Subnet.
+// +kubebuilder:validation:XValidation:rule="has(self.tenant) == has(oldSelf.tenant) && (!has(self.tenant) || self.tenant == oldSelf.tenant)",message="tenant is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
 type SubnetSpec struct {
 	// Tenant is the owning account/tenant of this subnet
 	Tenant string `json:"tenant,omitempty"`
@@ -63,11 +66,23 @@ type SubnetSpec struct {
 
 	// VpcReference references the ArubaVpc that owns this subnet
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="vpcReference is immutable"
 	VpcReference ResourceReference `json:"vpcReference"`
 
 	// ProjectReference references the Project that owns this block storage
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
 	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote subnet is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
 }
 
 // SubnetStatus defines the observed state of Subnet.
@@ -81,14 +96,26 @@ type SubnetStatus struct {
 	// VpcID is the VPC ID where this subnet is created
 	// +kubebuilder:validation:Optional
 	VpcID string `json:"vpcID,omitempty"`
+
+	// Network is the address of the subnet network in CIDR notation
+	// +kubebuilder:validation:Optional
+	Network string `json:"network,omitempty"`
+
+	// Gateway is the gateway address of the subnet
+	// +kubebuilder:validation:Optional
+	Gateway string `json:"gateway,omitempty"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=asn
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Resource ID",type="string",JSONPath=".status.resourceID"
+// +kubebuilder:printcolumn:name="Gateway",type="string",JSONPath=".status.gateway"
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Subnet is the Schema for the subnets API.
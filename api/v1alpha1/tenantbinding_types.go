@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantBindingSpec binds tenants to the namespaces allowed to use them.
+// +kubebuilder:validation:XValidation:rule="has(self.namespaces) || has(self.namespaceSelector)",message="namespaces or namespaceSelector is required"
+type TenantBindingSpec struct {
+	// Tenants are the tenant IDs the bound namespaces may use
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Tenants []string `json:"tenants"`
+
+	// Namespaces are the names of the bound namespaces
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector binds the namespaces matching its labels
+	// +kubebuilder:validation:Optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=tb
+// +kubebuilder:printcolumn:name="Tenants",type="string",JSONPath=".spec.tenants"
+// +kubebuilder:printcolumn:name="Namespaces",type="string",JSONPath=".spec.namespaces"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TenantBinding is the Schema for the tenantbindings API. When tenant isolation is
+// enabled, a resource may only use a tenant bound to its namespace by a TenantBinding.
+type TenantBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TenantBindingSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantBindingList contains a list of TenantBinding.
+type TenantBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantBinding{}, &TenantBindingList{})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this BlockStorage to the Hub version (v1alpha1).
+func (src *BlockStorage) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.BlockStorage)
+	preserved, lost := &hubData{}, &spokeData{}
+	if err := readAnnotationData(src, HubDataAnnotation, preserved); err != nil {
+		return err
+	}
+
+	convertObjectMeta(&src.ObjectMeta, &dst.ObjectMeta, HubDataAnnotation)
+	dst.Spec.Tenant = preserved.Tenant
+	dst.Spec.Tags = convertTagsTo(src.Spec.Tags, preserved, lost)
+	dst.Spec.Location = v1alpha1.Location{Value: src.Spec.LocationRef}
+	dst.Spec.SizeGb = src.Spec.SizeGb
+	dst.Spec.BillingPeriod = src.Spec.BillingPeriod
+	dst.Spec.DataCenter = src.Spec.DataCenter
+	dst.Spec.Type = src.Spec.Type
+	dst.Spec.Bootable = src.Spec.Bootable
+	dst.Spec.Image = src.Spec.Image
+	dst.Spec.DataSource = convertBlockStorageDataSourceTo(src.Spec.DataSource)
+	dst.Spec.RemoteDeletionPolicy = v1alpha1.RemoteDeletionPolicy(src.Spec.RemoteDeletionPolicy)
+	dst.Spec.ProviderConfigRef = nil
+	if src.Spec.ProviderConfigRef != nil {
+		dst.Spec.ProviderConfigRef = &v1alpha1.ProviderConfigReference{Name: src.Spec.ProviderConfigRef.Name}
+	}
+	dst.Spec.ProjectReference = v1alpha1.ResourceReference(src.Spec.ProjectReference)
+	convertResourceStatusTo(&src.Status.ResourceStatus, &dst.Status.ResourceStatus)
+	dst.Status.ProjectID = src.Status.ProjectID
+	dst.Status.CapacityGb = src.Status.CapacityGb
+	dst.Status.Expansion = convertBlockStorageExpansionTo(src.Status.Expansion)
+
+	return writeAnnotationData(dst, SpokeDataAnnotation, lost, lost.isEmpty())
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *BlockStorage) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.BlockStorage)
+	preserved, lost := &spokeData{}, &hubData{}
+	if err := readAnnotationData(src, SpokeDataAnnotation, preserved); err != nil {
+		return err
+	}
+
+	convertObjectMeta(&src.ObjectMeta, &dst.ObjectMeta, SpokeDataAnnotation)
+	lost.Tenant = src.Spec.Tenant
+	dst.Spec.Tags = convertTagsFrom(src.Spec.Tags, preserved, lost)
+	dst.Spec.LocationRef = src.Spec.Location.Value
+	dst.Spec.SizeGb = src.Spec.SizeGb
+	dst.Spec.BillingPeriod = src.Spec.BillingPeriod
+	dst.Spec.DataCenter = src.Spec.DataCenter
+	dst.Spec.Type = src.Spec.Type
+	dst.Spec.Bootable = src.Spec.Bootable
+	dst.Spec.Image = src.Spec.Image
+	dst.Spec.DataSource = convertBlockStorageDataSourceFrom(src.Spec.DataSource)
+	dst.Spec.RemoteDeletionPolicy = RemoteDeletionPolicy(src.Spec.RemoteDeletionPolicy)
+	dst.Spec.ProviderConfigRef = nil
+	if src.Spec.ProviderConfigRef != nil {
+		dst.Spec.ProviderConfigRef = &ProviderConfigReference{Name: src.Spec.ProviderConfigRef.Name}
+	}
+	dst.Spec.ProjectReference = ResourceReference(src.Spec.ProjectReference)
+	convertResourceStatusFrom(&src.Status.ResourceStatus, &dst.Status.ResourceStatus)
+	dst.Status.ProjectID = src.Status.ProjectID
+	dst.Status.CapacityGb = src.Status.CapacityGb
+	dst.Status.Expansion = convertBlockStorageExpansionFrom(src.Status.Expansion)
+
+	return writeAnnotationData(dst, HubDataAnnotation, lost, lost.isEmpty())
+}
+
+func convertBlockStorageExpansionTo(src *BlockStorageExpansion) *v1alpha1.BlockStorageExpansion {
+	if src == nil {
+		return nil
+	}
+	return &v1alpha1.BlockStorageExpansion{
+		Phase:          v1alpha1.BlockStorageExpansionPhase(src.Phase),
+		FromGb:         src.FromGb,
+		ToGb:           src.ToGb,
+		CloudServers:   slices.Clone(src.CloudServers),
+		RequiredAction: v1alpha1.BlockStorageExpansionAction(src.RequiredAction),
+	}
+}
+
+func convertBlockStorageExpansionFrom(src *v1alpha1.BlockStorageExpansion) *BlockStorageExpansion {
+	if src == nil {
+		return nil
+	}
+	return &BlockStorageExpansion{
+		Phase:          BlockStorageExpansionPhase(src.Phase),
+		FromGb:         src.FromGb,
+		ToGb:           src.ToGb,
+		CloudServers:   slices.Clone(src.CloudServers),
+		RequiredAction: BlockStorageExpansionAction(src.RequiredAction),
+	}
+}
+
+func convertBlockStorageDataSourceTo(src *BlockStorageDataSource) *v1alpha1.BlockStorageDataSource {
+	if src == nil {
+		return nil
+	}
+	return &v1alpha1.BlockStorageDataSource{
+		Kind:              v1alpha1.BlockStorageDataSourceKind(src.Kind),
+		ResourceReference: v1alpha1.ResourceReference(src.ResourceReference),
+	}
+}
+
+func convertBlockStorageDataSourceFrom(src *v1alpha1.BlockStorageDataSource) *BlockStorageDataSource {
+	if src == nil {
+		return nil
+	}
+	return &BlockStorageDataSource{
+		Kind:              BlockStorageDataSourceKind(src.Kind),
+		ResourceReference: ResourceReference(src.ResourceReference),
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BlockStorageSpec defines the desired state of BlockStorage.
+// +kubebuilder:validation:XValidation:rule="!has(self.bootable) || !self.bootable || (has(self.image) && size(self.image) > 0)",message="image is required when bootable is true"
+// +kubebuilder:validation:XValidation:rule="(has(self.bootable) && self.bootable) == (has(oldSelf.bootable) && oldSelf.bootable)",message="bootable is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.image) == has(oldSelf.image) && (!has(self.image) || self.image == oldSelf.image)",message="image is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.dataSource) == has(oldSelf.dataSource) && (!has(self.dataSource) || self.dataSource == oldSelf.dataSource)",message="dataSource is immutable"
+// +kubebuilder:validation:XValidation:rule="!has(self.dataSource) || !has(self.image)",message="dataSource and image are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
+type BlockStorageSpec struct {
+	// Tags are key/value labels associated with the block storage
+	// +kubebuilder:validation:Optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// LocationRef is the location of the block storage (e.g., "ITBG-Bergamo")
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="locationRef is immutable"
+	LocationRef string `json:"locationRef"`
+
+	// SizeGb specifies the size of the block storage in GB
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16384
+	// +kubebuilder:validation:XValidation:rule="self >= oldSelf",message="sizeGb can only be increased"
+	SizeGb int32 `json:"sizeGb"`
+
+	// BillingPeriod defines the billing period (Hour, Month, etc.)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Hour;Month
+	BillingPeriod string `json:"billingPeriod"`
+
+	// DataCenter specifies the data center for the block storage
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dataCenter is immutable"
+	DataCenter string `json:"dataCenter"`
+
+	// Type specifies the type of the block storage
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Standard;Performance
+	Type string `json:"type,omitempty"`
+
+	// Bootable indicates whether the block storage is bootable
+	// +kubebuilder:validation:Optional
+	Bootable bool `json:"bootable,omitempty"`
+
+	// Image specifies the image ID for the block storage
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+
+	// DataSource is the snapshot or block storage the block storage is created from, it is
+	// created empty when not set. SizeGb must be at least the size of the source.
+	// +kubebuilder:validation:Optional
+	DataSource *BlockStorageDataSource `json:"dataSource,omitempty"`
+
+	// ProjectReference references the Project that owns this block storage
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
+	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote block storage is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
+}
+
+// BlockStorageDataSourceKind is the kind of resource a block storage is created from
+// +kubebuilder:validation:Enum=BlockStorageSnapshot;BlockStorage
+type BlockStorageDataSourceKind string
+
+const (
+	// BlockStorageDataSourceSnapshot restores a BlockStorageSnapshot
+	BlockStorageDataSourceSnapshot BlockStorageDataSourceKind = "BlockStorageSnapshot"
+	// BlockStorageDataSourceBlockStorage clones another BlockStorage
+	BlockStorageDataSourceBlockStorage BlockStorageDataSourceKind = "BlockStorage"
+)
+
+// BlockStorageDataSource references the resource a block storage is created from
+type BlockStorageDataSource struct {
+	// Kind is the kind of the source
+	// +kubebuilder:validation:Required
+	Kind BlockStorageDataSourceKind `json:"kind"`
+
+	ResourceReference `json:",inline"`
+}
+
+// BlockStorageStatus defines the observed state of BlockStorage.
+type BlockStorageStatus struct {
+	ResourceStatus `json:",inline"`
+
+	// ProjectID is the project ID where this block storage is created
+	// +kubebuilder:validation:Optional
+	ProjectID string `json:"projectID,omitempty"`
+
+	// CapacityGb is the size of the block storage reported by Aruba Cloud
+	// +kubebuilder:validation:Optional
+	CapacityGb int32 `json:"capacityGb,omitempty"`
+
+	// Expansion reports the last expansion of the block storage to spec.sizeGb
+	// +kubebuilder:validation:Optional
+	Expansion *BlockStorageExpansion `json:"expansion,omitempty"`
+}
+
+// BlockStorageExpansionPhase is the progress of a block storage expansion
+// +kubebuilder:validation:Enum=Expanding;Completed
+type BlockStorageExpansionPhase string
+
+const (
+	// BlockStorageExpansionExpanding means the new size was requested and is being applied
+	BlockStorageExpansionExpanding BlockStorageExpansionPhase = "Expanding"
+	// BlockStorageExpansionCompleted means Aruba Cloud reports the new size
+	BlockStorageExpansionCompleted BlockStorageExpansionPhase = "Completed"
+)
+
+// BlockStorageExpansionAction is the action needed on the attached cloud servers to use the new size
+// +kubebuilder:validation:Enum=None;GrowFilesystem;Reboot
+type BlockStorageExpansionAction string
+
+const (
+	// BlockStorageExpansionActionNone means the block storage isn't attached to any cloud server
+	BlockStorageExpansionActionNone BlockStorageExpansionAction = "None"
+	// BlockStorageExpansionActionGrowFilesystem means the partition and filesystem of the data volume must be grown
+	BlockStorageExpansionActionGrowFilesystem BlockStorageExpansionAction = "GrowFilesystem"
+	// BlockStorageExpansionActionReboot means the cloud server booting from the block storage must be rebooted
+	BlockStorageExpansionActionReboot BlockStorageExpansionAction = "Reboot"
+)
+
+// BlockStorageExpansion reports the expansion of a block storage
+type BlockStorageExpansion struct {
+	// Phase is the progress of the expansion
+	Phase BlockStorageExpansionPhase `json:"phase"`
+
+	// FromGb is the size before the expansion
+	// +kubebuilder:validation:Optional
+	FromGb int32 `json:"fromGb,omitempty"`
+
+	// ToGb is the requested size
+	ToGb int32 `json:"toGb"`
+
+	// CloudServers are the namespace/name of the cloud servers the block storage is attached to once expanded
+	// +kubebuilder:validation:Optional
+	CloudServers []string `json:"cloudServers,omitempty"`
+
+	// RequiredAction is the action needed on the attached cloud servers to use the new size, set once expanded
+	// +kubebuilder:validation:Optional
+	RequiredAction BlockStorageExpansionAction `json:"requiredAction,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=bs
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Resource ID",type="string",JSONPath=".status.resourceID"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="Capacity",type="integer",JSONPath=".status.capacityGb"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BlockStorage is the Schema for the blockstorages API.
+type BlockStorage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BlockStorageSpec   `json:"spec,omitempty"`
+	Status BlockStorageStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BlockStorageList contains a list of BlockStorage.
+type BlockStorageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlockStorage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BlockStorage{}, &BlockStorageList{})
+}
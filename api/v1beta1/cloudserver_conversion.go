@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this CloudServer to the Hub version (v1alpha1).
+func (src *CloudServer) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.CloudServer)
+	preserved, lost := &hubData{}, &spokeData{}
+	if err := readAnnotationData(src, HubDataAnnotation, preserved); err != nil {
+		return err
+	}
+
+	convertObjectMeta(&src.ObjectMeta, &dst.ObjectMeta, HubDataAnnotation)
+	dst.Spec.Tenant = preserved.Tenant
+	dst.Spec.Tags = convertTagsTo(src.Spec.Tags, preserved, lost)
+	dst.Spec.Location = v1alpha1.Location{Value: src.Spec.LocationRef}
+	dst.Spec.DataCenter = src.Spec.DataCenter
+	dst.Spec.VpcPreset = src.Spec.VpcPreset
+	dst.Spec.FlavorName = src.Spec.FlavorName
+	dst.Spec.RemoteDeletionPolicy = v1alpha1.RemoteDeletionPolicy(src.Spec.RemoteDeletionPolicy)
+	dst.Spec.ProviderConfigRef = nil
+	if src.Spec.ProviderConfigRef != nil {
+		dst.Spec.ProviderConfigRef = &v1alpha1.ProviderConfigReference{Name: src.Spec.ProviderConfigRef.Name}
+	}
+	dst.Spec.WriteConnectionSecretToRef = (*v1alpha1.ConnectionSecretReference)(src.Spec.WriteConnectionSecretToRef.DeepCopy())
+	dst.Spec.SSHUsername = src.Spec.SSHUsername
+	dst.Spec.PowerState = v1alpha1.CloudServerPowerState(src.Spec.PowerState)
+	dst.Spec.VpcReference = v1alpha1.ResourceReference(src.Spec.VpcReference)
+	dst.Spec.KeyPairReference = v1alpha1.ResourceReference(src.Spec.KeyPairReference)
+	dst.Spec.BootVolumeReference = v1alpha1.ResourceReference(src.Spec.BootVolumeReference)
+	dst.Spec.ProjectReference = v1alpha1.ResourceReference(src.Spec.ProjectReference)
+	dst.Spec.ElasticIpReference = nil
+	if src.Spec.ElasticIpReference != nil {
+		ref := v1alpha1.ResourceReference(*src.Spec.ElasticIpReference)
+		dst.Spec.ElasticIpReference = &ref
+	}
+	dst.Spec.SubnetReferences = convertReferencesTo(src.Spec.SubnetReferences)
+	dst.Spec.SecurityGroupReferences = convertReferencesTo(src.Spec.SecurityGroupReferences)
+	dst.Spec.DataVolumeReferences = convertReferencesTo(src.Spec.DataVolumeReferences)
+	dst.Spec.SecurityGroupSelector = src.Spec.SecurityGroupSelector.DeepCopy()
+	dst.Spec.DataVolumeSelector = src.Spec.DataVolumeSelector.DeepCopy()
+	convertResourceStatusTo(&src.Status.ResourceStatus, &dst.Status.ResourceStatus)
+	dst.Status.ProjectID = src.Status.ProjectID
+	dst.Status.VpcID = src.Status.VpcID
+	dst.Status.BootVolumeID = src.Status.BootVolumeID
+	dst.Status.ElasticIpID = src.Status.ElasticIpID
+	dst.Status.KeyPairID = src.Status.KeyPairID
+	dst.Status.SubnetIDs = slices.Clone(src.Status.SubnetIDs)
+	dst.Status.SecurityGroupIDs = slices.Clone(src.Status.SecurityGroupIDs)
+	dst.Status.DataVolumeIDs = slices.Clone(src.Status.DataVolumeIDs)
+	dst.Status.ResolvedSecurityGroups = convertReferencesTo(src.Status.ResolvedSecurityGroups)
+	dst.Status.ResolvedDataVolumes = convertReferencesTo(src.Status.ResolvedDataVolumes)
+	dst.Status.VolumeIDs = slices.Clone(src.Status.VolumeIDs)
+	dst.Status.IPAddress = src.Status.IPAddress
+	dst.Status.PublicIPAddress = src.Status.PublicIPAddress
+	dst.Status.Flavor = (*v1alpha1.CloudServerFlavor)(src.Status.Flavor.DeepCopy())
+	dst.Status.PowerState = v1alpha1.CloudServerPowerState(src.Status.PowerState)
+	dst.Status.PowerAction = v1alpha1.CloudServerPowerAction(src.Status.PowerAction)
+	dst.Status.LastRestartRequest = src.Status.LastRestartRequest
+	dst.Status.Resize = convertCloudServerResizeTo(src.Status.Resize)
+
+	return writeAnnotationData(dst, SpokeDataAnnotation, lost, lost.isEmpty())
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *CloudServer) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.CloudServer)
+	preserved, lost := &spokeData{}, &hubData{}
+	if err := readAnnotationData(src, SpokeDataAnnotation, preserved); err != nil {
+		return err
+	}
+
+	convertObjectMeta(&src.ObjectMeta, &dst.ObjectMeta, SpokeDataAnnotation)
+	lost.Tenant = src.Spec.Tenant
+	dst.Spec.Tags = convertTagsFrom(src.Spec.Tags, preserved, lost)
+	dst.Spec.LocationRef = src.Spec.Location.Value
+	dst.Spec.DataCenter = src.Spec.DataCenter
+	dst.Spec.VpcPreset = src.Spec.VpcPreset
+	dst.Spec.FlavorName = src.Spec.FlavorName
+	dst.Spec.RemoteDeletionPolicy = RemoteDeletionPolicy(src.Spec.RemoteDeletionPolicy)
+	dst.Spec.ProviderConfigRef = nil
+	if src.Spec.ProviderConfigRef != nil {
+		dst.Spec.ProviderConfigRef = &ProviderConfigReference{Name: src.Spec.ProviderConfigRef.Name}
+	}
+	dst.Spec.WriteConnectionSecretToRef = (*ConnectionSecretReference)(src.Spec.WriteConnectionSecretToRef.DeepCopy())
+	dst.Spec.SSHUsername = src.Spec.SSHUsername
+	dst.Spec.PowerState = CloudServerPowerState(src.Spec.PowerState)
+	dst.Spec.VpcReference = ResourceReference(src.Spec.VpcReference)
+	dst.Spec.KeyPairReference = ResourceReference(src.Spec.KeyPairReference)
+	dst.Spec.BootVolumeReference = ResourceReference(src.Spec.BootVolumeReference)
+	dst.Spec.ProjectReference = ResourceReference(src.Spec.ProjectReference)
+	dst.Spec.ElasticIpReference = nil
+	if src.Spec.ElasticIpReference != nil {
+		ref := ResourceReference(*src.Spec.ElasticIpReference)
+		dst.Spec.ElasticIpReference = &ref
+	}
+	dst.Spec.SubnetReferences = convertReferencesFrom(src.Spec.SubnetReferences)
+	dst.Spec.SecurityGroupReferences = convertReferencesFrom(src.Spec.SecurityGroupReferences)
+	dst.Spec.DataVolumeReferences = convertReferencesFrom(src.Spec.DataVolumeReferences)
+	dst.Spec.SecurityGroupSelector = src.Spec.SecurityGroupSelector.DeepCopy()
+	dst.Spec.DataVolumeSelector = src.Spec.DataVolumeSelector.DeepCopy()
+	convertResourceStatusFrom(&src.Status.ResourceStatus, &dst.Status.ResourceStatus)
+	dst.Status.ProjectID = src.Status.ProjectID
+	dst.Status.VpcID = src.Status.VpcID
+	dst.Status.BootVolumeID = src.Status.BootVolumeID
+	dst.Status.ElasticIpID = src.Status.ElasticIpID
+	dst.Status.KeyPairID = src.Status.KeyPairID
+	dst.Status.SubnetIDs = slices.Clone(src.Status.SubnetIDs)
+	dst.Status.SecurityGroupIDs = slices.Clone(src.Status.SecurityGroupIDs)
+	dst.Status.DataVolumeIDs = slices.Clone(src.Status.DataVolumeIDs)
+	dst.Status.ResolvedSecurityGroups = convertReferencesFrom(src.Status.ResolvedSecurityGroups)
+	dst.Status.ResolvedDataVolumes = convertReferencesFrom(src.Status.ResolvedDataVolumes)
+	dst.Status.VolumeIDs = slices.Clone(src.Status.VolumeIDs)
+	dst.Status.IPAddress = src.Status.IPAddress
+	dst.Status.PublicIPAddress = src.Status.PublicIPAddress
+	dst.Status.Flavor = (*CloudServerFlavor)(src.Status.Flavor.DeepCopy())
+	dst.Status.PowerState = CloudServerPowerState(src.Status.PowerState)
+	dst.Status.PowerAction = CloudServerPowerAction(src.Status.PowerAction)
+	dst.Status.LastRestartRequest = src.Status.LastRestartRequest
+	dst.Status.Resize = convertCloudServerResizeFrom(src.Status.Resize)
+
+	return writeAnnotationData(dst, HubDataAnnotation, lost, lost.isEmpty())
+}
+
+func convertCloudServerResizeTo(src *CloudServerResize) *v1alpha1.CloudServerResize {
+	if src == nil {
+		return nil
+	}
+	return &v1alpha1.CloudServerResize{
+		Phase:   v1alpha1.CloudServerResizePhase(src.Phase),
+		From:    src.From,
+		To:      src.To,
+		Message: src.Message,
+	}
+}
+
+func convertCloudServerResizeFrom(src *v1alpha1.CloudServerResize) *CloudServerResize {
+	if src == nil {
+		return nil
+	}
+	return &CloudServerResize{
+		Phase:   CloudServerResizePhase(src.Phase),
+		From:    src.From,
+		To:      src.To,
+		Message: src.Message,
+	}
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloudServerSpec defines the desired state of CloudServer.
+// +kubebuilder:validation:XValidation:rule="(has(self.securityGroupReferences) && size(self.securityGroupReferences) > 0) || has(self.securityGroupSelector)",message="securityGroupReferences or securityGroupSelector is required"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
+type CloudServerSpec struct {
+	// Tags are key/value labels associated with the cloud server
+	// +kubebuilder:validation:Optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// LocationRef is the location of the cloud server (e.g., "ITBG-Bergamo")
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="locationRef is immutable"
+	LocationRef string `json:"locationRef"`
+
+	// DataCenter specifies the data center
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dataCenter is immutable"
+	DataCenter string `json:"dataCenter"`
+
+	// VpcReference references the VPC where the cloud server will be created
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="vpcReference is immutable"
+	VpcReference ResourceReference `json:"vpcReference"`
+
+	// VpcPreset indicates whether to use VPC preset
+	// +kubebuilder:validation:Optional
+	VpcPreset bool `json:"vpcPreset,omitempty"`
+
+	// FlavorId specifies the flavor/size of the cloud server
+	// +kubebuilder:validation:Required
+	FlavorName string `json:"flavorName"`
+
+	// ElasticIpReference references an existing elastic IP (optional)
+	// +kubebuilder:validation:Optional
+	ElasticIpReference *ResourceReference `json:"elasticIpReference,omitempty"`
+
+	// KeyPairReference references a key pair for SSH access (optional)
+	// +kubebuilder:validation:Required
+	KeyPairReference ResourceReference `json:"keyPairReference"`
+
+	// SubnetReferences references the subnets where the cloud server will be attached
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	SubnetReferences []ResourceReference `json:"subnetReferences"`
+
+	// SecurityGroupReferences references the security groups for the cloud server
+	// +kubebuilder:validation:Optional
+	SecurityGroupReferences []ResourceReference `json:"securityGroupReferences,omitempty"`
+
+	// SecurityGroupSelector selects by label additional security groups of the namespace of the cloud server
+	// +kubebuilder:validation:Optional
+	SecurityGroupSelector *metav1.LabelSelector `json:"securityGroupSelector,omitempty"`
+
+	// BootVolumeReference references the boot volume for the cloud server
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="bootVolumeReference is immutable"
+	BootVolumeReference ResourceReference `json:"bootVolumeReference"`
+
+	// DataVolumeReferences references additional data volumes to attach to the cloud server (optional)
+	// +kubebuilder:validation:Optional
+	DataVolumeReferences []ResourceReference `json:"dataVolumeReferences,omitempty"`
+
+	// DataVolumeSelector selects by label additional data volumes of the namespace of the cloud server
+	// +kubebuilder:validation:Optional
+	DataVolumeSelector *metav1.LabelSelector `json:"dataVolumeSelector,omitempty"`
+
+	// ProjectReference references the Project that owns this cloud server
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
+	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// WriteConnectionSecretToRef names the Secret the connection details are written to.
+	// The Secret is owned by this resource and deleted with it.
+	// +kubebuilder:validation:Optional
+	WriteConnectionSecretToRef *ConnectionSecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// SSHUsername is the user to log in to the cloud server with, published in the connection Secret
+	// +kubebuilder:validation:Optional
+	SSHUsername string `json:"sshUsername,omitempty"`
+
+	// PowerState is the desired power state of the cloud server
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=On
+	PowerState CloudServerPowerState `json:"powerState,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote cloud server is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
+}
+
+// CloudServerStatus defines the observed state of CloudServer.
+type CloudServerStatus struct {
+	ResourceStatus `json:",inline"`
+
+	// ProjectID is the project ID where this cloud server is created
+	// +kubebuilder:validation:Optional
+	ProjectID string `json:"projectID,omitempty"`
+
+	// VpcID is the VPC ID where this cloud server is created
+	// +kubebuilder:validation:Optional
+	VpcID string `json:"vpcID,omitempty"`
+
+	// BootVolumeID is the boot volume ID where this cloud server is created
+	// +kubebuilder:validation:Optional
+	BootVolumeID string `json:"bootVolumeID,omitempty"`
+
+	// ElasticIpID is the elastic IP ID if one is assigned
+	// +kubebuilder:validation:Optional
+	ElasticIpID string `json:"elasticIpID,omitempty"`
+
+	// KeyPairID is the key pair ID if one is specified
+	// +kubebuilder:validation:Optional
+	KeyPairID string `json:"keyPairID,omitempty"`
+
+	// SubnetIDs are the subnet IDs where this cloud server is attached
+	// +kubebuilder:validation:Optional
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+
+	// SecurityGroupIDs are the security group IDs for this cloud server
+	// +kubebuilder:validation:Optional
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+
+	// DataVolumeIDs are the data volume IDs attached to this cloud server
+	// +kubebuilder:validation:Optional
+	DataVolumeIDs []string `json:"dataVolumeIDs,omitempty"`
+
+	// ResolvedSecurityGroups are the security groups referenced or selected by the spec
+	// +kubebuilder:validation:Optional
+	ResolvedSecurityGroups []ResourceReference `json:"resolvedSecurityGroups,omitempty"`
+
+	// ResolvedDataVolumes are the data volumes referenced or selected by the spec
+	// +kubebuilder:validation:Optional
+	ResolvedDataVolumes []ResourceReference `json:"resolvedDataVolumes,omitempty"`
+
+	// VolumeIDs are the volume IDs attached to this cloud server
+	// +kubebuilder:validation:Optional
+	VolumeIDs []string `json:"volumeIDs,omitempty"`
+
+	// IPAddress is the private IP address of the cloud server
+	// +kubebuilder:validation:Optional
+	IPAddress string `json:"ipAddress,omitempty"`
+
+	// PublicIPAddress is the address of the elastic IP attached to the cloud server
+	// +kubebuilder:validation:Optional
+	PublicIPAddress string `json:"publicIPAddress,omitempty"`
+
+	// Flavor describes the flavor of the cloud server, the flavor last applied while the API doesn't return it
+	// +kubebuilder:validation:Optional
+	Flavor *CloudServerFlavor `json:"flavor,omitempty"`
+
+	// PowerState is the power state observed on the remote cloud server, empty while it is transitioning
+	// +kubebuilder:validation:Optional
+	PowerState CloudServerPowerState `json:"powerState,omitempty"`
+
+	// PowerAction is the power action in progress
+	// +kubebuilder:validation:Optional
+	PowerAction CloudServerPowerAction `json:"powerAction,omitempty"`
+
+	// LastRestartRequest is the value of the restart annotation last handled
+	// +kubebuilder:validation:Optional
+	LastRestartRequest string `json:"lastRestartRequest,omitempty"`
+
+	// Resize reports the last resize of the cloud server to spec.flavorName, the effective flavor being reported in flavor
+	// +kubebuilder:validation:Optional
+	Resize *CloudServerResize `json:"resize,omitempty"`
+}
+
+// CloudServerPowerState is the power state of a cloud server
+// +kubebuilder:validation:Enum=On;Off
+type CloudServerPowerState string
+
+const (
+	// CloudServerPowerOn means the cloud server is running
+	CloudServerPowerOn CloudServerPowerState = "On"
+	// CloudServerPowerOff means the cloud server is stopped
+	CloudServerPowerOff CloudServerPowerState = "Off"
+)
+
+// CloudServerPowerAction is a power action run on a cloud server
+// +kubebuilder:validation:Enum=PowerOn;PowerOff;Restart
+type CloudServerPowerAction string
+
+const (
+	// CloudServerPowerActionOn powers the cloud server on
+	CloudServerPowerActionOn CloudServerPowerAction = "PowerOn"
+	// CloudServerPowerActionOff powers the cloud server off
+	CloudServerPowerActionOff CloudServerPowerAction = "PowerOff"
+	// CloudServerPowerActionRestart powers the cloud server off, then on
+	CloudServerPowerActionRestart CloudServerPowerAction = "Restart"
+)
+
+// ConditionTypeFlavorCompatible indicates whether the cloud server can be resized to spec.flavorName
+const ConditionTypeFlavorCompatible = "FlavorCompatible"
+
+// CloudServerResizePhase is the progress of a cloud server resize
+// +kubebuilder:validation:Enum=Stopping;Resizing;Completed;Refused
+type CloudServerResizePhase string
+
+const (
+	// CloudServerResizeStopping means the cloud server is being powered off to be resized
+	CloudServerResizeStopping CloudServerResizePhase = "Stopping"
+	// CloudServerResizeResizing means the new flavor was requested and is being applied
+	CloudServerResizeResizing CloudServerResizePhase = "Resizing"
+	// CloudServerResizeCompleted means the cloud server runs with the new flavor
+	CloudServerResizeCompleted CloudServerResizePhase = "Completed"
+	// CloudServerResizeRefused means the new flavor is incompatible with the cloud server
+	CloudServerResizeRefused CloudServerResizePhase = "Refused"
+)
+
+// CloudServerResize reports the resize of a cloud server to another flavor
+type CloudServerResize struct {
+	// Phase is the progress of the resize
+	Phase CloudServerResizePhase `json:"phase"`
+
+	// From is the flavor of the cloud server before the resize
+	// +kubebuilder:validation:Optional
+	From string `json:"from,omitempty"`
+
+	// To is the requested flavor
+	To string `json:"to"`
+
+	// Message explains why the resize was refused
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// CloudServerFlavor describes the resources of a cloud server flavor
+type CloudServerFlavor struct {
+	// Name is the name of the flavor (e.g., "CSO4A8")
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// Category is the category of the flavor
+	// +kubebuilder:validation:Optional
+	Category string `json:"category,omitempty"`
+
+	// CPU is the number of virtual CPUs
+	// +kubebuilder:validation:Optional
+	CPU int32 `json:"cpu,omitempty"`
+
+	// RAMGb is the memory in GB
+	// +kubebuilder:validation:Optional
+	RAMGb int32 `json:"ramGb,omitempty"`
+
+	// DiskGb is the disk size in GB
+	// +kubebuilder:validation:Optional
+	DiskGb int32 `json:"diskGb,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=cs
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Resource ID",type="string",JSONPath=".status.resourceID"
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".status.ipAddress"
+// +kubebuilder:printcolumn:name="Power",type="string",JSONPath=".status.powerState"
+// +kubebuilder:printcolumn:name="Flavor",type="string",JSONPath=".status.flavor.name",priority=1
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CloudServer is the Schema for the cloudservers API.
+type CloudServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudServerSpec   `json:"spec,omitempty"`
+	Status CloudServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CloudServerList contains a list of CloudServer.
+type CloudServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CloudServer{}, &CloudServerList{})
+}
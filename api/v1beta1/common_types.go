@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// Common phases for all resources
+type ResourcePhase string
+
+const (
+	// ResourcePhaseCreating indicates the resource is being created
+	ResourcePhaseCreating ResourcePhase = "Creating"
+	// ResourcePhaseProvisioning indicates the resource is being provisioned remotely
+	ResourcePhaseProvisioning ResourcePhase = "Provisioning"
+	// ResourcePhaseCreated indicates the resource has been created successfully
+	ResourcePhaseCreated ResourcePhase = "Created"
+	// ResourcePhaseUpdating indicates the resource is being updated
+	ResourcePhaseUpdating ResourcePhase = "Updating"
+	// ResourcePhaseDeleting indicates the resource is being deleted
+	ResourcePhaseDeleting ResourcePhase = "Deleting"
+	// ResourcePhaseDeleted indicates the resource has been deleted
+	ResourcePhaseDeleted ResourcePhase = "Deleted"
+	// ResourcePhaseFailed indicates the resource has failed
+	ResourcePhaseFailed ResourcePhase = "Failed"
+)
+
+// Condition types for resources
+const (
+	// ConditionTypeSynchronized indicates whether the resource is synchronized with the remote system
+	ConditionTypeSynchronized = "Synchronized"
+	// ConditionTypeRemoteMissing indicates whether the remote resource was deleted outside of the operator
+	ConditionTypeRemoteMissing = "RemoteMissing"
+)
+
+// RemoteDeletionPolicy defines how the operator reacts when the remote resource
+// is deleted outside of Kubernetes
+// +kubebuilder:validation:Enum=Recreate;Report
+type RemoteDeletionPolicy string
+
+const (
+	// RemoteDeletionPolicyRecreate recreates the missing remote resource
+	RemoteDeletionPolicyRecreate RemoteDeletionPolicy = "Recreate"
+	// RemoteDeletionPolicyReport only reports the missing remote resource
+	RemoteDeletionPolicyReport RemoteDeletionPolicy = "Report"
+)
+
+// RemoteOutputs holds the outputs common to every remote resource, as returned by the remote API
+type RemoteOutputs struct {
+	// URI is the URI of the remote resource
+	// +kubebuilder:validation:Optional
+	URI string `json:"uri,omitempty"`
+
+	// State is the state of the remote resource (e.g., "Active")
+	// +kubebuilder:validation:Optional
+	State string `json:"state,omitempty"`
+
+	// CreationDate is when the remote resource was created
+	// +kubebuilder:validation:Optional
+	CreationDate string `json:"creationDate,omitempty"`
+
+	// CreatedBy is the user that created the remote resource
+	// +kubebuilder:validation:Optional
+	CreatedBy string `json:"createdBy,omitempty"`
+
+	// UpdateDate is when the remote resource was last updated
+	// +kubebuilder:validation:Optional
+	UpdateDate string `json:"updateDate,omitempty"`
+
+	// UpdatedBy is the user that last updated the remote resource
+	// +kubebuilder:validation:Optional
+	UpdatedBy string `json:"updatedBy,omitempty"`
+
+	// Version is the version of the remote resource
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+}
+
+// ResourceReference represents a reference to another resource, either to its
+// custom resource by name or to a remote resource managed outside of the cluster by ID
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.id)",message="exactly one of name or id must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.id) || !has(self.namespace)",message="namespace can't be set with id"
+type ResourceReference struct {
+	// Name is the name of the referenced resource
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the referenced resource, defaulted to the namespace of the referencing resource
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Namespace string `json:"namespace,omitempty"`
+	// ID is the Aruba Cloud ID of a remote resource without a custom resource. It is
+	// checked with the remote API the first time it is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	ID string `json:"id,omitempty"`
+}
+
+// ConnectionSecretReference names the Secret the connection details of a resource are written to
+type ConnectionSecretReference struct {
+	// Name is the name of the Secret, in the namespace of the resource
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+}
+
+// ProviderConfigReference references a ProviderConfig
+type ProviderConfigReference struct {
+	// Name is the name of the ProviderConfig
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+}
+
+// FieldError describes a field of the spec rejected by the remote API
+type FieldError struct {
+	// Field is the path of the rejected field in the resource (e.g., "spec.network.address")
+	// +kubebuilder:validation:Optional
+	Field string `json:"field,omitempty"`
+
+	// Message explains why the field was rejected
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// LastError describes the last error returned by the remote API
+type LastError struct {
+	// HTTPStatus is the HTTP status code returned by the remote API
+	// +kubebuilder:validation:Optional
+	HTTPStatus int `json:"httpStatus,omitempty"`
+
+	// Type is the error type returned by the remote API
+	// +kubebuilder:validation:Optional
+	Type string `json:"type,omitempty"`
+
+	// Title is the human-readable summary of the error
+	// +kubebuilder:validation:Optional
+	Title string `json:"title,omitempty"`
+
+	// TraceID identifies the failed request in the remote system
+	// +kubebuilder:validation:Optional
+	TraceID string `json:"traceId,omitempty"`
+
+	// ParentID identifies the parent span of the failed request in the remote system
+	// +kubebuilder:validation:Optional
+	ParentID string `json:"parentId,omitempty"`
+
+	// FieldErrors lists the fields rejected by the remote API
+	// +kubebuilder:validation:Optional
+	FieldErrors []FieldError `json:"fieldErrors,omitempty"`
+
+	// ObservedTime is when the error was returned by the remote API
+	// +kubebuilder:validation:Optional
+	ObservedTime metav1.Time `json:"observedTime,omitempty"`
+}
+
+// Common status for all resources
+type ResourceStatus struct {
+	// Phase represents the current phase of the resource
+	// +kubebuilder:validation:Optional
+	Phase ResourcePhase `json:"phase,omitempty"`
+
+	// Message provides human-readable information about the current state
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// ResourceID is the unique identifier of the resource in the remote system
+	// +kubebuilder:validation:Optional
+	ResourceID string `json:"resourceID,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// PhaseStartTime tracks when the current phase started
+	// +kubebuilder:validation:Optional
+	PhaseStartTime *metav1.Time `json:"phaseStartTime,omitempty"`
+
+	// LastError holds the details of the last error returned by the remote API
+	// +kubebuilder:validation:Optional
+	LastError *LastError `json:"lastError,omitempty"`
+
+	// AppliedTags are the tags last sent to the remote API, including the
+	// propagated labels and the operator-managed tags
+	// +kubebuilder:validation:Optional
+	AppliedTags []string `json:"appliedTags,omitempty"`
+
+	// DefaultedFields lists the spec fields filled from the ArubaDefaults of the namespace
+	// +kubebuilder:validation:Optional
+	DefaultedFields []string `json:"defaultedFields,omitempty"`
+
+	// ValidatedReferenceIDs lists the referenced remote IDs, as Kind/ID, found by the remote API
+	// +kubebuilder:validation:Optional
+	ValidatedReferenceIDs []string `json:"validatedReferenceIDs,omitempty"`
+
+	// Remote holds the outputs of the remote resource, refreshed while the resource is Created
+	// +kubebuilder:validation:Optional
+	Remote *RemoteOutputs `json:"remote,omitempty"`
+
+	// Conditions represent the latest available observations of the Resource state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
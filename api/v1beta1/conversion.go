@@ -0,0 +1,298 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+const (
+	// HubDataAnnotation preserves the v1alpha1 fields that can't be represented in v1beta1
+	HubDataAnnotation = "arubacloud.com/v1alpha1-data"
+	// SpokeDataAnnotation preserves the v1beta1 fields that can't be represented in v1alpha1
+	SpokeDataAnnotation = "arubacloud.com/v1beta1-data"
+
+	// portAll is the v1alpha1 port value matching every port
+	portAll = "ALL"
+)
+
+// hubData holds the v1alpha1 values lost when converting to v1beta1
+type hubData struct {
+	Tenant string   `json:"tenant,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	Port   *string  `json:"port,omitempty"`
+}
+
+// spokeData holds the v1beta1 values lost when converting to v1alpha1
+type spokeData struct {
+	Tags  map[string]string `json:"tags,omitempty"`
+	Ports *PortRange        `json:"ports,omitempty"`
+}
+
+// convertObjectMeta copies src into dst dropping the data annotation of src's version
+func convertObjectMeta(src *metav1.ObjectMeta, dst *metav1.ObjectMeta, dataAnnotation string) {
+	src.DeepCopyInto(dst)
+	delete(dst.Annotations, dataAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+}
+
+// readAnnotationData decodes the data stored in the annotation key of obj, if any
+func readAnnotationData(obj metav1.Object, key string, data any) error {
+	value, ok := obj.GetAnnotations()[key]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(value), data); err != nil {
+		return fmt.Errorf("failed to decode annotation %s: %w", key, err)
+	}
+	return nil
+}
+
+// writeAnnotationData stores data in the annotation key of obj, unless data is empty
+func writeAnnotationData(obj metav1.Object, key string, data any, empty bool) error {
+	if empty {
+		return nil
+	}
+	value, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode annotation %s: %w", key, err)
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = string(value)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// isEmpty reports whether no value has to be preserved
+func (d *hubData) isEmpty() bool {
+	return d.Tenant == "" && d.Tags == nil && d.Port == nil
+}
+
+// isEmpty reports whether no value has to be preserved
+func (d *spokeData) isEmpty() bool {
+	return d.Tags == nil && d.Ports == nil
+}
+
+// tagsFromList converts v1alpha1 "key=value" tags into a map, a tag without "=" maps to an empty value
+func tagsFromList(tags []string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, "=")
+		m[key] = value
+	}
+	return m
+}
+
+// tagsToList converts map tags into sorted v1alpha1 "key=value" tags
+func tagsToList(tags map[string]string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	list := make([]string, 0, len(tags))
+	for key, value := range tags {
+		if value == "" {
+			list = append(list, key)
+			continue
+		}
+		list = append(list, key+"="+value)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// convertTagsTo converts spoke tags to hub tags, preferring the preserved hub tags while they still match
+func convertTagsTo(src map[string]string, preserved *hubData, lost *spokeData) []string {
+	tags := tagsToList(src)
+	if preserved.Tags != nil && equality.Semantic.DeepEqual(tagsFromList(preserved.Tags), src) {
+		tags = preserved.Tags
+	}
+	if !equality.Semantic.DeepEqual(tagsFromList(tags), src) {
+		lost.Tags = src
+	}
+	return tags
+}
+
+// convertTagsFrom converts hub tags to spoke tags, preferring the preserved spoke tags while they still match
+func convertTagsFrom(src []string, preserved *spokeData, lost *hubData) map[string]string {
+	tags := tagsFromList(src)
+	if preserved.Tags != nil && equality.Semantic.DeepEqual(tagsToList(preserved.Tags), src) {
+		tags = preserved.Tags
+	}
+	if !equality.Semantic.DeepEqual(tagsToList(tags), src) {
+		lost.Tags = src
+	}
+	return tags
+}
+
+// portRangeFromString parses a v1alpha1 port, "ALL" and unparsable values map to all ports
+func portRangeFromString(port string) *PortRange {
+	if port == portAll {
+		return nil
+	}
+	from, to, isRange := strings.Cut(port, "-")
+	fromPort, err := strconv.ParseInt(from, 10, 32)
+	if err != nil {
+		return nil
+	}
+	if !isRange {
+		return &PortRange{From: int32(fromPort)}
+	}
+	toPort, err := strconv.ParseInt(to, 10, 32)
+	if err != nil {
+		return nil
+	}
+	return &PortRange{From: int32(fromPort), To: int32(toPort)}
+}
+
+// portRangeToString formats ports as a v1alpha1 port
+func portRangeToString(ports *PortRange) string {
+	if ports == nil {
+		return portAll
+	}
+	if ports.To == 0 {
+		return strconv.Itoa(int(ports.From))
+	}
+	return fmt.Sprintf("%d-%d", ports.From, ports.To)
+}
+
+// convertPortsTo converts spoke ports to a hub port, preferring the preserved hub port while it still matches
+func convertPortsTo(src *PortRange, preserved *hubData, lost *spokeData) string {
+	port := portRangeToString(src)
+	if preserved.Port != nil && equality.Semantic.DeepEqual(portRangeFromString(*preserved.Port), src) {
+		port = *preserved.Port
+	}
+	if !equality.Semantic.DeepEqual(portRangeFromString(port), src) {
+		lost.Ports = src.DeepCopy()
+	}
+	return port
+}
+
+// convertPortsFrom converts a hub port to spoke ports, preferring the preserved spoke ports while they still match
+func convertPortsFrom(src string, preserved *spokeData, lost *hubData) *PortRange {
+	ports := portRangeFromString(src)
+	if preserved.Ports != nil && portRangeToString(preserved.Ports) == src {
+		ports = preserved.Ports
+	}
+	if portRangeToString(ports) != src {
+		lost.Port = &src
+	}
+	return ports
+}
+
+// convertResourceStatusTo converts the common spoke status to the hub status
+func convertResourceStatusTo(src *ResourceStatus, dst *v1alpha1.ResourceStatus) {
+	dst.Phase = v1alpha1.ResourcePhase(src.Phase)
+	dst.Message = src.Message
+	dst.ResourceID = src.ResourceID
+	dst.ObservedGeneration = src.ObservedGeneration
+	dst.PhaseStartTime = src.PhaseStartTime.DeepCopy()
+	dst.LastError = nil
+	if src.LastError != nil {
+		dst.LastError = &v1alpha1.LastError{
+			HTTPStatus:   src.LastError.HTTPStatus,
+			Type:         src.LastError.Type,
+			Title:        src.LastError.Title,
+			TraceID:      src.LastError.TraceID,
+			ParentID:     src.LastError.ParentID,
+			ObservedTime: src.LastError.ObservedTime,
+		}
+		for _, fieldError := range src.LastError.FieldErrors {
+			dst.LastError.FieldErrors = append(dst.LastError.FieldErrors, v1alpha1.FieldError(fieldError))
+		}
+	}
+	dst.AppliedTags = slices.Clone(src.AppliedTags)
+	dst.DefaultedFields = slices.Clone(src.DefaultedFields)
+	dst.ValidatedReferenceIDs = slices.Clone(src.ValidatedReferenceIDs)
+	dst.Remote = (*v1alpha1.RemoteOutputs)(src.Remote.DeepCopy())
+	dst.Conditions = nil
+	for _, condition := range src.Conditions {
+		dst.Conditions = append(dst.Conditions, *condition.DeepCopy())
+	}
+}
+
+// convertResourceStatusFrom converts the common hub status to the spoke status
+func convertResourceStatusFrom(src *v1alpha1.ResourceStatus, dst *ResourceStatus) {
+	dst.Phase = ResourcePhase(src.Phase)
+	dst.Message = src.Message
+	dst.ResourceID = src.ResourceID
+	dst.ObservedGeneration = src.ObservedGeneration
+	dst.PhaseStartTime = src.PhaseStartTime.DeepCopy()
+	dst.LastError = nil
+	if src.LastError != nil {
+		dst.LastError = &LastError{
+			HTTPStatus:   src.LastError.HTTPStatus,
+			Type:         src.LastError.Type,
+			Title:        src.LastError.Title,
+			TraceID:      src.LastError.TraceID,
+			ParentID:     src.LastError.ParentID,
+			ObservedTime: src.LastError.ObservedTime,
+		}
+		for _, fieldError := range src.LastError.FieldErrors {
+			dst.LastError.FieldErrors = append(dst.LastError.FieldErrors, FieldError(fieldError))
+		}
+	}
+	dst.AppliedTags = slices.Clone(src.AppliedTags)
+	dst.DefaultedFields = slices.Clone(src.DefaultedFields)
+	dst.ValidatedReferenceIDs = slices.Clone(src.ValidatedReferenceIDs)
+	dst.Remote = (*RemoteOutputs)(src.Remote.DeepCopy())
+	dst.Conditions = nil
+	for _, condition := range src.Conditions {
+		dst.Conditions = append(dst.Conditions, *condition.DeepCopy())
+	}
+}
+
+// convertReferencesTo converts a list of spoke references to hub references
+func convertReferencesTo(src []ResourceReference) []v1alpha1.ResourceReference {
+	if src == nil {
+		return nil
+	}
+	dst := make([]v1alpha1.ResourceReference, len(src))
+	for i, ref := range src {
+		dst[i] = v1alpha1.ResourceReference(ref)
+	}
+	return dst
+}
+
+// convertReferencesFrom converts a list of hub references to spoke references
+func convertReferencesFrom(src []v1alpha1.ResourceReference) []ResourceReference {
+	if src == nil {
+		return nil
+	}
+	dst := make([]ResourceReference, len(src))
+	for i, ref := range src {
+		dst[i] = ResourceReference(ref)
+	}
+	return dst
+}
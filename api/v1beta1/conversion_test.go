@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/randfill"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+const fuzzIterations = 1000
+
+// newFiller returns a filler producing objects without the annotations reserved for conversion
+func newFiller(seed int64) *randfill.Filler {
+	return randfill.NewWithSeed(seed).NilChance(0.2).NumElements(0, 4).Funcs(
+		func(typeMeta *metav1.TypeMeta, c randfill.Continue) {
+			// The apiVersion and kind are set by the conversion webhook, not by the conversion functions
+		},
+		func(meta *metav1.ObjectMeta, c randfill.Continue) {
+			meta.Name = c.String(0)
+			meta.Namespace = c.String(0)
+			c.Fill(&meta.Labels)
+			c.Fill(&meta.Annotations)
+			delete(meta.Annotations, HubDataAnnotation)
+			delete(meta.Annotations, SpokeDataAnnotation)
+		},
+		func(ports *PortRange, c randfill.Continue) {
+			// Mostly valid ranges, with a few arbitrary values to exercise the lossy paths
+			if c.Intn(4) == 0 {
+				ports.From, ports.To = c.Int31(), c.Int31()
+				return
+			}
+			ports.From = c.Int31n(65535) + 1
+			if c.Intn(2) == 0 {
+				ports.To = ports.From + c.Int31n(65536-ports.From)
+			}
+		},
+		func(spec *v1alpha1.SecurityRuleSpec, c randfill.Continue) {
+			c.FillNoCustom(spec)
+			switch c.Intn(4) {
+			case 0:
+				spec.Port = portAll
+			case 1:
+				spec.Port = fmt.Sprint(c.Intn(65535) + 1)
+			case 2:
+				spec.Port = fmt.Sprintf("%d-%d", c.Intn(1000)+1, c.Intn(1000)+1000)
+			}
+		},
+		func(tags *[]string, c randfill.Continue) {
+			// Mix "key=value", "key" and duplicated keys
+			n := c.Intn(4)
+			for i := 0; i < n; i++ {
+				switch c.Intn(3) {
+				case 0:
+					*tags = append(*tags, c.String(0))
+				case 1:
+					*tags = append(*tags, fmt.Sprintf("key%d=%s", c.Intn(3), c.String(0)))
+				default:
+					*tags = append(*tags, fmt.Sprintf("key%d", c.Intn(3)))
+				}
+			}
+		},
+	)
+}
+
+// hubSpokePairs returns a new hub object and a new spoke object for every kind
+func hubSpokePairs() map[string]func() (conversion.Hub, conversion.Convertible) {
+	return map[string]func() (conversion.Hub, conversion.Convertible){
+		"Project":       func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.Project{}, &Project{} },
+		"ElasticIp":     func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.ElasticIp{}, &ElasticIp{} },
+		"BlockStorage":  func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.BlockStorage{}, &BlockStorage{} },
+		"CloudServer":   func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.CloudServer{}, &CloudServer{} },
+		"KeyPair":       func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.KeyPair{}, &KeyPair{} },
+		"Vpc":           func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.Vpc{}, &Vpc{} },
+		"Subnet":        func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.Subnet{}, &Subnet{} },
+		"SecurityGroup": func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.SecurityGroup{}, &SecurityGroup{} },
+		"SecurityRule":  func() (conversion.Hub, conversion.Convertible) { return &v1alpha1.SecurityRule{}, &SecurityRule{} },
+	}
+}
+
+func TestConversion_HubSpokeHub(t *testing.T) {
+	for kind, newPair := range hubSpokePairs() {
+		t.Run(kind, func(t *testing.T) {
+			filler := newFiller(1)
+			for i := 0; i < fuzzIterations; i++ {
+				hub, spoke := newPair()
+				filler.Fill(hub)
+				original := hub.DeepCopyObject()
+
+				require.NoError(t, spoke.ConvertFrom(hub))
+				restored, _ := newPair()
+				require.NoError(t, spoke.ConvertTo(restored))
+
+				if !equality.Semantic.DeepEqual(original, restored) {
+					t.Fatalf("round trip is lossy (-original +restored):\n%s", cmp.Diff(original, restored))
+				}
+			}
+		})
+	}
+}
+
+func TestConversion_SpokeHubSpoke(t *testing.T) {
+	for kind, newPair := range hubSpokePairs() {
+		t.Run(kind, func(t *testing.T) {
+			filler := newFiller(2)
+			for i := 0; i < fuzzIterations; i++ {
+				hub, spoke := newPair()
+				filler.Fill(spoke)
+				original := spoke.DeepCopyObject()
+
+				require.NoError(t, spoke.ConvertTo(hub))
+				_, restored := newPair()
+				require.NoError(t, restored.ConvertFrom(hub))
+
+				if !equality.Semantic.DeepEqual(original, restored) {
+					t.Fatalf("round trip is lossy (-original +restored):\n%s", cmp.Diff(original, restored))
+				}
+			}
+		})
+	}
+}
+
+func TestConversion_SecurityRulePorts(t *testing.T) {
+	tests := []struct {
+		port  string
+		ports *PortRange
+	}{
+		{port: "ALL", ports: nil},
+		{port: "443", ports: &PortRange{From: 443}},
+		{port: "1000-2000", ports: &PortRange{From: 1000, To: 2000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.port, func(t *testing.T) {
+			hub := &v1alpha1.SecurityRule{Spec: v1alpha1.SecurityRuleSpec{Port: tt.port}}
+			spoke := &SecurityRule{}
+			require.NoError(t, spoke.ConvertFrom(hub))
+			assert.Equal(t, tt.ports, spoke.Spec.Ports)
+			assert.Empty(t, spoke.Annotations)
+		})
+	}
+}
+
+func TestConversion_Tags(t *testing.T) {
+	hub := &v1alpha1.Vpc{Spec: v1alpha1.VpcSpec{
+		Tenant:   "test-tenant",
+		Tags:     []string{"env=prod", "managed"},
+		Location: v1alpha1.Location{Value: "ITBG-Bergamo"},
+	}}
+	spoke := &Vpc{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+
+	assert.Equal(t, map[string]string{"env": "prod", "managed": ""}, spoke.Spec.Tags)
+	assert.Equal(t, "ITBG-Bergamo", spoke.Spec.LocationRef)
+	assert.JSONEq(t, `{"tenant":"test-tenant"}`, spoke.Annotations[HubDataAnnotation])
+}
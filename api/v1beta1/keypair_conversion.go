@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this KeyPair to the Hub version (v1alpha1).
+func (src *KeyPair) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.KeyPair)
+	preserved, lost := &hubData{}, &spokeData{}
+	if err := readAnnotationData(src, HubDataAnnotation, preserved); err != nil {
+		return err
+	}
+
+	convertObjectMeta(&src.ObjectMeta, &dst.ObjectMeta, HubDataAnnotation)
+	dst.Spec.Tenant = preserved.Tenant
+	dst.Spec.Tags = convertTagsTo(src.Spec.Tags, preserved, lost)
+	dst.Spec.Location = v1alpha1.Location{Value: src.Spec.LocationRef}
+	dst.Spec.Value = src.Spec.Value
+	dst.Spec.ValueFrom = convertKeyPairValueSourceTo(src.Spec.ValueFrom)
+	dst.Spec.Generate = convertKeyPairGenerationTo(src.Spec.Generate)
+	dst.Spec.RemoteDeletionPolicy = v1alpha1.RemoteDeletionPolicy(src.Spec.RemoteDeletionPolicy)
+	dst.Spec.ProviderConfigRef = nil
+	if src.Spec.ProviderConfigRef != nil {
+		dst.Spec.ProviderConfigRef = &v1alpha1.ProviderConfigReference{Name: src.Spec.ProviderConfigRef.Name}
+	}
+	dst.Spec.ProjectReference = v1alpha1.ResourceReference(src.Spec.ProjectReference)
+	convertResourceStatusTo(&src.Status.ResourceStatus, &dst.Status.ResourceStatus)
+	dst.Status.ProjectID = src.Status.ProjectID
+	dst.Status.PublicKey = src.Status.PublicKey
+	dst.Status.Fingerprint = src.Status.Fingerprint
+	dst.Status.Rotation = convertKeyPairRotationTo(src.Status.Rotation)
+
+	return writeAnnotationData(dst, SpokeDataAnnotation, lost, lost.isEmpty())
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *KeyPair) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.KeyPair)
+	preserved, lost := &spokeData{}, &hubData{}
+	if err := readAnnotationData(src, SpokeDataAnnotation, preserved); err != nil {
+		return err
+	}
+
+	convertObjectMeta(&src.ObjectMeta, &dst.ObjectMeta, SpokeDataAnnotation)
+	lost.Tenant = src.Spec.Tenant
+	dst.Spec.Tags = convertTagsFrom(src.Spec.Tags, preserved, lost)
+	dst.Spec.LocationRef = src.Spec.Location.Value
+	dst.Spec.Value = src.Spec.Value
+	dst.Spec.ValueFrom = convertKeyPairValueSourceFrom(src.Spec.ValueFrom)
+	dst.Spec.Generate = convertKeyPairGenerationFrom(src.Spec.Generate)
+	dst.Spec.RemoteDeletionPolicy = RemoteDeletionPolicy(src.Spec.RemoteDeletionPolicy)
+	dst.Spec.ProviderConfigRef = nil
+	if src.Spec.ProviderConfigRef != nil {
+		dst.Spec.ProviderConfigRef = &ProviderConfigReference{Name: src.Spec.ProviderConfigRef.Name}
+	}
+	dst.Spec.ProjectReference = ResourceReference(src.Spec.ProjectReference)
+	convertResourceStatusFrom(&src.Status.ResourceStatus, &dst.Status.ResourceStatus)
+	dst.Status.ProjectID = src.Status.ProjectID
+	dst.Status.PublicKey = src.Status.PublicKey
+	dst.Status.Fingerprint = src.Status.Fingerprint
+	dst.Status.Rotation = convertKeyPairRotationFrom(src.Status.Rotation)
+
+	return writeAnnotationData(dst, HubDataAnnotation, lost, lost.isEmpty())
+}
+
+func convertKeyPairGenerationTo(src *KeyPairGeneration) *v1alpha1.KeyPairGeneration {
+	if src == nil {
+		return nil
+	}
+	dst := &v1alpha1.KeyPairGeneration{
+		Algorithm:  v1alpha1.KeyPairAlgorithm(src.Algorithm),
+		Bits:       src.Bits,
+		SecretName: src.SecretName,
+	}
+	for _, format := range src.Formats {
+		dst.Formats = append(dst.Formats, v1alpha1.KeyFormat(format))
+	}
+	return dst
+}
+
+func convertKeyPairGenerationFrom(src *v1alpha1.KeyPairGeneration) *KeyPairGeneration {
+	if src == nil {
+		return nil
+	}
+	dst := &KeyPairGeneration{
+		Algorithm:  KeyPairAlgorithm(src.Algorithm),
+		Bits:       src.Bits,
+		SecretName: src.SecretName,
+	}
+	for _, format := range src.Formats {
+		dst.Formats = append(dst.Formats, KeyFormat(format))
+	}
+	return dst
+}
+
+func convertKeyPairValueSourceTo(src *KeyPairValueSource) *v1alpha1.KeyPairValueSource {
+	if src == nil {
+		return nil
+	}
+	return &v1alpha1.KeyPairValueSource{
+		SecretKeyRef:    (*v1alpha1.LocalKeySelector)(src.SecretKeyRef.DeepCopy()),
+		ConfigMapKeyRef: (*v1alpha1.LocalKeySelector)(src.ConfigMapKeyRef.DeepCopy()),
+	}
+}
+
+func convertKeyPairValueSourceFrom(src *v1alpha1.KeyPairValueSource) *KeyPairValueSource {
+	if src == nil {
+		return nil
+	}
+	return &KeyPairValueSource{
+		SecretKeyRef:    (*LocalKeySelector)(src.SecretKeyRef.DeepCopy()),
+		ConfigMapKeyRef: (*LocalKeySelector)(src.ConfigMapKeyRef.DeepCopy()),
+	}
+}
+
+func convertKeyPairRotationTo(src *KeyPairRotation) *v1alpha1.KeyPairRotation {
+	if src == nil {
+		return nil
+	}
+	return &v1alpha1.KeyPairRotation{
+		Phase:               v1alpha1.KeyPairRotationPhase(src.Phase),
+		PreviousFingerprint: src.PreviousFingerprint,
+		Fingerprint:         src.Fingerprint,
+		PreviousResourceID:  src.PreviousResourceID,
+		Message:             src.Message,
+	}
+}
+
+func convertKeyPairRotationFrom(src *v1alpha1.KeyPairRotation) *KeyPairRotation {
+	if src == nil {
+		return nil
+	}
+	return &KeyPairRotation{
+		Phase:               KeyPairRotationPhase(src.Phase),
+		PreviousFingerprint: src.PreviousFingerprint,
+		Fingerprint:         src.Fingerprint,
+		PreviousResourceID:  src.PreviousResourceID,
+		Message:             src.Message,
+	}
+}
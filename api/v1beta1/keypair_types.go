@@ -0,0 +1,233 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LocalKeySelector selects a key of an object in the namespace of the referencing resource
+type LocalKeySelector struct {
+	// Name is the name of the object
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the key of the value in the object
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// KeyPairValueSource selects the Secret or ConfigMap key holding an SSH public key
+// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="exactly one of secretKeyRef or configMapKeyRef must be set"
+type KeyPairValueSource struct {
+	// SecretKeyRef selects a key of a Secret
+	// +kubebuilder:validation:Optional
+	SecretKeyRef *LocalKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap
+	// +kubebuilder:validation:Optional
+	ConfigMapKeyRef *LocalKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// KeyPairAlgorithm is the algorithm of a generated key pair
+// +kubebuilder:validation:Enum=ed25519;rsa
+type KeyPairAlgorithm string
+
+const (
+	// KeyPairAlgorithmEd25519 generates an Ed25519 key pair
+	KeyPairAlgorithmEd25519 KeyPairAlgorithm = "ed25519"
+	// KeyPairAlgorithmRSA generates an RSA key pair
+	KeyPairAlgorithmRSA KeyPairAlgorithm = "rsa"
+)
+
+// KeyFormat is a format the keys of a generated key pair are stored in
+// +kubebuilder:validation:Enum=OpenSSH;PEM
+type KeyFormat string
+
+const (
+	// KeyFormatOpenSSH stores the keys under ssh-privatekey and ssh-publickey
+	KeyFormatOpenSSH KeyFormat = "OpenSSH"
+	// KeyFormatPEM stores the PKCS#8 private key under private.pem and the PKIX public key under public.pem
+	KeyFormatPEM KeyFormat = "PEM"
+)
+
+// KeyPairGeneration describes a key pair generated by the operator
+// +kubebuilder:validation:XValidation:rule="!has(self.bits) || self.algorithm == 'rsa'",message="bits is only supported by the rsa algorithm"
+type KeyPairGeneration struct {
+	// Algorithm is the algorithm of the generated key pair
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ed25519
+	Algorithm KeyPairAlgorithm `json:"algorithm,omitempty"`
+
+	// Bits is the size of a generated RSA key, 4096 when not set
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=2048
+	// +kubebuilder:validation:Maximum=8192
+	Bits int32 `json:"bits,omitempty"`
+
+	// SecretName is the name of the Secret, in the namespace of the keypair, the keys are stored in
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SecretName string `json:"secretName"`
+
+	// Formats are the formats the keys are stored in
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	// +kubebuilder:default={OpenSSH}
+	Formats []KeyFormat `json:"formats,omitempty"`
+}
+
+// KeyPairSpec defines the desired state of KeyPair.
+// +kubebuilder:validation:XValidation:rule="[has(self.value), has(self.valueFrom), has(self.generate)].filter(x, x).size() == 1",message="exactly one of value, valueFrom or generate must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
+type KeyPairSpec struct {
+	// Tags are key/value labels associated with the keypair
+	// +kubebuilder:validation:Optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// LocationRef is the location of the keypair (e.g., "ITBG-Bergamo")
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="locationRef is immutable"
+	LocationRef string `json:"locationRef"`
+
+	// Value specifies the SSH public key value
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom reads the SSH public key from a key of a Secret or ConfigMap in the namespace of the keypair
+	// +kubebuilder:validation:Optional
+	ValueFrom *KeyPairValueSource `json:"valueFrom,omitempty"`
+
+	// Generate makes the operator generate the key pair and store it in a Secret, instead of
+	// taking the public key from value
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="generate is immutable"
+	Generate *KeyPairGeneration `json:"generate,omitempty"`
+
+	// ProjectReference references the Project that owns this keypair
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
+	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote keypair is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
+}
+
+// KeyPairStatus defines the observed state of KeyPair.
+type KeyPairStatus struct {
+	ResourceStatus `json:",inline"`
+
+	// ProjectID is the project ID where this keypair is created
+	// +kubebuilder:validation:Optional
+	ProjectID string `json:"projectID,omitempty"`
+
+	// PublicKey is the public key the remote keypair was created with, in authorized_keys format
+	// +kubebuilder:validation:Optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// Fingerprint is the SHA256 fingerprint of the public key
+	// +kubebuilder:validation:Optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Rotation reports the last rotation of the remote keypair to a new public key
+	// +kubebuilder:validation:Optional
+	Rotation *KeyPairRotation `json:"rotation,omitempty"`
+}
+
+// KeyPairRotationPhase is the progress of a key rotation
+// +kubebuilder:validation:Enum=MovingCloudServers;DeletingPreviousKey;Completed;Blocked
+type KeyPairRotationPhase string
+
+const (
+	// KeyPairRotationMovingCloudServers means the new remote keypair was created and the cloud servers using the previous one are being moved to it
+	KeyPairRotationMovingCloudServers KeyPairRotationPhase = "MovingCloudServers"
+	// KeyPairRotationDeletingPreviousKey means the new remote keypair was created and the previous one is being deleted
+	KeyPairRotationDeletingPreviousKey KeyPairRotationPhase = "DeletingPreviousKey"
+	// KeyPairRotationCompleted means the previous remote keypair was replaced
+	KeyPairRotationCompleted KeyPairRotationPhase = "Completed"
+	// KeyPairRotationBlocked means the API refused to move some cloud servers to the new keypair, they keep the previous one
+	KeyPairRotationBlocked KeyPairRotationPhase = "Blocked"
+)
+
+// KeyPairRotation reports the rotation of the remote keypair to a new public key
+type KeyPairRotation struct {
+	// Phase is the progress of the rotation
+	Phase KeyPairRotationPhase `json:"phase"`
+
+	// PreviousFingerprint is the fingerprint of the key being replaced
+	// +kubebuilder:validation:Optional
+	PreviousFingerprint string `json:"previousFingerprint,omitempty"`
+
+	// Fingerprint is the fingerprint of the new key
+	// +kubebuilder:validation:Optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// PreviousResourceID is the ID of the remote keypair being replaced, deleted once no cloud server uses it
+	// +kubebuilder:validation:Optional
+	PreviousResourceID string `json:"previousResourceID,omitempty"`
+
+	// Message details the progress of the rotation
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=kp
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Resource ID",type="string",JSONPath=".status.resourceID"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="Fingerprint",type="string",JSONPath=".status.fingerprint",priority=1
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// KeyPair is the Schema for the keypairs API.
+type KeyPair struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeyPairSpec   `json:"spec,omitempty"`
+	Status KeyPairStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeyPairList contains a list of KeyPair.
+type KeyPairList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeyPair `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeyPair{}, &KeyPairList{})
+}
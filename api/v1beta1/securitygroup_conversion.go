@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this SecurityGroup to the Hub version (v1alpha1).
+func (src *SecurityGroup) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.SecurityGroup)
+	preserved, lost := &hubData{}, &spokeData{}
+	if err := readAnnotationData(src, HubDataAnnotation, preserved); err != nil {
+		return err
+	}
+
+	convertObjectMeta(&src.ObjectMeta, &dst.ObjectMeta, HubDataAnnotation)
+	dst.Spec.Tenant = preserved.Tenant
+	dst.Spec.Tags = convertTagsTo(src.Spec.Tags, preserved, lost)
+	dst.Spec.Location = v1alpha1.Location{Value: src.Spec.LocationRef}
+	dst.Spec.Default = src.Spec.Default
+	dst.Spec.RemoteDeletionPolicy = v1alpha1.RemoteDeletionPolicy(src.Spec.RemoteDeletionPolicy)
+	dst.Spec.ProviderConfigRef = nil
+	if src.Spec.ProviderConfigRef != nil {
+		dst.Spec.ProviderConfigRef = &v1alpha1.ProviderConfigReference{Name: src.Spec.ProviderConfigRef.Name}
+	}
+	dst.Spec.VpcReference = v1alpha1.ResourceReference(src.Spec.VpcReference)
+	dst.Spec.ProjectReference = v1alpha1.ResourceReference(src.Spec.ProjectReference)
+	convertResourceStatusTo(&src.Status.ResourceStatus, &dst.Status.ResourceStatus)
+	dst.Status.ProjectID = src.Status.ProjectID
+	dst.Status.VpcID = src.Status.VpcID
+
+	return writeAnnotationData(dst, SpokeDataAnnotation, lost, lost.isEmpty())
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *SecurityGroup) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.SecurityGroup)
+	preserved, lost := &spokeData{}, &hubData{}
+	if err := readAnnotationData(src, SpokeDataAnnotation, preserved); err != nil {
+		return err
+	}
+
+	convertObjectMeta(&src.ObjectMeta, &dst.ObjectMeta, SpokeDataAnnotation)
+	lost.Tenant = src.Spec.Tenant
+	dst.Spec.Tags = convertTagsFrom(src.Spec.Tags, preserved, lost)
+	dst.Spec.LocationRef = src.Spec.Location.Value
+	dst.Spec.Default = src.Spec.Default
+	dst.Spec.RemoteDeletionPolicy = RemoteDeletionPolicy(src.Spec.RemoteDeletionPolicy)
+	dst.Spec.ProviderConfigRef = nil
+	if src.Spec.ProviderConfigRef != nil {
+		dst.Spec.ProviderConfigRef = &ProviderConfigReference{Name: src.Spec.ProviderConfigRef.Name}
+	}
+	dst.Spec.VpcReference = ResourceReference(src.Spec.VpcReference)
+	dst.Spec.ProjectReference = ResourceReference(src.Spec.ProjectReference)
+	convertResourceStatusFrom(&src.Status.ResourceStatus, &dst.Status.ResourceStatus)
+	dst.Status.ProjectID = src.Status.ProjectID
+	dst.Status.VpcID = src.Status.VpcID
+
+	return writeAnnotationData(dst, HubDataAnnotation, lost, lost.isEmpty())
+}
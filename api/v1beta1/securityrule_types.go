@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// SecurityRuleTarget defines the target of a security rule
+// +kubebuilder:validation:XValidation:rule="self.kind != 'Ip' || isCIDR(self.value) || isIP(self.value)",message="value must be an IP address or a CIDR when kind is Ip"
+type SecurityRuleTarget struct {
+	// Kind specifies the type of target (e.g., "Ip", "SecurityGroup")
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Ip;SecurityGroup
+	Kind string `json:"kind"`
+
+	// Value specifies the target value (e.g., IP address/CIDR or security group reference)
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
+}
+
+// PortRange defines a single port or an inclusive range of ports
+// +kubebuilder:validation:XValidation:rule="!has(self.to) || self.to >= self.from",message="to must not be lower than from"
+type PortRange struct {
+	// From is the first port of the range, or the only port when To is not set
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	From int32 `json:"from"`
+
+	// To is the last port of the range
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	To int32 `json:"to,omitempty"`
+}
+
+// SecurityRuleSpec defines the desired state of SecurityRule.
+// +kubebuilder:validation:XValidation:rule="!(self.protocol in ['ICMP', 'ALL']) || !has(self.ports)",message="ports must not be set when protocol is ICMP or ALL"
+// +kubebuilder:validation:XValidation:rule="has(self.providerConfigRef) == has(oldSelf.providerConfigRef) && (!has(self.providerConfigRef) || self.providerConfigRef == oldSelf.providerConfigRef)",message="providerConfigRef is immutable"
+type SecurityRuleSpec struct {
+	// Tags are key/value labels associated with the security rule
+	// +kubebuilder:validation:Optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// LocationRef is the location of the security rule (e.g., "ITBG-Bergamo")
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="locationRef is immutable"
+	LocationRef string `json:"locationRef"`
+
+	// Protocol specifies the network protocol (TCP, UDP, ICMP, etc.)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=TCP;UDP;ICMP;ALL
+	Protocol string `json:"protocol"`
+
+	// Ports specifies the port or port range, all ports when not set
+	// +kubebuilder:validation:Optional
+	Ports *PortRange `json:"ports,omitempty"`
+
+	// Direction specifies the rule direction (Ingress or Egress)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Ingress;Egress
+	Direction string `json:"direction"`
+
+	// Target specifies the target of the security rule
+	// +kubebuilder:validation:Required
+	Target SecurityRuleTarget `json:"target"`
+
+	// SecurityGroupReference references the ArubaSecurityGroup that owns this rule
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="securityGroupReference is immutable"
+	SecurityGroupReference ResourceReference `json:"securityGroupReference"`
+
+	// VpcReference references the ArubaVpc that contains the security group
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="vpcReference is immutable"
+	VpcReference ResourceReference `json:"vpcReference"`
+
+	// ProjectReference references the Project that owns this security rule
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectReference is immutable"
+	ProjectReference ResourceReference `json:"projectReference"`
+
+	// ProviderConfigRef references the ProviderConfig holding the API endpoint and credentials,
+	// the operator configuration is used when it is not set
+	// +kubebuilder:validation:Optional
+	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// RemoteDeletionPolicy defines how to react when the remote security rule is deleted outside of the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Report
+	RemoteDeletionPolicy RemoteDeletionPolicy `json:"remoteDeletionPolicy,omitempty"`
+}
+
+// SecurityRuleStatus defines the observed state of SecurityRule.
+type SecurityRuleStatus struct {
+	ResourceStatus `json:",inline"`
+
+	// ProjectID is the project ID where this security rule is created
+	// +kubebuilder:validation:Optional
+	ProjectID string `json:"projectID,omitempty"`
+
+	// VpcID is the VPC ID where this security rule is created
+	// +kubebuilder:validation:Optional
+	VpcID string `json:"vpcID,omitempty"`
+
+	// SecurityGroupID is the security group ID that contains this rule
+	// +kubebuilder:validation:Optional
+	SecurityGroupID string `json:"securityGroupID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=sr
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Resource ID",type="string",JSONPath=".status.resourceID"
+// +kubebuilder:printcolumn:name="Protocol",type="string",JSONPath=".spec.protocol"
+// +kubebuilder:printcolumn:name="Direction",type="string",JSONPath=".spec.direction"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.remote.state",priority=1
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".status.remote.uri",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SecurityRule is the Schema for the securityrules API.
+type SecurityRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecurityRuleSpec   `json:"spec,omitempty"`
+	Status SecurityRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecurityRuleList contains a list of SecurityRule.
+type SecurityRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SecurityRule{}, &SecurityRuleList{})
+}
@@ -31,12 +31,14 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/config"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -44,8 +46,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1beta1"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/controller"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/schedule"
+	webhookv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -59,6 +64,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -226,6 +232,13 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "80aa3464.arubacloud.com",
+		// Secrets and ConfigMaps are read directly, caching them would keep every one of the
+		// cluster in memory. The controllers only watch their metadata.
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+			},
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -273,6 +286,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup BlockStorageSnapshot controller
+	blockStorageSnapshotReconciler := controller.NewBlockStorageSnapshotReconciler(baseReconciler)
+	if err = blockStorageSnapshotReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BlockStorageSnapshot")
+		os.Exit(1)
+	}
+
 	// Setup CloudServer controller
 	cloudServerReconciler := controller.NewCloudServerReconciler(baseReconciler)
 	if err = cloudServerReconciler.SetupWithManager(mgr); err != nil {
@@ -315,6 +335,89 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup ProviderConfig controller
+	providerConfigReconciler := controller.NewProviderConfigReconciler(baseReconciler)
+	if err = providerConfigReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ProviderConfig")
+		os.Exit(1)
+	}
+
+	// Setup ServerSchedule scheduler
+	if err = mgr.Add(schedule.NewScheduler(mgr)); err != nil {
+		setupLog.Error(err, "unable to add scheduler", "scheduler", "ServerSchedule")
+		os.Exit(1)
+	}
+
+	// Setup SnapshotPolicy scheduler
+	if err = mgr.Add(schedule.NewSnapshotScheduler(mgr)); err != nil {
+		setupLog.Error(err, "unable to add scheduler", "scheduler", "SnapshotPolicy")
+		os.Exit(1)
+	}
+
+	// Setup validating webhooks, set ENABLE_WEBHOOKS=false to run the manager locally without certificates
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookv1alpha1.SetupProjectWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Project")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupElasticIpWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ElasticIp")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupBlockStorageWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BlockStorage")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupBlockStorageSnapshotWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BlockStorageSnapshot")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupCloudServerWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CloudServer")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupKeyPairWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KeyPair")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupSecurityGroupWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SecurityGroup")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupSecurityRuleWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SecurityRule")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupSubnetWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Subnet")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupVpcWebhookWithManager(mgr, baseReconciler.Tenants); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Vpc")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupServerScheduleWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ServerSchedule")
+			os.Exit(1)
+		}
+
+		if err = webhookv1alpha1.SetupSnapshotPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SnapshotPolicy")
+			os.Exit(1)
+		}
+	}
+	// +kubebuilder:scaffold:builder
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -5,11 +5,6 @@ import (
 	"fmt"
 )
 
-type BlockStorageStatus struct {
-	State        string `json:"state"`
-	CreationDate string `json:"creationDate"`
-}
-
 type BlockStorageCategory struct {
 	Name     string `json:"name"`
 	Provider string `json:"provider"`
@@ -32,18 +27,13 @@ type BlockStorageProject struct {
 }
 
 type BlockStorageMetadata struct {
-	ID           string                `json:"id,omitempty"`
-	URI          string                `json:"uri,omitempty"`
-	Name         string                `json:"name"`
-	Tags         []string              `json:"tags,omitempty"`
-	Location     BlockStorageLocation  `json:"location"`
-	Project      *BlockStorageProject  `json:"project,omitempty"`
-	Category     *BlockStorageCategory `json:"category,omitempty"`
-	CreationDate string                `json:"creationDate,omitempty"`
-	CreatedBy    string                `json:"createdBy,omitempty"`
-	UpdateDate   string                `json:"updateDate,omitempty"`
-	UpdatedBy    string                `json:"updatedBy,omitempty"`
-	Version      string                `json:"version,omitempty"`
+	ID       string                `json:"id,omitempty"`
+	Name     string                `json:"name"`
+	Tags     []string              `json:"tags,omitempty"`
+	Location BlockStorageLocation  `json:"location"`
+	Project  *BlockStorageProject  `json:"project,omitempty"`
+	Category *BlockStorageCategory `json:"category,omitempty"`
+	RemoteMetadata
 }
 
 type BlockStorageProperties struct {
@@ -53,6 +43,18 @@ type BlockStorageProperties struct {
 	Type          string `json:"type,omitempty"`
 	Bootable      bool   `json:"bootable,omitempty"`
 	Image         string `json:"image,omitempty"`
+	// Snapshot references the snapshot the block storage is restored from
+	Snapshot *BlockStorageSource `json:"snapshot,omitempty"`
+	// SourceVolume references the block storage the block storage is cloned from
+	SourceVolume *BlockStorageSource `json:"sourceVolume,omitempty"`
+}
+
+// BlockStorageSource is the data source of a block storage in properties.snapshot or
+// properties.sourceVolume. Like the volume of a snapshot, the API takes a URI reference
+// rather than a bare ID: /projects/{project}/providers/Aruba.Storage/snapshots/{id} for a
+// snapshot and /projects/{project}/providers/Aruba.Storage/blockStorages/{id} for a block storage.
+type BlockStorageSource struct {
+	URI string `json:"uri"`
 }
 
 type BlockStorageRequest struct {
@@ -63,7 +65,7 @@ type BlockStorageRequest struct {
 type BlockStorageResponse struct {
 	Metadata   BlockStorageMetadata   `json:"metadata"`
 	Properties BlockStorageProperties `json:"properties"`
-	Status     *BlockStorageStatus    `json:"status,omitempty"`
+	Status     *RemoteStatus          `json:"status,omitempty"`
 }
 
 type BlockStorageListResponse struct {
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+type SnapshotLocation struct {
+	Code    string `json:"code,omitempty"`
+	Country string `json:"country,omitempty"`
+	City    string `json:"city,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Value   string `json:"value"`
+}
+
+type SnapshotProject struct {
+	ID string `json:"id"`
+}
+
+type SnapshotMetadata struct {
+	ID       string           `json:"id,omitempty"`
+	Name     string           `json:"name"`
+	Tags     []string         `json:"tags,omitempty"`
+	Location SnapshotLocation `json:"location"`
+	Project  *SnapshotProject `json:"project,omitempty"`
+	RemoteMetadata
+}
+
+type SnapshotVolume struct {
+	URI string `json:"uri"`
+}
+
+type SnapshotProperties struct {
+	Volume SnapshotVolume `json:"volume"`
+	SizeGb int32          `json:"sizeGb,omitempty"`
+}
+
+type SnapshotRequest struct {
+	Metadata   SnapshotMetadata   `json:"metadata"`
+	Properties SnapshotProperties `json:"properties"`
+}
+
+type SnapshotResponse struct {
+	Metadata   SnapshotMetadata   `json:"metadata"`
+	Properties SnapshotProperties `json:"properties"`
+	Status     *RemoteStatus      `json:"status,omitempty"`
+}
+
+type SnapshotListResponse struct {
+	Total  int                `json:"total"`
+	Values []SnapshotResponse `json:"values"`
+}
+
+// CreateSnapshot creates a new snapshot of a block storage via API
+func (c *HelperClient) CreateSnapshot(ctx context.Context, projectID string, req SnapshotRequest) (*SnapshotResponse, error) {
+	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Storage/snapshots", projectID)
+	var snapshotResp SnapshotResponse
+	if err := c.DoAPIRequest(ctx, "POST", endpoint, req, &snapshotResp); err != nil {
+		return nil, err
+	}
+	return &snapshotResp, nil
+}
+
+// GetSnapshot retrieves a snapshot via API
+func (c *HelperClient) GetSnapshot(ctx context.Context, projectID, snapshotID string) (*SnapshotResponse, error) {
+	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Storage/snapshots/%s", projectID, snapshotID)
+	var snapshotResp SnapshotResponse
+	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &snapshotResp); err != nil {
+		return nil, err
+	}
+	return &snapshotResp, nil
+}
+
+// UpdateSnapshot updates the name and tags of an existing snapshot via API
+func (c *HelperClient) UpdateSnapshot(ctx context.Context, projectID, snapshotID string, req SnapshotRequest) (*SnapshotResponse, error) {
+	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Storage/snapshots/%s", projectID, snapshotID)
+	var snapshotResp SnapshotResponse
+	if err := c.DoAPIRequest(ctx, "PUT", endpoint, req, &snapshotResp); err != nil {
+		return nil, err
+	}
+	return &snapshotResp, nil
+}
+
+// DeleteSnapshot deletes a snapshot via API
+func (c *HelperClient) DeleteSnapshot(ctx context.Context, projectID, snapshotID string) error {
+	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Storage/snapshots/%s", projectID, snapshotID)
+	return c.DoAPIRequest(ctx, "DELETE", endpoint, nil, nil)
+}
+
+// ListSnapshots lists all snapshots in a project
+func (c *HelperClient) ListSnapshots(ctx context.Context, projectID string) (*SnapshotListResponse, error) {
+	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Storage/snapshots", projectID)
+	var snapshotList SnapshotListResponse
+	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &snapshotList); err != nil {
+		return nil, err
+	}
+	return &snapshotList, nil
+}
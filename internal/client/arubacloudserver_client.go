@@ -5,10 +5,11 @@ import (
 	"fmt"
 )
 
-type CloudServerStatus struct {
-	State        string `json:"state"`
-	CreationDate string `json:"creationDate"`
-}
+// Remote states of a cloud server reporting its power state
+const (
+	CloudServerStateActive  = "Active"
+	CloudServerStateStopped = "Stopped"
+)
 
 type CloudServerCategory struct {
 	Name     string `json:"name"`
@@ -36,18 +37,13 @@ type CloudServerResourceReference struct {
 }
 
 type CloudServerMetadata struct {
-	ID           string               `json:"id,omitempty"`
-	URI          string               `json:"uri,omitempty"`
-	Name         string               `json:"name"`
-	Tags         []string             `json:"tags,omitempty"`
-	Location     CloudServerLocation  `json:"location"`
-	Project      *CloudServerProject  `json:"project,omitempty"`
-	Category     *CloudServerCategory `json:"category,omitempty"`
-	CreationDate string               `json:"creationDate,omitempty"`
-	CreatedBy    string               `json:"createdBy,omitempty"`
-	UpdateDate   string               `json:"updateDate,omitempty"`
-	UpdatedBy    string               `json:"updatedBy,omitempty"`
-	Version      string               `json:"version,omitempty"`
+	ID       string               `json:"id,omitempty"`
+	Name     string               `json:"name"`
+	Tags     []string             `json:"tags,omitempty"`
+	Location CloudServerLocation  `json:"location"`
+	Project  *CloudServerProject  `json:"project,omitempty"`
+	Category *CloudServerCategory `json:"category,omitempty"`
+	RemoteMetadata
 }
 
 type CloudServerProperties struct {
@@ -61,6 +57,17 @@ type CloudServerProperties struct {
 	Subnets        []CloudServerResourceReference `json:"subnets"`
 	SecurityGroups []CloudServerResourceReference `json:"securityGroups"`
 	IPAddress      string                         `json:"ipAddress,omitempty"`
+	Flavor         *CloudServerFlavor             `json:"flavor,omitempty"`
+}
+
+// CloudServerFlavor describes the flavor returned with a cloud server
+type CloudServerFlavor struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Category string `json:"category,omitempty"`
+	CPU      int32  `json:"cpu,omitempty"`
+	RAM      int32  `json:"ram,omitempty"`
+	HD       int32  `json:"hd,omitempty"`
 }
 
 type CloudServerRequest struct {
@@ -71,7 +78,7 @@ type CloudServerRequest struct {
 type CloudServerResponse struct {
 	Metadata   CloudServerMetadata   `json:"metadata"`
 	Properties CloudServerProperties `json:"properties"`
-	Status     *CloudServerStatus    `json:"status,omitempty"`
+	Status     *RemoteStatus         `json:"status,omitempty"`
 }
 
 type CloudServerListResponse struct {
@@ -115,6 +122,18 @@ func (c *HelperClient) DeleteCloudServer(ctx context.Context, projectID, cloudSe
 	return c.DoAPIRequest(ctx, "DELETE", endpoint, nil, nil)
 }
 
+// PowerOnCloudServer powers on a cloud server via API
+func (c *HelperClient) PowerOnCloudServer(ctx context.Context, projectID, cloudServerID string) error {
+	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Compute/cloudServers/%s/poweron", projectID, cloudServerID)
+	return c.DoAPIRequest(ctx, "POST", endpoint, nil, nil)
+}
+
+// PowerOffCloudServer powers off a cloud server via API
+func (c *HelperClient) PowerOffCloudServer(ctx context.Context, projectID, cloudServerID string) error {
+	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Compute/cloudServers/%s/poweroff", projectID, cloudServerID)
+	return c.DoAPIRequest(ctx, "POST", endpoint, nil, nil)
+}
+
 type AttachDetachDataVolumesRequest struct {
 	VolumesToAttach []CloudServerResourceReference `json:"volumesToAttach,omitempty"`
 	VolumesToDetach []CloudServerResourceReference `json:"volumesToDetach,omitempty"`
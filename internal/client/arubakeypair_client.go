@@ -5,11 +5,6 @@ import (
 	"fmt"
 )
 
-type KeyPairStatus struct {
-	State        string `json:"state"`
-	CreationDate string `json:"creationDate"`
-}
-
 type KeyPairCategory struct {
 	Name     string `json:"name"`
 	Provider string `json:"provider"`
@@ -32,18 +27,13 @@ type KeyPairProject struct {
 }
 
 type KeyPairMetadata struct {
-	ID           string           `json:"id,omitempty"`
-	URI          string           `json:"uri,omitempty"`
-	Name         string           `json:"name"`
-	Tags         []string         `json:"tags,omitempty"`
-	Location     KeyPairLocation  `json:"location"`
-	Project      *KeyPairProject  `json:"project,omitempty"`
-	Category     *KeyPairCategory `json:"category,omitempty"`
-	CreationDate string           `json:"creationDate,omitempty"`
-	CreatedBy    string           `json:"createdBy,omitempty"`
-	UpdateDate   string           `json:"updateDate,omitempty"`
-	UpdatedBy    string           `json:"updatedBy,omitempty"`
-	Version      string           `json:"version,omitempty"`
+	ID       string           `json:"id,omitempty"`
+	Name     string           `json:"name"`
+	Tags     []string         `json:"tags,omitempty"`
+	Location KeyPairLocation  `json:"location"`
+	Project  *KeyPairProject  `json:"project,omitempty"`
+	Category *KeyPairCategory `json:"category,omitempty"`
+	RemoteMetadata
 }
 
 type KeyPairProperties struct {
@@ -62,7 +52,7 @@ type KeyPairUpdateRequest struct {
 type KeyPairResponse struct {
 	Metadata   KeyPairMetadata    `json:"metadata"`
 	Properties *KeyPairProperties `json:"properties,omitempty"`
-	Status     *KeyPairStatus     `json:"status,omitempty"`
+	Status     *RemoteStatus      `json:"status,omitempty"`
 }
 
 type KeyPairListResponse struct {
@@ -5,11 +5,6 @@ import (
 	"fmt"
 )
 
-type ElasticIpStatus struct {
-	State        string `json:"state"`
-	CreationDate string `json:"creationDate"`
-}
-
 type ElasticIpCategory struct {
 	Name     string `json:"name"`
 	Provider string `json:"provider"`
@@ -36,18 +31,13 @@ type ElasticIpBillingPlan struct {
 }
 
 type ElasticIpMetadata struct {
-	ID           string             `json:"id,omitempty"`
-	URI          string             `json:"uri,omitempty"`
-	Name         string             `json:"name"`
-	Tags         []string           `json:"tags,omitempty"`
-	Location     ElasticIpLocation  `json:"location"`
-	Project      *ElasticIpProject  `json:"project,omitempty"`
-	Category     *ElasticIpCategory `json:"category,omitempty"`
-	CreationDate string             `json:"creationDate,omitempty"`
-	CreatedBy    string             `json:"createdBy,omitempty"`
-	UpdateDate   string             `json:"updateDate,omitempty"`
-	UpdatedBy    string             `json:"updatedBy,omitempty"`
-	Version      string             `json:"version,omitempty"`
+	ID       string             `json:"id,omitempty"`
+	Name     string             `json:"name"`
+	Tags     []string           `json:"tags,omitempty"`
+	Location ElasticIpLocation  `json:"location"`
+	Project  *ElasticIpProject  `json:"project,omitempty"`
+	Category *ElasticIpCategory `json:"category,omitempty"`
+	RemoteMetadata
 }
 
 type ElasticIpProperties struct {
@@ -63,7 +53,7 @@ type ElasticIpRequest struct {
 type ElasticIpResponse struct {
 	Metadata   ElasticIpMetadata   `json:"metadata"`
 	Properties ElasticIpProperties `json:"properties"`
-	Status     *ElasticIpStatus    `json:"status,omitempty"`
+	Status     *RemoteStatus       `json:"status,omitempty"`
 }
 
 type ElasticIpListResponse struct {
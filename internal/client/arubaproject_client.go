@@ -9,6 +9,7 @@ type ProjectMetadata struct {
 	ID   string   `json:"id,omitempty"`
 	Name string   `json:"name"`
 	Tags []string `json:"tags,omitempty"`
+	RemoteMetadata
 }
 
 type ProjectProperties struct {
@@ -35,6 +36,16 @@ func (c *HelperClient) CreateProject(ctx context.Context, req ProjectRequest) (*
 	return &projectResp, nil
 }
 
+// GetProject retrieves a project via API
+func (c *HelperClient) GetProject(ctx context.Context, projectID string) (*ProjectResponse, error) {
+	endpoint := fmt.Sprintf("/projects/%s", projectID)
+	var projectResp ProjectResponse
+	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &projectResp); err != nil {
+		return nil, err
+	}
+	return &projectResp, nil
+}
+
 // UpdateProject updates an existing project via API
 func (c *HelperClient) UpdateProject(ctx context.Context, projectID string, req ProjectRequest) (*ProjectResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s", projectID)
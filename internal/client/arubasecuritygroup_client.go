@@ -5,11 +5,6 @@ import (
 	"fmt"
 )
 
-type SecurityGroupStatus struct {
-	State        string `json:"state"`
-	CreationDate string `json:"creationDate"`
-}
-
 type SecurityGroupLocation struct {
 	Code    string `json:"code,omitempty"`
 	Country string `json:"country,omitempty"`
@@ -19,16 +14,11 @@ type SecurityGroupLocation struct {
 }
 
 type SecurityGroupMetadata struct {
-	ID           string                `json:"id,omitempty"`
-	URI          string                `json:"uri,omitempty"`
-	Name         string                `json:"name"`
-	Tags         []string              `json:"tags,omitempty"`
-	Location     SecurityGroupLocation `json:"location"`
-	CreationDate string                `json:"creationDate,omitempty"`
-	CreatedBy    string                `json:"createdBy,omitempty"`
-	UpdateDate   string                `json:"updateDate,omitempty"`
-	UpdatedBy    string                `json:"updatedBy,omitempty"`
-	Version      string                `json:"version,omitempty"`
+	ID       string                `json:"id,omitempty"`
+	Name     string                `json:"name"`
+	Tags     []string              `json:"tags,omitempty"`
+	Location SecurityGroupLocation `json:"location"`
+	RemoteMetadata
 }
 
 type SecurityGroupProperties struct {
@@ -43,7 +33,7 @@ type SecurityGroupRequest struct {
 type SecurityGroupResponse struct {
 	Metadata   SecurityGroupMetadata   `json:"metadata"`
 	Properties SecurityGroupProperties `json:"properties"`
-	Status     *SecurityGroupStatus    `json:"status,omitempty"`
+	Status     *RemoteStatus           `json:"status,omitempty"`
 }
 
 type SecurityGroupListResponse struct {
@@ -5,11 +5,6 @@ import (
 	"fmt"
 )
 
-type SecurityRuleStatus struct {
-	State        string `json:"state"`
-	CreationDate string `json:"creationDate"`
-}
-
 type SecurityRuleTarget struct {
 	Kind  string `json:"kind"`
 	Value string `json:"value"`
@@ -24,16 +19,11 @@ type SecurityRuleLocation struct {
 }
 
 type SecurityRuleMetadata struct {
-	ID           string               `json:"id,omitempty"`
-	URI          string               `json:"uri,omitempty"`
-	Name         string               `json:"name"`
-	Tags         []string             `json:"tags,omitempty"`
-	Location     SecurityRuleLocation `json:"location"`
-	CreationDate string               `json:"creationDate,omitempty"`
-	CreatedBy    string               `json:"createdBy,omitempty"`
-	UpdateDate   string               `json:"updateDate,omitempty"`
-	UpdatedBy    string               `json:"updatedBy,omitempty"`
-	Version      string               `json:"version,omitempty"`
+	ID       string               `json:"id,omitempty"`
+	Name     string               `json:"name"`
+	Tags     []string             `json:"tags,omitempty"`
+	Location SecurityRuleLocation `json:"location"`
+	RemoteMetadata
 }
 
 type SecurityRuleProperties struct {
@@ -51,7 +41,7 @@ type SecurityRuleRequest struct {
 type SecurityRuleResponse struct {
 	Metadata   SecurityRuleMetadata   `json:"metadata"`
 	Properties SecurityRuleProperties `json:"properties"`
-	Status     *SecurityRuleStatus    `json:"status,omitempty"`
+	Status     *RemoteStatus          `json:"status,omitempty"`
 }
 
 type SecurityRuleListResponse struct {
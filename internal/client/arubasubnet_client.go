@@ -5,13 +5,9 @@ import (
 	"fmt"
 )
 
-type SubnetStatus struct {
-	State        string `json:"state"`
-	CreationDate string `json:"creationDate"`
-}
-
 type SubnetNetwork struct {
 	Address string `json:"address"`
+	Gateway string `json:"gateway,omitempty"`
 }
 
 type SubnetDHCP struct {
@@ -19,15 +15,10 @@ type SubnetDHCP struct {
 }
 
 type SubnetMetadata struct {
-	ID           string   `json:"id,omitempty"`
-	URI          string   `json:"uri,omitempty"`
-	Name         string   `json:"name"`
-	Tags         []string `json:"tags,omitempty"`
-	CreationDate string   `json:"creationDate,omitempty"`
-	CreatedBy    string   `json:"createdBy,omitempty"`
-	UpdateDate   string   `json:"updateDate,omitempty"`
-	UpdatedBy    string   `json:"updatedBy,omitempty"`
-	Version      string   `json:"version,omitempty"`
+	ID   string   `json:"id,omitempty"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+	RemoteMetadata
 }
 
 type SubnetProperties struct {
@@ -45,7 +36,7 @@ type SubnetRequest struct {
 type SubnetResponse struct {
 	Metadata   SubnetMetadata   `json:"metadata"`
 	Properties SubnetProperties `json:"properties"`
-	Status     *SubnetStatus    `json:"status,omitempty"`
+	Status     *RemoteStatus    `json:"status,omitempty"`
 }
 
 type SubnetListResponse struct {
@@ -5,11 +5,6 @@ import (
 	"fmt"
 )
 
-type VpcStatus struct {
-	State        string `json:"state"`
-	CreationDate string `json:"creationDate"`
-}
-
 type VpcCategory struct {
 	Name     string `json:"name"`
 	Provider string `json:"provider"`
@@ -32,18 +27,13 @@ type VpcProject struct {
 }
 
 type VpcMetadata struct {
-	ID           string       `json:"id,omitempty"`
-	URI          string       `json:"uri,omitempty"`
-	Name         string       `json:"name"`
-	Tags         []string     `json:"tags,omitempty"`
-	Location     VpcLocation  `json:"location"`
-	Project      *VpcProject  `json:"project,omitempty"`
-	Category     *VpcCategory `json:"category,omitempty"`
-	CreationDate string       `json:"creationDate,omitempty"`
-	CreatedBy    string       `json:"createdBy,omitempty"`
-	UpdateDate   string       `json:"updateDate,omitempty"`
-	UpdatedBy    string       `json:"updatedBy,omitempty"`
-	Version      string       `json:"version,omitempty"`
+	ID       string       `json:"id,omitempty"`
+	Name     string       `json:"name"`
+	Tags     []string     `json:"tags,omitempty"`
+	Location VpcLocation  `json:"location"`
+	Project  *VpcProject  `json:"project,omitempty"`
+	Category *VpcCategory `json:"category,omitempty"`
+	RemoteMetadata
 }
 
 type VPCProperties struct {
@@ -57,8 +47,8 @@ type VpcRequest struct {
 }
 
 type VpcResponse struct {
-	Metadata VpcMetadata `json:"metadata"`
-	Status   *VpcStatus  `json:"status,omitempty"`
+	Metadata VpcMetadata   `json:"metadata"`
+	Status   *RemoteStatus `json:"status,omitempty"`
 }
 
 type VpcListResponse struct {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"slices"
+	"strings"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -64,7 +65,107 @@ func (e *ApiError) Error() string {
 
 // IsInvalidStatus although, 400 should be a bad request, but they use 400 and 404 even if the resource is not ready
 func (e *ApiError) IsInvalidStatus() bool {
-	return e.Status == 404 || e.Status == 400
+	return (e.Status == 404 || e.Status == 400) && !e.IsValidationError()
+}
+
+// notReadyKeywords are found in error titles when the API rejects a request
+// because the resource is still transitioning rather than because it is invalid
+var notReadyKeywords = []string{"invalid status", "invalid state", "not ready", "in progress", "provisioning", "busy"}
+
+// validationKeywords are found in error types and titles when the request itself is invalid
+var validationKeywords = []string{"validation", "invalid", "not valid", "not supported", "not allowed"}
+
+// IsValidationError reports whether the API rejected the request content itself,
+// e.g. a bad flavor name, CIDR or image, so that retrying the same request can't succeed
+func (e *ApiError) IsValidationError() bool {
+	if e.Status != http.StatusBadRequest && e.Status != http.StatusUnprocessableEntity {
+		return false
+	}
+
+	// Field-level errors point at the request content whatever the title says
+	for _, detail := range e.Errors {
+		if detail.Field != "" {
+			return true
+		}
+	}
+
+	title := strings.ToLower(e.Title)
+	for _, keyword := range notReadyKeywords {
+		if strings.Contains(title, keyword) {
+			return false
+		}
+	}
+
+	text := strings.ToLower(e.Type + " " + e.Title)
+	for _, keyword := range validationKeywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+
+	return e.Status == http.StatusUnprocessableEntity
+}
+
+// FieldErrors formats the field-level error details returned by the API
+func (e *ApiError) FieldErrors() string {
+	details := make([]string, 0, len(e.Errors))
+	for _, detail := range e.Errors {
+		if detail.Field == "" {
+			details = append(details, detail.Message)
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s: %s", detail.Field, detail.Message))
+	}
+	return strings.Join(details, "; ")
+}
+
+// IsNotFound reports whether the API answered that the resource does not exist
+func (e *ApiError) IsNotFound() bool {
+	return e.Status == http.StatusNotFound
+}
+
+// RemoteInfo holds the metadata and state common to every remote resource
+type RemoteInfo struct {
+	URI          string
+	State        string
+	CreationDate string
+	CreatedBy    string
+	UpdateDate   string
+	UpdatedBy    string
+	Version      string
+}
+
+// RemoteMetadata holds the metadata fields common to every remote resource, it is embedded in the
+// metadata of each kind
+type RemoteMetadata struct {
+	URI          string `json:"uri,omitempty"`
+	CreationDate string `json:"creationDate,omitempty"`
+	CreatedBy    string `json:"createdBy,omitempty"`
+	UpdateDate   string `json:"updateDate,omitempty"`
+	UpdatedBy    string `json:"updatedBy,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// RemoteStatus is the status of a remote resource
+type RemoteStatus struct {
+	State        string `json:"state"`
+	CreationDate string `json:"creationDate"`
+}
+
+// Info returns the metadata and the state, from status when the kind reports one, of a remote resource
+func (m RemoteMetadata) Info(status *RemoteStatus) RemoteInfo {
+	info := RemoteInfo{
+		URI:          m.URI,
+		CreationDate: m.CreationDate,
+		CreatedBy:    m.CreatedBy,
+		UpdateDate:   m.UpdateDate,
+		UpdatedBy:    m.UpdatedBy,
+		Version:      m.Version,
+	}
+	if status != nil {
+		info.State = status.State
+	}
+	return info
 }
 
 // NewHelperClient creates a new HelperClient instance
@@ -86,13 +187,37 @@ func (c *HelperClient) SetAPIToken(token string) {
 	c.apiToken = token
 }
 
+// connectionKey is the context key of the Connection used by DoAPIRequest
+type connectionKey struct{}
+
+// Connection is an API gateway and the token used to call it
+type Connection struct {
+	APIGateway string
+	Token      string
+}
+
+// WithConnection returns a copy of ctx whose API requests are sent to conn
+// instead of the gateway and token of the HelperClient
+func WithConnection(ctx context.Context, conn Connection) context.Context {
+	return context.WithValue(ctx, connectionKey{}, conn)
+}
+
+// connectionOf returns the connection of ctx, falling back to the gateway and token of the HelperClient
+func (c *HelperClient) connectionOf(ctx context.Context) Connection {
+	if conn, ok := ctx.Value(connectionKey{}).(Connection); ok {
+		return conn
+	}
+	return Connection{APIGateway: c.apiGatewayUrl, Token: c.apiToken}
+}
+
 // DoAPIRequest performs an authenticated API request
 func (c *HelperClient) DoAPIRequest(ctx context.Context, method, endpoint string, body, response any) error {
-	if c.apiGatewayUrl == "" {
+	conn := c.connectionOf(ctx)
+	if conn.APIGateway == "" {
 		return fmt.Errorf("api gateway url not loaded")
 	}
 
-	url := fmt.Sprintf("%s%s", c.apiGatewayUrl, endpoint)
+	url := fmt.Sprintf("%s%s", conn.APIGateway, endpoint)
 	clientLog := ctrl.Log.WithValues("Method", method, "Url", url)
 	clientLog.Info("API Request")
 
@@ -113,7 +238,7 @@ func (c *HelperClient) DoAPIRequest(ctx context.Context, method, endpoint string
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "*/*")
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	req.Header.Set("Authorization", "Bearer "+conn.Token)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -0,0 +1,195 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiError_Classification(t *testing.T) {
+	tests := []struct {
+		name             string
+		apiErr           client.ApiError
+		expectValidation bool
+		expectNotReady   bool
+	}{
+		{
+			name: "field errors on bad request",
+			apiErr: client.ApiError{
+				Status: 400,
+				Title:  "Bad Request",
+				Errors: []client.ErrorDetail{{Field: "properties.flavorName", Message: "flavor not found"}},
+			},
+			expectValidation: true,
+			expectNotReady:   false,
+		},
+		{
+			name: "validation title without field errors",
+			apiErr: client.ApiError{
+				Status: 400,
+				Title:  "One or more validation errors occurred.",
+			},
+			expectValidation: true,
+			expectNotReady:   false,
+		},
+		{
+			name: "invalid status while the resource is transitioning",
+			apiErr: client.ApiError{
+				Status: 400,
+				Title:  "Resource is in an invalid status",
+			},
+			expectValidation: false,
+			expectNotReady:   true,
+		},
+		{
+			name: "field errors with a state in the title",
+			apiErr: client.ApiError{
+				Status: 400,
+				Title:  "Invalid state of the request",
+				Errors: []client.ErrorDetail{{Field: "properties.sizeGb", Message: "must be greater than 0"}},
+			},
+			expectValidation: true,
+			expectNotReady:   false,
+		},
+		{
+			name: "field errors with a status in the title",
+			apiErr: client.ApiError{
+				Status: 400,
+				Title:  "Validation failed: status",
+				Errors: []client.ErrorDetail{{Field: "properties.status", Message: "unknown value"}},
+			},
+			expectValidation: true,
+			expectNotReady:   false,
+		},
+		{
+			name: "validation title mentioning a status",
+			apiErr: client.ApiError{
+				Status: 400,
+				Title:  "Validation failed: status",
+			},
+			expectValidation: true,
+			expectNotReady:   false,
+		},
+		{
+			name: "operation in progress",
+			apiErr: client.ApiError{
+				Status: 400,
+				Title:  "Another operation is in progress",
+			},
+			expectValidation: false,
+			expectNotReady:   true,
+		},
+		{
+			name: "not found while the resource is not ready",
+			apiErr: client.ApiError{
+				Status: 404,
+				Title:  "Not Found",
+			},
+			expectValidation: false,
+			expectNotReady:   true,
+		},
+		{
+			name: "bad request with an empty body",
+			apiErr: client.ApiError{
+				Status: 400,
+				Title:  "Unknown API error",
+			},
+			expectValidation: false,
+			expectNotReady:   true,
+		},
+		{
+			name: "unprocessable entity",
+			apiErr: client.ApiError{
+				Status: 422,
+				Title:  "Unprocessable Entity",
+			},
+			expectValidation: true,
+			expectNotReady:   false,
+		},
+		{
+			name: "server error",
+			apiErr: client.ApiError{
+				Status: 500,
+				Title:  "Internal Server Error",
+				Errors: []client.ErrorDetail{{Field: "properties.address", Message: "boom"}},
+			},
+			expectValidation: false,
+			expectNotReady:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectValidation, tt.apiErr.IsValidationError())
+			assert.Equal(t, tt.expectNotReady, tt.apiErr.IsInvalidStatus())
+		})
+	}
+}
+
+func TestApiError_FieldErrors(t *testing.T) {
+	apiErr := client.ApiError{
+		Status: 400,
+		Errors: []client.ErrorDetail{
+			{Field: "properties.network.address", Message: "host bits must be zero"},
+			{Message: "request rejected"},
+		},
+	}
+
+	assert.Equal(t, "properties.network.address: host bits must be zero; request rejected", apiErr.FieldErrors())
+}
+
+// recordingHTTPClient records the requests it receives and answers 200 with an empty body
+type recordingHTTPClient struct {
+	requests []*http.Request
+}
+
+func (c *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestDoAPIRequest_Connection(t *testing.T) {
+	httpClient := &recordingHTTPClient{}
+	helper := client.NewHelperClient(nil, httpClient, "https://default.example.com")
+	helper.SetAPIToken("default-token")
+
+	require.NoError(t, helper.DoAPIRequest(context.Background(), http.MethodGet, "/projects", nil, nil))
+	ctx := client.WithConnection(context.Background(), client.Connection{APIGateway: "https://other.example.com", Token: "other-token"})
+	require.NoError(t, helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil))
+
+	require.Len(t, httpClient.requests, 2)
+	assert.Equal(t, "https://default.example.com/projects", httpClient.requests[0].URL.String())
+	assert.Equal(t, "Bearer default-token", httpClient.requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "https://other.example.com/projects", httpClient.requests[1].URL.String())
+	assert.Equal(t, "Bearer other-token", httpClient.requests[1].Header.Get("Authorization"))
+}
+
+func TestRemoteMetadata_Info(t *testing.T) {
+	body := `{
+		"metadata": {"id": "vpc-1", "uri": "/projects/p/providers/Aruba.Network/vpcs/vpc-1", "name": "vpc",
+			"creationDate": "2025-01-02T03:04:05Z", "createdBy": "alice", "updateDate": "2025-02-03T04:05:06Z",
+			"updatedBy": "bob", "version": "3"},
+		"status": {"state": "Active"}
+	}`
+	var resp client.VpcResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+	assert.Equal(t, "vpc-1", resp.Metadata.ID)
+	assert.Equal(t, client.RemoteInfo{
+		URI:          "/projects/p/providers/Aruba.Network/vpcs/vpc-1",
+		State:        "Active",
+		CreationDate: "2025-01-02T03:04:05Z",
+		CreatedBy:    "alice",
+		UpdateDate:   "2025-02-03T04:05:06Z",
+		UpdatedBy:    "bob",
+		Version:      "3",
+	}, resp.Metadata.Info(resp.Status))
+	assert.Empty(t, resp.Metadata.Info(nil).State)
+}
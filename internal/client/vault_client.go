@@ -79,14 +79,23 @@ type AppRoleClient struct {
 }
 
 func VaultClient(address string) IVaultClient {
+	client, err := NewVaultClient(address)
+	if err != nil {
+		ctrl.Log.Error(err, "Vault client initialization failed")
+		os.Exit(1)
+	}
+	return client
+}
+
+// NewVaultClient creates a Vault client for address, returning the initialization error
+func NewVaultClient(address string) (IVaultClient, error) {
 	config := vault.DefaultConfig()
 	config.Address = address
 	client, err := vault.NewClient(config)
 	if err != nil {
-		ctrl.Log.Error(err, "Vault client initialization failed")
-		os.Exit(1)
+		return nil, err
 	}
-	return &VaultClientAPI{c: client}
+	return &VaultClientAPI{c: client}, nil
 }
 
 // NewAppRoleClient creates and authenticates an AppRoleClient
@@ -22,6 +22,12 @@ type MainConfig struct {
 	RoleSecret     string
 	ClientID       string
 	ClientSecret   string
+	// PropagatedLabels are the label keys propagated as tags to the remote resources
+	PropagatedLabels []string
+	// ClusterID identifies this cluster in the operator-managed tags
+	ClusterID string
+	// TenantIsolation restricts the tenants of each namespace to the ones bound by TenantBindings
+	TenantIsolation bool
 }
 
 // Validate ensures all required fields are present.
@@ -65,17 +71,20 @@ func (c *MainConfig) Validate() error {
 // ToReconcilerConfig converts MainConfig into ReconcilerConfig.
 func (c *MainConfig) ToReconcilerConfig() reconciler.ReconcilerConfig {
 	return reconciler.ReconcilerConfig{
-		APIGateway:     c.APIGateway,
-		VaultAddress:   c.VaultAddress,
-		KeycloakURL:    c.KeycloakURL,
-		ClientID:       c.ClientID,
-		ClientSecret:   c.ClientSecret,
-		VaultIsEnabled: c.VaultIsEnabled,
-		RealmAPI:       c.RealmAPI,
-		Namespace:      c.Namespace,
-		RolePath:       c.RolePath,
-		KVMount:        c.KVMount,
-		RoleID:         c.RoleID,
-		RoleSecret:     c.RoleSecret,
+		APIGateway:       c.APIGateway,
+		VaultAddress:     c.VaultAddress,
+		KeycloakURL:      c.KeycloakURL,
+		ClientID:         c.ClientID,
+		ClientSecret:     c.ClientSecret,
+		VaultIsEnabled:   c.VaultIsEnabled,
+		RealmAPI:         c.RealmAPI,
+		Namespace:        c.Namespace,
+		RolePath:         c.RolePath,
+		KVMount:          c.KVMount,
+		RoleID:           c.RoleID,
+		RoleSecret:       c.RoleSecret,
+		PropagatedLabels: c.PropagatedLabels,
+		ClusterID:        c.ClusterID,
+		TenantIsolation:  c.TenantIsolation,
 	}
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,18 +29,25 @@ func Load(ctx context.Context, mgr ctrl.Manager, configMapName, configNamespace,
 	}
 
 	mainConfig := &MainConfig{
-		APIGateway:     cfg.Data["api-gateway"],
-		VaultIsEnabled: cfg.Data["vault-enabled"] == "true",
-		VaultAddress:   cfg.Data["vault-address"],
-		KeycloakURL:    cfg.Data["keycloak-url"],
-		RealmAPI:       cfg.Data["realm-api"],
-		Namespace:      cfg.Data["role-namespace"],
-		RolePath:       cfg.Data["role-path"],
-		KVMount:        cfg.Data["kv-mount"],
-		RoleID:         string(secret.Data["role-id"]),
-		RoleSecret:     string(secret.Data["role-secret"]),
-		ClientID:       string(secret.Data["client-id"]),
-		ClientSecret:   string(secret.Data["client-secret"]),
+		APIGateway:       cfg.Data["api-gateway"],
+		VaultIsEnabled:   cfg.Data["vault-enabled"] == "true",
+		VaultAddress:     cfg.Data["vault-address"],
+		KeycloakURL:      cfg.Data["keycloak-url"],
+		RealmAPI:         cfg.Data["realm-api"],
+		Namespace:        cfg.Data["role-namespace"],
+		RolePath:         cfg.Data["role-path"],
+		KVMount:          cfg.Data["kv-mount"],
+		RoleID:           string(secret.Data["role-id"]),
+		RoleSecret:       string(secret.Data["role-secret"]),
+		ClientID:         string(secret.Data["client-id"]),
+		ClientSecret:     string(secret.Data["client-secret"]),
+		PropagatedLabels: parseList(cfg.Data["propagate-labels"]),
+		ClusterID:        cfg.Data["cluster-id"],
+	}
+	// Tenant isolation is enabled by default in Vault mode, where tenants select the credentials
+	mainConfig.TenantIsolation = mainConfig.VaultIsEnabled
+	if value, ok := cfg.Data["tenant-isolation"]; ok && value != "" {
+		mainConfig.TenantIsolation = value == "true"
 	}
 
 	if err := mainConfig.Validate(); err != nil {
@@ -64,3 +72,14 @@ func getSecret(ctx context.Context, c client.Client, ns, name string) (*corev1.S
 	}
 	return secret, nil
 }
+
+// parseList splits a comma-separated value, ignoring blank entries
+func parseList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
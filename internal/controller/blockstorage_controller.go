@@ -18,9 +18,17 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
@@ -43,6 +51,8 @@ func NewBlockStorageReconciler(baseReconciler *reconciler.Reconciler) *BlockStor
 // +kubebuilder:rbac:groups=arubacloud.com,resources=blockstorages/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=arubacloud.com,resources=blockstorages/finalizers,verbs=update
 // +kubebuilder:rbac:groups=arubacloud.com,resources=projects,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=cloudservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=blockstoragesnapshots,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
@@ -55,10 +65,42 @@ func (r *BlockStorageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 func (r *BlockStorageReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.BlockStorage{}).
+		// The deletion of a block storage waits for its snapshots
+		Watches(&v1alpha1.BlockStorageSnapshot{}, handler.EnqueueRequestsFromMapFunc(r.snapshottedBlockStorage)).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.BlockStorageList{}))).
 		Named("blockstorage").
 		Complete(r)
 }
 
+// snapshottedBlockStorage enqueues the block storage the mapped BlockStorageSnapshot was taken of
+func (r *BlockStorageReconciler) snapshottedBlockStorage(ctx context.Context, obj client.Object) []reconcile.Request {
+	snapshot, ok := obj.(*v1alpha1.BlockStorageSnapshot)
+	if !ok {
+		return nil
+	}
+	ref := snapshot.Spec.BlockStorageReference
+	if ref.ID == "" {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = snapshot.Namespace
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: ref.Name, Namespace: namespace}}}
+	}
+
+	blockStorages := &v1alpha1.BlockStorageList{}
+	if err := r.List(ctx, blockStorages); err != nil {
+		ctrl.Log.Error(err, "failed to list block storages")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, blockStorage := range blockStorages.Items {
+		if blockStorage.Status.ResourceID == ref.ID {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: blockStorage.Name, Namespace: blockStorage.Namespace}})
+		}
+	}
+	return requests
+}
+
 const (
 	blockStorageFinalizerName = "blockstorage.arubacloud.com/finalizer"
 )
@@ -70,7 +112,7 @@ func (r *BlockStorageReconciler) Init(ctx context.Context, obj client.Object, st
 func (r *BlockStorageReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	blockStorage := obj.(*v1alpha1.BlockStorage)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
-		projectID, err := r.GetProjectID(ctx, blockStorage.Spec.ProjectReference.Name, blockStorage.Spec.ProjectReference.Namespace)
+		projectID, err := r.ProjectIDOf(ctx, blockStorage.Spec.ProjectReference)
 		if err != nil {
 			return "", "", err
 		}
@@ -78,7 +120,7 @@ func (r *BlockStorageReconciler) Creating(ctx context.Context, obj client.Object
 		blockStorageReq := arubaClient.BlockStorageRequest{
 			Metadata: arubaClient.BlockStorageMetadata{
 				Name: blockStorage.Name,
-				Tags: blockStorage.Spec.Tags,
+				Tags: r.ResourceTags(blockStorage),
 				Location: arubaClient.BlockStorageLocation{
 					Value: blockStorage.Spec.Location.Value,
 				},
@@ -93,6 +135,11 @@ func (r *BlockStorageReconciler) Creating(ctx context.Context, obj client.Object
 			},
 		}
 
+		err = r.ApplyBlockStorageDataSource(ctx, blockStorage.Spec.DataSource, projectID, &blockStorageReq.Properties)
+		if err != nil {
+			return "", "", err
+		}
+
 		blockStorageResp, err := r.CreateBlockStorage(ctx, projectID, blockStorageReq)
 		if err != nil {
 			return "", "", err
@@ -127,32 +174,149 @@ func (r *BlockStorageReconciler) Provisioning(ctx context.Context, obj client.Ob
 func (r *BlockStorageReconciler) Updating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	blockStorage := obj.(*v1alpha1.BlockStorage)
 	return r.HandleUpdating(ctx, obj, status, func(ctx context.Context) error {
-		blockStorageReq := arubaClient.BlockStorageRequest{
-			Metadata: arubaClient.BlockStorageMetadata{
-				Name: blockStorage.Name,
-				Tags: blockStorage.Spec.Tags,
-				Location: arubaClient.BlockStorageLocation{
-					Value: blockStorage.Spec.Location.Value,
-				},
-			},
-			Properties: arubaClient.BlockStorageProperties{
-				SizeGb:        blockStorage.Spec.SizeGb,
-				BillingPeriod: blockStorage.Spec.BillingPeriod,
-				DataCenter:    blockStorage.Spec.DataCenter,
-			},
+		// The size is increased by an expansion once the update is applied, see reconcileExpansion
+		sizeGb := blockStorage.Status.CapacityGb
+		if sizeGb == 0 {
+			sizeGb = blockStorage.Spec.SizeGb
 		}
 
-		_, err := r.UpdateBlockStorage(ctx, blockStorage.Status.ProjectID, status.ResourceID, blockStorageReq)
+		_, err := r.UpdateBlockStorage(ctx, blockStorage.Status.ProjectID, status.ResourceID, r.updateRequest(blockStorage, sizeGb))
 		return err
 	})
 }
 
+// updateRequest builds the request updating the block storage to sizeGb
+func (r *BlockStorageReconciler) updateRequest(blockStorage *v1alpha1.BlockStorage, sizeGb int32) arubaClient.BlockStorageRequest {
+	return arubaClient.BlockStorageRequest{
+		Metadata: arubaClient.BlockStorageMetadata{
+			Name: blockStorage.Name,
+			Tags: r.ResourceTags(blockStorage),
+			Location: arubaClient.BlockStorageLocation{
+				Value: blockStorage.Spec.Location.Value,
+			},
+		},
+		Properties: arubaClient.BlockStorageProperties{
+			SizeGb:        sizeGb,
+			BillingPeriod: blockStorage.Spec.BillingPeriod,
+			DataCenter:    blockStorage.Spec.DataCenter,
+		},
+	}
+}
+
 func (r *BlockStorageReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	blockStorage := obj.(*v1alpha1.BlockStorage)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, blockStorage.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetBlockStorage(ctx, blockStorage.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		blockStorage.Status.CapacityGb = resp.Properties.SizeGb
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
+	// Expansions wait for the pending spec changes to be applied, an expansion in progress is completed first
+	expansion := blockStorage.Status.Expansion
+	if status.ObservedGeneration == blockStorage.GetGeneration() || expansion != nil && expansion.Phase == v1alpha1.BlockStorageExpansionExpanding {
+		if result, changed, err := r.reconcileExpansion(ctx, blockStorage, status); changed {
+			return result, err
+		}
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
+// reconcileExpansion runs the next step expanding the block storage to spec.sizeGb, reporting
+// whether the status was changed. Once Aruba Cloud reports the new size, the action needed on
+// the attached cloud servers is reported in the status and recorded as an event on them.
+func (r *BlockStorageReconciler) reconcileExpansion(ctx context.Context, blockStorage *v1alpha1.BlockStorage, status *v1alpha1.ResourceStatus) (ctrl.Result, bool, error) {
+	expansion := blockStorage.Status.Expansion
+	plan := reconciler.PlanExpansion(blockStorage)
+	if !plan.Expand && (expansion == nil || plan.Phase == expansion.Phase) {
+		if plan.Phase == v1alpha1.BlockStorageExpansionExpanding {
+			// Wait for the new size to be reported
+			result, err := r.Next(ctx, blockStorage, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionFalse,
+				"Expanding", fmt.Sprintf("Expansion from %dGB to %dGB in progress", expansion.FromGb, expansion.ToGb), true)
+			return result, true, err
+		}
+		return ctrl.Result{}, false, nil
+	}
+
+	if plan.Expand {
+		expansion = &v1alpha1.BlockStorageExpansion{
+			Phase:  plan.Phase,
+			FromGb: blockStorage.Status.CapacityGb,
+			ToGb:   blockStorage.Spec.SizeGb,
+		}
+		if _, err := r.UpdateBlockStorage(ctx, blockStorage.Status.ProjectID, status.ResourceID, r.updateRequest(blockStorage, expansion.ToGb)); err != nil {
+			result, err := r.NextToFailedOnApiError(ctx, blockStorage, status, err)
+			return result, true, err
+		}
+		blockStorage.Status.Expansion = expansion
+		message := fmt.Sprintf("Expansion from %dGB to %dGB in progress", expansion.FromGb, expansion.ToGb)
+		r.RecordEvent(blockStorage, corev1.EventTypeNormal, "Expanding", message)
+		// Without requeue the debounce of Next can't skip the status update, the result still requeues
+		result, err := r.Next(ctx, blockStorage, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionFalse, "Expanding", message, false)
+		return result, true, err
+	}
+
+	booting, attaching, err := reconciler.CloudServersAttaching(ctx, r.Client, status.ResourceID)
+	if err != nil {
+		result, err := r.NextToFailedOnReconcileError(ctx, blockStorage, status, err)
+		return result, true, err
+	}
+	expansion = expansion.DeepCopy()
+	expansion.Phase = plan.Phase
+	expansion.CloudServers = slices.Concat(booting, attaching)
+	expansion.RequiredAction = reconciler.ExpansionActionFor(booting, attaching)
+	blockStorage.Status.Expansion = expansion
+
+	message := fmt.Sprintf("Block storage expanded from %dGB to %dGB", expansion.FromGb, expansion.ToGb)
+	switch expansion.RequiredAction {
+	case v1alpha1.BlockStorageExpansionActionReboot:
+		message += fmt.Sprintf(", reboot %s to grow the root filesystem", strings.Join(booting, ", "))
+	case v1alpha1.BlockStorageExpansionActionGrowFilesystem:
+		message += fmt.Sprintf(", grow the partition and filesystem on %s", strings.Join(attaching, ", "))
+	}
+	r.RecordEvent(blockStorage, corev1.EventTypeNormal, "Expanded", message)
+	for _, name := range expansion.CloudServers {
+		r.recordOnCloudServer(ctx, name, message)
+	}
+
+	result, err := r.Next(ctx, blockStorage, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionTrue, "Expanded", message, false)
+	return result, true, err
+}
+
+// recordOnCloudServer records the expansion of a block storage on the cloud server with namespace/name
+func (r *BlockStorageReconciler) recordOnCloudServer(ctx context.Context, name, message string) {
+	namespace, name, _ := strings.Cut(name, "/")
+	cloudServer := &v1alpha1.CloudServer{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cloudServer); err != nil {
+		return
+	}
+	r.RecordEvent(cloudServer, corev1.EventTypeNormal, "VolumeExpanded", message)
+}
+
 func (r *BlockStorageReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	blockStorage := obj.(*v1alpha1.BlockStorage)
+
+	// Aruba Cloud doesn't delete a block storage with snapshots, the deletion waits for them to be deleted
+	snapshots, err := reconciler.SnapshotsOf(ctx, r.Client, status.ResourceID)
+	if err != nil {
+		return r.NextToFailedOnReconcileError(ctx, obj, status, err)
+	}
+	if len(snapshots) > 0 {
+		message := fmt.Sprintf("Deletion blocked by the snapshots %s", strings.Join(snapshots, ", "))
+		if status.Message != message {
+			r.RecordEvent(obj, corev1.EventTypeWarning, "DeletionBlocked", message)
+		}
+		// The wait is bounded by the deletion of the snapshots, the reason is exempt from the phase timeout
+		return r.Next(ctx, obj, status, v1alpha1.ResourcePhaseDeleting, metav1.ConditionFalse, "DependentSnapshots", message, false)
+	}
+
 	return r.HandleDeletion(ctx, obj, status, blockStorageFinalizerName, func(ctx context.Context) error {
 		return r.DeleteBlockStorage(ctx, blockStorage.Status.ProjectID, status.ResourceID)
 	})
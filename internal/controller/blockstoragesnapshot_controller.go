@@ -0,0 +1,185 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+)
+
+// BlockStorageSnapshotReconciler reconciles a BlockStorageSnapshot object
+type BlockStorageSnapshotReconciler struct {
+	*reconciler.Reconciler
+}
+
+// NewBlockStorageSnapshotReconciler creates a new BlockStorageSnapshotReconciler
+func NewBlockStorageSnapshotReconciler(baseReconciler *reconciler.Reconciler) *BlockStorageSnapshotReconciler {
+	return &BlockStorageSnapshotReconciler{
+		Reconciler: baseReconciler,
+	}
+}
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=blockstoragesnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=arubacloud.com,resources=blockstoragesnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=blockstoragesnapshots/finalizers,verbs=update
+// +kubebuilder:rbac:groups=arubacloud.com,resources=blockstorages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=projects,verbs=get;list;watch
+
+func (r *BlockStorageSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := &v1alpha1.BlockStorageSnapshot{}
+	return r.Reconciler.Reconcile(ctx, req, obj, &obj.Status.ResourceStatus, r, &obj.Spec.Tenant)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BlockStorageSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.BlockStorageSnapshot{}).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.BlockStorageSnapshotList{}))).
+		Named("blockstoragesnapshot").
+		Complete(r)
+}
+
+const (
+	blockStorageSnapshotFinalizerName = "blockstoragesnapshot.arubacloud.com/finalizer"
+)
+
+func (r *BlockStorageSnapshotReconciler) Init(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	return r.InitializeResource(ctx, obj, status, blockStorageSnapshotFinalizerName)
+}
+
+func (r *BlockStorageSnapshotReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	snapshot := obj.(*v1alpha1.BlockStorageSnapshot)
+	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
+		projectID, err := r.ProjectIDOf(ctx, snapshot.Spec.ProjectReference)
+		if err != nil {
+			return "", "", err
+		}
+
+		blockStorageID, err := r.BlockStorageIDOf(ctx, snapshot.Spec.BlockStorageReference, projectID)
+		if err != nil {
+			return "", "", err
+		}
+
+		snapshotResp, err := r.CreateSnapshot(ctx, projectID, r.snapshotRequest(snapshot, projectID, blockStorageID))
+		if err != nil {
+			return "", "", err
+		}
+
+		snapshot.Status.ProjectID = projectID
+		snapshot.Status.BlockStorageID = blockStorageID
+		r.observeSnapshot(snapshot, snapshotResp)
+
+		state := ""
+		if snapshotResp.Status != nil {
+			state = snapshotResp.Status.State
+		}
+
+		return snapshotResp.Metadata.ID, state, nil
+	})
+}
+
+func (r *BlockStorageSnapshotReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	snapshot := obj.(*v1alpha1.BlockStorageSnapshot)
+	return r.HandleProvisioning(ctx, obj, status, func(ctx context.Context) (string, error) {
+		snapshotResp, err := r.GetSnapshot(ctx, snapshot.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return "", err
+		}
+
+		r.observeSnapshot(snapshot, snapshotResp)
+		if snapshotResp.Status != nil {
+			return snapshotResp.Status.State, nil
+		}
+		return "", nil
+	})
+}
+
+func (r *BlockStorageSnapshotReconciler) Updating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	snapshot := obj.(*v1alpha1.BlockStorageSnapshot)
+	return r.HandleUpdating(ctx, obj, status, func(ctx context.Context) error {
+		_, err := r.UpdateSnapshot(ctx, snapshot.Status.ProjectID, status.ResourceID,
+			r.snapshotRequest(snapshot, snapshot.Status.ProjectID, snapshot.Status.BlockStorageID))
+		return err
+	})
+}
+
+func (r *BlockStorageSnapshotReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	snapshot := obj.(*v1alpha1.BlockStorageSnapshot)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, snapshot.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetSnapshot(ctx, snapshot.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		r.observeSnapshot(snapshot, resp)
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
+	return r.CheckForUpdates(ctx, obj, status)
+}
+
+func (r *BlockStorageSnapshotReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	snapshot := obj.(*v1alpha1.BlockStorageSnapshot)
+	return r.HandleDeletion(ctx, obj, status, blockStorageSnapshotFinalizerName, func(ctx context.Context) error {
+		return r.DeleteSnapshot(ctx, snapshot.Status.ProjectID, status.ResourceID)
+	})
+}
+
+// snapshotRequest returns the request taking a snapshot of the block storage with blockStorageID
+func (r *BlockStorageSnapshotReconciler) snapshotRequest(snapshot *v1alpha1.BlockStorageSnapshot, projectID, blockStorageID string) arubaClient.SnapshotRequest {
+	return arubaClient.SnapshotRequest{
+		Metadata: arubaClient.SnapshotMetadata{
+			Name: snapshot.Name,
+			Tags: r.ResourceTags(snapshot),
+			Location: arubaClient.SnapshotLocation{
+				Value: snapshot.Spec.Location.Value,
+			},
+		},
+		Properties: arubaClient.SnapshotProperties{
+			Volume: arubaClient.SnapshotVolume{
+				URI: r.buildVolumeURI(projectID, blockStorageID),
+			},
+		},
+	}
+}
+
+// observeSnapshot records the size and creation time reported by Aruba Cloud in the status of snapshot
+func (r *BlockStorageSnapshotReconciler) observeSnapshot(snapshot *v1alpha1.BlockStorageSnapshot, resp *arubaClient.SnapshotResponse) {
+	if resp.Properties.SizeGb != 0 {
+		snapshot.Status.SizeGb = resp.Properties.SizeGb
+	}
+	if creationTime, err := time.Parse(time.RFC3339, resp.Metadata.CreationDate); err == nil {
+		snapshot.Status.CreationTime = &metav1.Time{Time: creationTime}
+	}
+}
+
+func (r *BlockStorageSnapshotReconciler) buildVolumeURI(projectID, volumeID string) string {
+	return fmt.Sprintf("/projects/%s/providers/Aruba.Storage/blockStorages/%s", projectID, volumeID)
+}
@@ -18,11 +18,21 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
@@ -58,6 +68,15 @@ func (r *CloudServerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 func (r *CloudServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.CloudServer{}).
+		Owns(&corev1.Secret{}, builder.OnlyMetadata).
+		// Label changes of the selected resources change the attached data volumes and security groups
+		Watches(&v1alpha1.BlockStorage{}, handler.EnqueueRequestsFromMapFunc(r.cloudServersSelecting(func(cs *v1alpha1.CloudServer) *metav1.LabelSelector {
+			return cs.Spec.DataVolumeSelector
+		}))).
+		Watches(&v1alpha1.SecurityGroup{}, handler.EnqueueRequestsFromMapFunc(r.cloudServersSelecting(func(cs *v1alpha1.CloudServer) *metav1.LabelSelector {
+			return cs.Spec.SecurityGroupSelector
+		}))).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.CloudServerList{}))).
 		Named("cloudserver").
 		Complete(r)
 }
@@ -73,22 +92,22 @@ func (r *CloudServerReconciler) Init(ctx context.Context, obj client.Object, sta
 func (r *CloudServerReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	cloudServer := obj.(*v1alpha1.CloudServer)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
-		projectID, err := r.GetProjectID(ctx, cloudServer.Spec.ProjectReference.Name, cloudServer.Spec.ProjectReference.Namespace)
+		projectID, err := r.ProjectIDOf(ctx, cloudServer.Spec.ProjectReference)
 		if err != nil {
 			return "", "", err
 		}
 
-		vpcID, err := r.GetVpcID(ctx, cloudServer.Spec.VpcReference.Name, cloudServer.Spec.VpcReference.Namespace)
+		vpcID, err := r.VpcIDOf(ctx, cloudServer.Spec.VpcReference, projectID)
 		if err != nil {
 			return "", "", err
 		}
 
-		bootVolumeID, err := r.GetBlockStorageID(ctx, cloudServer.Spec.BootVolumeReference.Name, cloudServer.Spec.BootVolumeReference.Namespace)
+		bootVolumeID, err := r.BlockStorageIDOf(ctx, cloudServer.Spec.BootVolumeReference, projectID)
 		if err != nil {
 			return "", "", err
 		}
 
-		keyPairID, err := r.GetKeyPairID(ctx, cloudServer.Spec.KeyPairReference.Name, cloudServer.Spec.KeyPairReference.Namespace)
+		keyPairID, err := r.KeyPairIDOf(ctx, cloudServer.Spec.KeyPairReference, projectID)
 		if err != nil {
 			return "", "", err
 		}
@@ -96,28 +115,24 @@ func (r *CloudServerReconciler) Creating(ctx context.Context, obj client.Object,
 		// Resolve subnet IDs
 		subnetIDs := make([]string, len(cloudServer.Spec.SubnetReferences))
 		for i, subnetRef := range cloudServer.Spec.SubnetReferences {
-			subnetID, err := r.GetSubnetID(ctx, subnetRef.Name, subnetRef.Namespace)
+			subnetID, err := r.SubnetIDOf(ctx, subnetRef, projectID, vpcID)
 			if err != nil {
-				return "", "", fmt.Errorf("failed to get subnet ID for %s/%s: %w", subnetRef.Namespace, subnetRef.Name, err)
+				return "", "", fmt.Errorf("failed to get subnet ID for %s: %w", subnetRef, err)
 			}
 			subnetIDs[i] = subnetID
 		}
 
 		// Resolve security group IDs
-		securityGroupIDs := make([]string, len(cloudServer.Spec.SecurityGroupReferences))
-		for i, sgRef := range cloudServer.Spec.SecurityGroupReferences {
-			sgID, err := r.GetSecurityGroupID(ctx, sgRef.Name, sgRef.Namespace)
-			if err != nil {
-				return "", "", fmt.Errorf("failed to get security group ID for %s/%s: %w", sgRef.Namespace, sgRef.Name, err)
-			}
-			securityGroupIDs[i] = sgID
+		securityGroupIDs, securityGroups, err := r.resolveSecurityGroups(ctx, cloudServer, projectID, vpcID)
+		if err != nil {
+			return "", "", err
 		}
 
 		// Create cloud server via API
 		cloudServerReq := arubaClient.CloudServerRequest{
 			Metadata: arubaClient.CloudServerMetadata{
 				Name: cloudServer.Name,
-				Tags: cloudServer.Spec.Tags,
+				Tags: r.ResourceTags(cloudServer),
 				Location: arubaClient.CloudServerLocation{
 					Value: cloudServer.Spec.Location.Value,
 				},
@@ -135,7 +150,7 @@ func (r *CloudServerReconciler) Creating(ctx context.Context, obj client.Object,
 		// Add optional elastic IP
 		var elasticIpID string
 		if cloudServer.Spec.ElasticIpReference != nil {
-			elasticIpID, err = r.GetElasticIpID(ctx, cloudServer.Spec.ElasticIpReference.Name, cloudServer.Spec.ElasticIpReference.Namespace)
+			elasticIpID, err = r.ElasticIpIDOf(ctx, *cloudServer.Spec.ElasticIpReference, projectID)
 			if err != nil {
 				return "", "", fmt.Errorf("failed to get elastic IP ID: %w", err)
 			}
@@ -164,11 +179,19 @@ func (r *CloudServerReconciler) Creating(ctx context.Context, obj client.Object,
 		cloudServer.Status.VpcID = vpcID
 		cloudServer.Status.SubnetIDs = subnetIDs
 		cloudServer.Status.SecurityGroupIDs = securityGroupIDs
+		cloudServer.Status.ResolvedSecurityGroups = securityGroups
 		cloudServer.Status.BootVolumeID = bootVolumeID
+		cloudServer.Status.Flavor = reconciler.ObservedFlavor(cloudServerResp.Properties, &v1alpha1.CloudServerFlavor{Name: cloudServer.Spec.FlavorName})
 		if elasticIpID != "" {
 			cloudServer.Status.ElasticIpID = elasticIpID
 		}
+		cloudServer.Status.PublicIPAddress = ""
 		cloudServer.Status.KeyPairID = keyPairID
+		// A restart requested before the creation is already satisfied
+		cloudServer.Status.LastRestartRequest = cloudServer.Annotations[v1alpha1.RestartAnnotation]
+		// A new cloud server starts without data volumes, they are attached in the Updating phase
+		cloudServer.Status.DataVolumeIDs = nil
+		cloudServer.Status.ResolvedDataVolumes = nil
 
 		state := ""
 		if cloudServerResp.Status != nil {
@@ -203,61 +226,43 @@ func (r *CloudServerReconciler) Updating(ctx context.Context, obj client.Object,
 		projectID := cloudServer.Status.ProjectID
 		vpcID := cloudServer.Status.VpcID
 
-		// Check if we need to update cloud server properties (generation mismatch)
-		needsPropertyUpdate := status.ObservedGeneration != cloudServer.GetGeneration()
+		// Selected security groups can change without a spec change
+		securityGroupIDs, securityGroups, err := r.resolveSecurityGroups(ctx, cloudServer, projectID, vpcID)
+		if err != nil {
+			return err
+		}
+
+		// Check if we need to update cloud server properties (generation mismatch or new security groups)
+		needsPropertyUpdate := status.ObservedGeneration != cloudServer.GetGeneration() ||
+			!util.SameIDs(securityGroupIDs, cloudServer.Status.SecurityGroupIDs)
 
 		if needsPropertyUpdate {
 			// Resolve subnet IDs
 			subnetIDs := make([]string, len(cloudServer.Spec.SubnetReferences))
 			for i, subnetRef := range cloudServer.Spec.SubnetReferences {
-				subnetID, err := r.GetSubnetID(ctx, subnetRef.Name, subnetRef.Namespace)
+				subnetID, err := r.SubnetIDOf(ctx, subnetRef, projectID, vpcID)
 				if err != nil {
-					return fmt.Errorf("failed to get subnet ID for %s/%s: %w", subnetRef.Namespace, subnetRef.Name, err)
+					return fmt.Errorf("failed to get subnet ID for %s: %w", subnetRef, err)
 				}
 				subnetIDs[i] = subnetID
 			}
 
-			// Resolve security group IDs
-			securityGroupIDs := make([]string, len(cloudServer.Spec.SecurityGroupReferences))
-			for i, sgRef := range cloudServer.Spec.SecurityGroupReferences {
-				sgID, err := r.GetSecurityGroupID(ctx, sgRef.Name, sgRef.Namespace)
-				if err != nil {
-					return fmt.Errorf("failed to get security group ID for %s/%s: %w", sgRef.Namespace, sgRef.Name, err)
-				}
-				securityGroupIDs[i] = sgID
-			}
-
-			// Update cloud server via API
-			cloudServerReq := arubaClient.CloudServerRequest{
-				Metadata: arubaClient.CloudServerMetadata{
-					Name: cloudServer.Name,
-					Tags: cloudServer.Spec.Tags,
-					Location: arubaClient.CloudServerLocation{
-						Value: cloudServer.Spec.Location.Value,
-					},
-				},
-			}
-
-			// Add optional fields
 			var elasticIpID string
 			if cloudServer.Spec.ElasticIpReference != nil {
-				elasticIpID, err := r.GetElasticIpID(ctx, cloudServer.Spec.ElasticIpReference.Name, cloudServer.Spec.ElasticIpReference.Namespace)
+				elasticIpID, err = r.ElasticIpIDOf(ctx, *cloudServer.Spec.ElasticIpReference, projectID)
 				if err != nil {
 					return fmt.Errorf("failed to get elastic IP ID: %w", err)
 				}
-				cloudServerReq.Properties.ElasticIp = &arubaClient.CloudServerResourceReference{URI: r.buildElasticIpURI(projectID, elasticIpID)}
 			}
 
-			// Add subnets and security groups
-			for _, subnetID := range subnetIDs {
-				cloudServerReq.Properties.Subnets = append(cloudServerReq.Properties.Subnets,
-					arubaClient.CloudServerResourceReference{URI: r.buildSubnetURI(projectID, vpcID, subnetID)})
-			}
-			for _, sgID := range securityGroupIDs {
-				cloudServerReq.Properties.SecurityGroups = append(cloudServerReq.Properties.SecurityGroups,
-					arubaClient.CloudServerResourceReference{URI: r.buildSecurityGroupURI(projectID, vpcID, sgID)})
+			// The flavor is changed by a resize once the update is applied, see reconcileResize
+			flavorName := reconciler.EffectiveFlavor(cloudServer)
+			if flavorName == "" {
+				flavorName = cloudServer.Spec.FlavorName
 			}
 
+			// Update cloud server via API
+			cloudServerReq := r.updateRequest(cloudServer, flavorName, subnetIDs, securityGroupIDs, elasticIpID)
 			_, err := r.UpdateCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID, cloudServerReq)
 			if err != nil {
 				return err
@@ -266,11 +271,12 @@ func (r *CloudServerReconciler) Updating(ctx context.Context, obj client.Object,
 			// Update status with new resolved IDs
 			cloudServer.Status.SubnetIDs = subnetIDs
 			cloudServer.Status.SecurityGroupIDs = securityGroupIDs
-			if elasticIpID != "" {
-				cloudServer.Status.ElasticIpID = elasticIpID
-			} else {
-				cloudServer.Status.ElasticIpID = ""
+			cloudServer.Status.ResolvedSecurityGroups = securityGroups
+			if cloudServer.Status.ElasticIpID != elasticIpID {
+				// The address is looked up again by connectionDetails
+				cloudServer.Status.PublicIPAddress = ""
 			}
+			cloudServer.Status.ElasticIpID = elasticIpID
 		}
 
 		// Now handle data volume management
@@ -278,19 +284,57 @@ func (r *CloudServerReconciler) Updating(ctx context.Context, obj client.Object,
 	})
 }
 
+// updateRequest builds the request updating the cloud server, the PUT replacing all of its properties
+func (r *CloudServerReconciler) updateRequest(cloudServer *v1alpha1.CloudServer, flavorName string, subnetIDs, securityGroupIDs []string, elasticIpID string) arubaClient.CloudServerRequest {
+	projectID := cloudServer.Status.ProjectID
+	vpcID := cloudServer.Status.VpcID
+	cloudServerReq := arubaClient.CloudServerRequest{
+		Metadata: arubaClient.CloudServerMetadata{
+			Name: cloudServer.Name,
+			Tags: r.ResourceTags(cloudServer),
+			Location: arubaClient.CloudServerLocation{
+				Value: cloudServer.Spec.Location.Value,
+			},
+		},
+		Properties: arubaClient.CloudServerProperties{
+			DataCenter: cloudServer.Spec.DataCenter,
+			VPC:        arubaClient.CloudServerResourceReference{URI: r.buildVpcURI(projectID, vpcID)},
+			BootVolume: arubaClient.CloudServerResourceReference{URI: r.buildVolumeURI(projectID, cloudServer.Status.BootVolumeID)},
+			VpcPreset:  cloudServer.Spec.VpcPreset,
+			FlavorName: flavorName,
+			KeyPair:    arubaClient.CloudServerResourceReference{URI: r.buildKeyPairURI(projectID, cloudServer.Status.KeyPairID)},
+		},
+	}
+
+	if elasticIpID != "" {
+		cloudServerReq.Properties.ElasticIp = &arubaClient.CloudServerResourceReference{URI: r.buildElasticIpURI(projectID, elasticIpID)}
+	}
+	for _, subnetID := range subnetIDs {
+		cloudServerReq.Properties.Subnets = append(cloudServerReq.Properties.Subnets,
+			arubaClient.CloudServerResourceReference{URI: r.buildSubnetURI(projectID, vpcID, subnetID)})
+	}
+	for _, sgID := range securityGroupIDs {
+		cloudServerReq.Properties.SecurityGroups = append(cloudServerReq.Properties.SecurityGroups,
+			arubaClient.CloudServerResourceReference{URI: r.buildSecurityGroupURI(projectID, vpcID, sgID)})
+	}
+	return cloudServerReq
+}
+
 // manageDataVolumesInUpdate handles attaching and detaching data volumes during update phase
 func (r *CloudServerReconciler) manageDataVolumesInUpdate(ctx context.Context, cloudServer *v1alpha1.CloudServer, projectID string) error {
 	phaseLogger := ctrl.Log.WithValues("Phase", "Updating", "Kind", cloudServer.GetObjectKind().GroupVersionKind().Kind, "Name", cloudServer.GetName())
 
 	// Resolve and calculate volume changes
-	desiredVolumeIDs, toAttach, toDetach, err := r.resolveAndCheckDataVolumes(ctx, cloudServer)
+	desiredVolumeIDs, dataVolumes, err := r.resolveDataVolumes(ctx, cloudServer)
 	if err != nil {
 		phaseLogger.Error(err, "failed to resolve data volume references")
 		return err
 	}
+	toAttach, toDetach := util.CalculateVolumeChanges(desiredVolumeIDs, cloudServer.Status.DataVolumeIDs)
 
 	// If no changes needed, return early
 	if len(toAttach) == 0 && len(toDetach) == 0 {
+		cloudServer.Status.ResolvedDataVolumes = dataVolumes
 		return nil
 	}
 
@@ -322,6 +366,7 @@ func (r *CloudServerReconciler) manageDataVolumesInUpdate(ctx context.Context, c
 
 	// Update status with new data volume IDs
 	cloudServer.Status.DataVolumeIDs = desiredVolumeIDs
+	cloudServer.Status.ResolvedDataVolumes = dataVolumes
 
 	phaseLogger.Info("Data volumes managed successfully")
 	return nil
@@ -332,6 +377,39 @@ func (r *CloudServerReconciler) Created(ctx context.Context, obj client.Object,
 	cloudServer := obj.(*v1alpha1.CloudServer)
 	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", cloudServer.GetObjectKind().GroupVersionKind().Kind, "Name", cloudServer.GetName())
 
+	// Check the cloud server was not deleted outside of the operator
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, cloudServer.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		cloudServer.Status.PowerState = ""
+		if resp.Status != nil {
+			cloudServer.Status.PowerState = reconciler.PowerStateOf(resp.Status.State)
+		}
+		cloudServer.Status.IPAddress = resp.Properties.IPAddress
+		cloudServer.Status.Flavor = reconciler.ObservedFlavor(resp.Properties, cloudServer.Status.Flavor)
+		// The address of the elastic IP only changes with the attached elastic IP, see Updating
+		if cloudServer.Spec.ElasticIpReference == nil {
+			cloudServer.Status.PublicIPAddress = ""
+		} else if cloudServer.Status.PublicIPAddress == "" {
+			cloudServer.Status.PublicIPAddress = r.publicIPAddress(ctx, cloudServer)
+		}
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
+	var details map[string]string
+	if cloudServer.Spec.WriteConnectionSecretToRef != nil {
+		details = connectionDetails(cloudServer)
+	}
+	if err := r.PublishConnectionSecret(ctx, obj, cloudServer.Spec.WriteConnectionSecretToRef, details); err != nil {
+		r.RecordEvent(obj, corev1.EventTypeWarning, "ConnectionSecretError", err.Error())
+	}
+
 	// Check if data volumes need to be managed
 	_, toAttach, toDetach, err := r.resolveAndCheckDataVolumes(ctx, cloudServer)
 
@@ -355,21 +433,92 @@ func (r *CloudServerReconciler) Created(ctx context.Context, obj client.Object,
 		)
 	}
 
+	// Check if the selected security groups changed
+	securityGroupIDs, _, err := r.resolveSecurityGroups(ctx, cloudServer, cloudServer.Status.ProjectID, cloudServer.Status.VpcID)
+	if err != nil {
+		phaseLogger.Error(err, "failed to check security group update status")
+		return r.NextToFailedOnApiError(ctx, obj, status, err)
+	}
+
+	if !util.SameIDs(securityGroupIDs, cloudServer.Status.SecurityGroupIDs) {
+		phaseLogger.Info("Security groups need to be updated, transitioning to Updating phase")
+		return r.Next(
+			ctx,
+			obj,
+			status,
+			v1alpha1.ResourcePhaseUpdating,
+			metav1.ConditionFalse,
+			"UpdatingSecurityGroups",
+			"Security groups need to be updated",
+			true,
+		)
+	}
+
 	// Check for other updates (generation mismatch)
+	// Resizes and power actions wait for the pending spec changes to be applied, a resize in
+	// progress is completed first
+	resizing := cloudServer.Status.Resize != nil && reconciler.ResizeInProgress(cloudServer.Status.Resize.Phase)
+	if resizing || status.ObservedGeneration == cloudServer.GetGeneration() {
+		if result, changed, err := r.reconcileResize(ctx, cloudServer, status); changed {
+			return result, err
+		}
+	}
+	if status.ObservedGeneration == cloudServer.GetGeneration() {
+		if result, changed, err := r.reconcilePower(ctx, cloudServer, status); changed {
+			return result, err
+		}
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
+// connectionDetails returns the details published in the connection Secret of the cloud server.
+// The public IP is the address of the attached elastic IP, when it is known.
+func connectionDetails(cloudServer *v1alpha1.CloudServer) map[string]string {
+	details := map[string]string{
+		reconciler.ConnectionKeyPrivateIP: cloudServer.Status.IPAddress,
+		reconciler.ConnectionKeyUsername:  cloudServer.Spec.SSHUsername,
+		reconciler.ConnectionKeyKeyPair:   cloudServer.Spec.KeyPairReference.Name,
+	}
+	if cloudServer.Spec.KeyPairReference.ID != "" {
+		details[reconciler.ConnectionKeyKeyPair] = cloudServer.Spec.KeyPairReference.ID
+	}
+	if cloudServer.Status.PublicIPAddress != "" {
+		details[reconciler.ConnectionKeyPublicIP] = cloudServer.Status.PublicIPAddress
+	}
+	return details
+}
+
+// publicIPAddress looks up the address of the elastic IP referenced by the cloud server.
+// It returns an empty string while the address is not known yet.
+func (r *CloudServerReconciler) publicIPAddress(ctx context.Context, cloudServer *v1alpha1.CloudServer) string {
+	ref := cloudServer.Spec.ElasticIpReference
+	if ref.ID != "" {
+		elasticIp, err := r.GetElasticIp(ctx, cloudServer.Status.ProjectID, ref.ID)
+		if err != nil {
+			return ""
+		}
+		return elasticIp.Properties.IPAddress
+	}
+
+	elasticIp := &v1alpha1.ElasticIp{}
+	key := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = cloudServer.Namespace
+	}
+	if err := r.Get(ctx, key, elasticIp); err != nil {
+		return ""
+	}
+	return elasticIp.Status.Address
+}
+
 // checkDataVolumesNeedUpdate checks if data volumes need to be attached or detached
 // Returns: needsUpdate (bool), desiredVolumeIDs ([]string), error
 func (r *CloudServerReconciler) resolveAndCheckDataVolumes(ctx context.Context, cloudServer *v1alpha1.CloudServer) ([]string, []string, []string, error) {
 	// Resolve desired data volume IDs from spec
-	desiredVolumeIDs := make([]string, 0, len(cloudServer.Spec.DataVolumeReferences))
-	for _, volumeRef := range cloudServer.Spec.DataVolumeReferences {
-		volumeID, err := r.GetBlockStorageID(ctx, volumeRef.Name, volumeRef.Namespace)
-		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to get data volume ID for %s/%s: %w", volumeRef.Namespace, volumeRef.Name, err)
-		}
-		desiredVolumeIDs = append(desiredVolumeIDs, volumeID)
+	desiredVolumeIDs, _, err := r.resolveDataVolumes(ctx, cloudServer)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	// Calculate volumes to attach and detach
@@ -378,6 +527,261 @@ func (r *CloudServerReconciler) resolveAndCheckDataVolumes(ctx context.Context,
 	return desiredVolumeIDs, toAttach, toDetach, nil
 }
 
+// resolveDataVolumes returns the IDs of the data volumes referenced by the spec, followed by
+// the ones selected by dataVolumeSelector, and the references of all of them. Selected
+// volumes that are not created yet, being deleted or used as boot volume are skipped.
+func (r *CloudServerReconciler) resolveDataVolumes(ctx context.Context, cloudServer *v1alpha1.CloudServer) ([]string, []v1alpha1.ResourceReference, error) {
+	volumeIDs := make([]string, 0, len(cloudServer.Spec.DataVolumeReferences))
+	volumes := make([]v1alpha1.ResourceReference, 0, len(cloudServer.Spec.DataVolumeReferences))
+	for _, volumeRef := range cloudServer.Spec.DataVolumeReferences {
+		volumeID, err := r.BlockStorageIDOf(ctx, volumeRef, cloudServer.Status.ProjectID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get data volume ID for %s: %w", volumeRef, err)
+		}
+		volumeIDs = append(volumeIDs, volumeID)
+		volumes = append(volumes, volumeRef)
+	}
+
+	if cloudServer.Spec.DataVolumeSelector == nil {
+		return volumeIDs, volumes, nil
+	}
+	selected := &v1alpha1.BlockStorageList{}
+	if err := r.listSelected(ctx, cloudServer, cloudServer.Spec.DataVolumeSelector, selected); err != nil {
+		return nil, nil, fmt.Errorf("failed to list the selected data volumes: %w", err)
+	}
+	bootVolume := cloudServer.Spec.BootVolumeReference
+	for _, volume := range selected.Items {
+		ref := v1alpha1.ResourceReference{Name: volume.Name, Namespace: volume.Namespace}
+		isBootVolume := volume.Status.ResourceID == cloudServer.Status.BootVolumeID ||
+			ref.Name == bootVolume.Name && (bootVolume.Namespace == "" || ref.Namespace == bootVolume.Namespace)
+		if isBootVolume || volume.Status.ResourceID == "" || !volume.DeletionTimestamp.IsZero() || slices.Contains(volumeIDs, volume.Status.ResourceID) {
+			continue
+		}
+		volumeIDs = append(volumeIDs, volume.Status.ResourceID)
+		volumes = append(volumes, ref)
+	}
+
+	return volumeIDs, volumes, nil
+}
+
+// resolveSecurityGroups returns the IDs of the security groups referenced by the spec, followed
+// by the ones selected by securityGroupSelector, and the references of all of them. Selected
+// security groups that are not created yet or being deleted are skipped.
+func (r *CloudServerReconciler) resolveSecurityGroups(ctx context.Context, cloudServer *v1alpha1.CloudServer, projectID, vpcID string) ([]string, []v1alpha1.ResourceReference, error) {
+	securityGroupIDs := make([]string, 0, len(cloudServer.Spec.SecurityGroupReferences))
+	securityGroups := make([]v1alpha1.ResourceReference, 0, len(cloudServer.Spec.SecurityGroupReferences))
+	for _, sgRef := range cloudServer.Spec.SecurityGroupReferences {
+		sgID, err := r.SecurityGroupIDOf(ctx, sgRef, projectID, vpcID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get security group ID for %s: %w", sgRef, err)
+		}
+		securityGroupIDs = append(securityGroupIDs, sgID)
+		securityGroups = append(securityGroups, sgRef)
+	}
+
+	if cloudServer.Spec.SecurityGroupSelector == nil {
+		return securityGroupIDs, securityGroups, nil
+	}
+	selected := &v1alpha1.SecurityGroupList{}
+	if err := r.listSelected(ctx, cloudServer, cloudServer.Spec.SecurityGroupSelector, selected); err != nil {
+		return nil, nil, fmt.Errorf("failed to list the selected security groups: %w", err)
+	}
+	for _, securityGroup := range selected.Items {
+		if securityGroup.Status.ResourceID == "" || !securityGroup.DeletionTimestamp.IsZero() || slices.Contains(securityGroupIDs, securityGroup.Status.ResourceID) {
+			continue
+		}
+		securityGroupIDs = append(securityGroupIDs, securityGroup.Status.ResourceID)
+		securityGroups = append(securityGroups, v1alpha1.ResourceReference{Name: securityGroup.Name, Namespace: securityGroup.Namespace})
+	}
+
+	return securityGroupIDs, securityGroups, nil
+}
+
+// listSelected lists into list the resources of the namespace of cloudServer matching selector, sorted by name
+func (r *CloudServerReconciler) listSelected(ctx context.Context, cloudServer *v1alpha1.CloudServer, selector *metav1.LabelSelector, list client.ObjectList) error {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return err
+	}
+	if err := r.List(ctx, list, client.InNamespace(cloudServer.Namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].(client.Object).GetName() < items[j].(client.Object).GetName()
+	})
+	return meta.SetList(list, items)
+}
+
+// reconcileResize runs the next step resizing the cloud server to spec.flavorName, reporting
+// whether the status was changed. A flavor the API rejects is refused rather than failing the
+// cloud server, which keeps running with its current flavor.
+func (r *CloudServerReconciler) reconcileResize(ctx context.Context, cloudServer *v1alpha1.CloudServer, status *v1alpha1.ResourceStatus) (ctrl.Result, bool, error) {
+	resize := cloudServer.Status.Resize
+	plan := reconciler.PlanResize(cloudServer)
+	if plan.Run == "" && resize != nil && plan.Phase == resize.Phase {
+		if reconciler.ResizeInProgress(plan.Phase) {
+			// Wait for the cloud server to stop or the new flavor to be applied
+			result, err := r.Next(ctx, cloudServer, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionFalse,
+				"Resizing", fmt.Sprintf("Resize from %s to %s in progress (%s)", resize.From, resize.To, resize.Phase), true)
+			return result, true, err
+		}
+		if plan.Phase == v1alpha1.CloudServerResizeRefused && cloudServer.Spec.FlavorName != resize.To &&
+			meta.IsStatusConditionFalse(status.Conditions, v1alpha1.ConditionTypeFlavorCompatible) {
+			// The refused flavor was reverted
+			status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeFlavorCompatible, metav1.ConditionTrue,
+				"FlavorApplied", fmt.Sprintf("Cloud server runs with flavor %s", reconciler.EffectiveFlavor(cloudServer)))
+			result, err := r.Next(ctx, cloudServer, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionTrue,
+				"FlavorApplied", "Resource is up to date", false)
+			return result, true, err
+		}
+		return ctrl.Result{}, false, nil
+	}
+	if plan.Run == "" && plan.Phase == "" {
+		return ctrl.Result{}, false, nil
+	}
+
+	if plan.Start {
+		resize = &v1alpha1.CloudServerResize{From: reconciler.EffectiveFlavor(cloudServer), To: cloudServer.Spec.FlavorName}
+	} else {
+		resize = resize.DeepCopy()
+	}
+
+	// The status only moves to the next phase once its step succeeded
+	switch plan.Run {
+	case reconciler.ResizeStepPowerOff:
+		if err := r.PowerOffCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID); err != nil {
+			result, err := r.NextToFailedOnApiError(ctx, cloudServer, status, err)
+			return result, true, err
+		}
+	case reconciler.ResizeStepResize:
+		cloudServerReq := r.updateRequest(cloudServer, resize.To, cloudServer.Status.SubnetIDs, cloudServer.Status.SecurityGroupIDs, cloudServer.Status.ElasticIpID)
+		if _, err := r.UpdateCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID, cloudServerReq); err != nil {
+			var apiErr *arubaClient.ApiError
+			if !errors.As(err, &apiErr) || !apiErr.IsValidationError() {
+				result, err := r.NextToFailedOnApiError(ctx, cloudServer, status, err)
+				return result, true, err
+			}
+			return r.refuseResize(ctx, cloudServer, status, resize, apiErr)
+		}
+		// The flavor applied stands for the flavor of the cloud server while the API doesn't return it,
+		// the next remote check replaces it with the flavor returned otherwise
+		cloudServer.Status.Flavor = &v1alpha1.CloudServerFlavor{Name: resize.To}
+	}
+	if plan.Start {
+		r.RecordEvent(cloudServer, corev1.EventTypeNormal, "Resizing", fmt.Sprintf("Resizing from %s to %s", resize.From, resize.To))
+	}
+	resize.Phase = plan.Phase
+	cloudServer.Status.Resize = resize
+
+	reason, message := "Resizing", fmt.Sprintf("Resize from %s to %s in progress (%s)", resize.From, resize.To, resize.Phase)
+	condStatus := metav1.ConditionFalse
+	if plan.Phase == v1alpha1.CloudServerResizeCompleted {
+		reason, message = "Resized", fmt.Sprintf("Cloud server resized from %s to %s", resize.From, resize.To)
+		condStatus = metav1.ConditionTrue
+		status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeFlavorCompatible, metav1.ConditionTrue,
+			"FlavorApplied", fmt.Sprintf("Cloud server runs with flavor %s", resize.To))
+		r.RecordEvent(cloudServer, corev1.EventTypeNormal, reason, message)
+	}
+	// Without requeue the debounce of Next can't skip the status update, the result still requeues
+	result, err := r.Next(ctx, cloudServer, status, v1alpha1.ResourcePhaseCreated, condStatus, reason, message, false)
+	return result, true, err
+}
+
+// refuseResize records that the API rejected the requested flavor. The cloud server keeps its
+// flavor and is brought back to its desired power state.
+func (r *CloudServerReconciler) refuseResize(ctx context.Context, cloudServer *v1alpha1.CloudServer, status *v1alpha1.ResourceStatus, resize *v1alpha1.CloudServerResize, apiErr *arubaClient.ApiError) (ctrl.Result, bool, error) {
+	reason := apiErr.Title
+	if fieldErrors := apiErr.FieldErrors(); fieldErrors != "" {
+		reason = fmt.Sprintf("%s: %s", reason, fieldErrors)
+	}
+	message := fmt.Sprintf("Flavor %s is incompatible with the cloud server, keeping %s: %s", resize.To, resize.From, reason)
+
+	resize.Phase = v1alpha1.CloudServerResizeRefused
+	resize.Message = reason
+	cloudServer.Status.Resize = resize
+	status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeFlavorCompatible, metav1.ConditionFalse, "IncompatibleFlavor", message)
+	r.RecordEvent(cloudServer, corev1.EventTypeWarning, "ResizeRefused", message)
+	result, err := r.Next(ctx, cloudServer, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionFalse, "IncompatibleFlavor", message, false)
+	return result, true, err
+}
+
+// reconcilePower runs the next step bringing the cloud server to its desired power state,
+// reporting whether the status was changed
+func (r *CloudServerReconciler) reconcilePower(ctx context.Context, cloudServer *v1alpha1.CloudServer, status *v1alpha1.ResourceStatus) (ctrl.Result, bool, error) {
+	plan := reconciler.PlanPower(cloudServer)
+	if plan.Run == "" && plan.Action == cloudServer.Status.PowerAction && plan.HandledRestart == "" {
+		if plan.Action != "" {
+			// Wait for the action in progress
+			result, err := r.Next(ctx, cloudServer, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionTrue,
+				string(plan.Action), fmt.Sprintf("Power action %s in progress", plan.Action), true)
+			return result, true, err
+		}
+		return ctrl.Result{}, false, nil
+	}
+
+	switch plan.Run {
+	case v1alpha1.CloudServerPowerActionOn:
+		if err := r.PowerOnCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID); err != nil {
+			result, err := r.NextToFailedOnApiError(ctx, cloudServer, status, err)
+			return result, true, err
+		}
+	case v1alpha1.CloudServerPowerActionOff:
+		if err := r.PowerOffCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID); err != nil {
+			result, err := r.NextToFailedOnApiError(ctx, cloudServer, status, err)
+			return result, true, err
+		}
+	}
+
+	message := fmt.Sprintf("Cloud server is powered %s", cloudServer.Status.PowerState)
+	reason := "PowerStateReached"
+	if plan.Action != "" {
+		message = fmt.Sprintf("Power action %s in progress", plan.Action)
+		reason = string(plan.Action)
+		r.RecordEvent(cloudServer, corev1.EventTypeNormal, reason, message)
+	} else if plan.HandledRestart != "" && plan.Run == "" {
+		message = fmt.Sprintf("Restart skipped, the cloud server is powered %s", cloudServer.Status.PowerState)
+		reason = "RestartSkipped"
+	}
+	cloudServer.Status.PowerAction = plan.Action
+	if plan.HandledRestart != "" {
+		cloudServer.Status.LastRestartRequest = plan.HandledRestart
+	}
+	// Without requeue the debounce of Next can't skip the status update, the result still requeues
+	result, err := r.Next(ctx, cloudServer, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionTrue, reason, message, false)
+	return result, true, err
+}
+
+// cloudServersSelecting returns a map function enqueuing the cloud servers whose selector,
+// returned by selectorOf, matches the labels of the mapped object
+func (r *CloudServerReconciler) cloudServersSelecting(selectorOf func(*v1alpha1.CloudServer) *metav1.LabelSelector) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		cloudServers := &v1alpha1.CloudServerList{}
+		if err := r.List(ctx, cloudServers, client.InNamespace(obj.GetNamespace())); err != nil {
+			ctrl.Log.Error(err, "failed to list cloud servers", "Namespace", obj.GetNamespace())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cloudServer := range cloudServers.Items {
+			selector := selectorOf(&cloudServer)
+			if selector == nil {
+				continue
+			}
+			labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+			if err != nil || !labelSelector.Matches(labels.Set(obj.GetLabels())) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: cloudServer.Name, Namespace: cloudServer.Namespace}})
+		}
+		return requests
+	}
+}
+
 // Deleting handles the actual cloud server deletion
 func (r *CloudServerReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	cloudServer := obj.(*v1alpha1.CloudServer)
@@ -574,5 +574,56 @@ var _ = Describe("CloudServer Controller Reconcile Method", func() {
 			Expect(k8sClient.Delete(ctx, arubaCloudServer)).To(Succeed())
 			Expect(k8sClient.Delete(ctx, testProject)).To(Succeed())
 		})
+
+		It("should resolve the data volumes selected by labels", func() {
+			By("Creating labelled block storages")
+			newVolume := func(name, resourceID string, labels map[string]string) *v1alpha1.BlockStorage {
+				volume := &v1alpha1.BlockStorage{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+					Spec: v1alpha1.BlockStorageSpec{
+						Location:         v1alpha1.Location{Value: "ITBG-Bergamo"},
+						SizeGb:           40,
+						BillingPeriod:    "Hour",
+						DataCenter:       "ITBG-1",
+						ProjectReference: v1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, volume)).To(Succeed())
+				if resourceID != "" {
+					volume.Status.ResourceID = resourceID
+					Expect(k8sClient.Status().Update(ctx, volume)).To(Succeed())
+				}
+				return volume
+			}
+			selected := map[string]string{"attach-to": "web-1"}
+			volumes := []*v1alpha1.BlockStorage{
+				newVolume("selector-data-b", "volume-b", selected),
+				newVolume("selector-data-a", "volume-a", selected),
+				newVolume("selector-data-pending", "", selected),
+				newVolume("selector-data-other", "volume-other", map[string]string{"attach-to": "web-2"}),
+				newVolume("selector-boot", "volume-boot", selected),
+			}
+
+			By("Resolving the data volumes of a cloud server selecting them")
+			cloudServer := &v1alpha1.CloudServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "selector-server", Namespace: "default"},
+				Spec: v1alpha1.CloudServerSpec{
+					BootVolumeReference: v1alpha1.ResourceReference{Name: "selector-boot", Namespace: "default"},
+					DataVolumeSelector:  &metav1.LabelSelector{MatchLabels: selected},
+				},
+			}
+			volumeIDs, resolved, err := cloudServerReconciler.resolveDataVolumes(ctx, cloudServer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumeIDs).To(Equal([]string{"volume-a", "volume-b"}))
+			Expect(resolved).To(Equal([]v1alpha1.ResourceReference{
+				{Name: "selector-data-a", Namespace: "default"},
+				{Name: "selector-data-b", Namespace: "default"},
+			}))
+
+			By("Cleanup")
+			for _, volume := range volumes {
+				Expect(k8sClient.Delete(ctx, volume)).To(Succeed())
+			}
+		})
 	})
 })
@@ -3,8 +3,11 @@ package controller
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
@@ -37,6 +40,8 @@ func (r *ElasticIpReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 func (r *ElasticIpReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.ElasticIp{}).
+		Owns(&corev1.Secret{}, builder.OnlyMetadata).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.ElasticIpList{}))).
 		Named("elasticip").
 		Complete(r)
 }
@@ -52,11 +57,7 @@ func (r *ElasticIpReconciler) Init(ctx context.Context, obj client.Object, statu
 func (r *ElasticIpReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	elasticIp := obj.(*v1alpha1.ElasticIp)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
-		projectID, err := r.GetProjectID(
-			ctx,
-			elasticIp.Spec.ProjectReference.Name,
-			elasticIp.Spec.ProjectReference.Namespace,
-		)
+		projectID, err := r.ProjectIDOf(ctx, elasticIp.Spec.ProjectReference)
 		if err != nil {
 			return "", "", err
 		}
@@ -64,7 +65,7 @@ func (r *ElasticIpReconciler) Creating(ctx context.Context, obj client.Object, s
 		elasticIpReq := arubaClient.ElasticIpRequest{
 			Metadata: arubaClient.ElasticIpMetadata{
 				Name: elasticIp.Name,
-				Tags: elasticIp.Spec.Tags,
+				Tags: r.ResourceTags(elasticIp),
 				Location: arubaClient.ElasticIpLocation{
 					Value: elasticIp.Spec.Location.Value,
 				},
@@ -113,7 +114,7 @@ func (r *ElasticIpReconciler) Updating(ctx context.Context, obj client.Object, s
 		elasticIpReq := arubaClient.ElasticIpRequest{
 			Metadata: arubaClient.ElasticIpMetadata{
 				Name: elasticIp.Name,
-				Tags: elasticIp.Spec.Tags,
+				Tags: r.ResourceTags(elasticIp),
 				Location: arubaClient.ElasticIpLocation{
 					Value: elasticIp.Spec.Location.Value,
 				},
@@ -131,6 +132,26 @@ func (r *ElasticIpReconciler) Updating(ctx context.Context, obj client.Object, s
 }
 
 func (r *ElasticIpReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	elasticIp := obj.(*v1alpha1.ElasticIp)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, elasticIp.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetElasticIp(ctx, elasticIp.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		elasticIp.Status.Address = resp.Properties.IPAddress
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
+	if err := r.PublishConnectionSecret(ctx, obj, elasticIp.Spec.WriteConnectionSecretToRef, map[string]string{
+		reconciler.ConnectionKeyPublicIP: elasticIp.Status.Address,
+	}); err != nil {
+		r.RecordEvent(obj, corev1.EventTypeWarning, "ConnectionSecretError", err.Error())
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
@@ -29,6 +29,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -476,3 +477,224 @@ var _ = Describe("ElasticIp Controller Reconcile Method", func() {
 		})
 	})
 })
+
+var _ = Describe("ElasticIp Controller Remote Deletion", func() {
+	Context("When the remote elastic IP was deleted outside of the operator", func() {
+		var (
+			ctx                context.Context
+			resourceReconciler *ElasticIpReconciler
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			auth := new(mocks.MockITokenManager)
+			auth.On("GetActiveToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token 123", nil)
+
+			// Create mock HTTP client that returns 404 for all requests
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.AnythingOfType("*http.Request")).Return(
+				func(*http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: 404,
+						Body:       io.NopCloser(strings.NewReader(`{"title": "Not Found", "status": 404}`)),
+						Header:     make(http.Header),
+					}
+				}, nil)
+
+			helperClient := client.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com")
+
+			resourceReconciler = &ElasticIpReconciler{
+				Reconciler: &reconciler.Reconciler{
+					Client:       k8sClient,
+					Scheme:       k8sClient.Scheme(),
+					HelperClient: helperClient,
+					TokenManager: auth,
+				},
+			}
+		})
+
+		createElasticIp := func(name string, policy v1alpha1.RemoteDeletionPolicy) *v1alpha1.ElasticIp {
+			elasticIp := &v1alpha1.ElasticIp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ElasticIpSpec{
+					Tenant: "test-tenant",
+					Location: v1alpha1.Location{
+						Value: "ITBG-Bergamo",
+					},
+					BillingPlan: v1alpha1.BillingPlan{
+						BillingPeriod: "Hour",
+					},
+					ProjectReference: v1alpha1.ResourceReference{
+						Name:      "test-project",
+						Namespace: "default",
+					},
+					RemoteDeletionPolicy: policy,
+				},
+			}
+			Expect(k8sClient.Create(ctx, elasticIp)).To(Succeed())
+
+			elasticIp.Status.Phase = v1alpha1.ResourcePhaseCreated
+			elasticIp.Status.ResourceID = "eip-12345"
+			elasticIp.Status.ProjectID = "project-12345"
+			elasticIp.Status.ObservedGeneration = elasticIp.Generation
+			Expect(k8sClient.Status().Update(ctx, elasticIp)).To(Succeed())
+			return elasticIp
+		}
+
+		It("should recreate the elastic IP with the Recreate policy", func() {
+			testName := fmt.Sprintf("test-remote-recreate-eip-%d", GinkgoRandomSeed())
+			elasticIp := createElasticIp(testName, v1alpha1.RemoteDeletionPolicyRecreate)
+
+			By("Reconciling the resource")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: testName, Namespace: "default"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the resource goes back to Creating")
+			updated := &v1alpha1.ElasticIp{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testName, Namespace: "default"}, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(v1alpha1.ResourcePhaseCreating))
+			Expect(updated.Status.ResourceID).To(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, v1alpha1.ConditionTypeRemoteMissing)).To(BeTrue())
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, elasticIp)).To(Succeed())
+		})
+
+		It("should report the missing elastic IP with the Report policy", func() {
+			testName := fmt.Sprintf("test-remote-report-eip-%d", GinkgoRandomSeed())
+			elasticIp := createElasticIp(testName, v1alpha1.RemoteDeletionPolicyReport)
+
+			By("Reconciling the resource")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: testName, Namespace: "default"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the resource keeps its ID and reports RemoteMissing")
+			updated := &v1alpha1.ElasticIp{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testName, Namespace: "default"}, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(v1alpha1.ResourcePhaseCreated))
+			Expect(updated.Status.ResourceID).To(Equal("eip-12345"))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, v1alpha1.ConditionTypeRemoteMissing)).To(BeTrue())
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionTypeSynchronized).Reason).To(Equal("RemoteMissing"))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, elasticIp)).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("ElasticIp Controller API Errors", func() {
+	Context("When the API rejects the elastic IP request", func() {
+		var (
+			ctx                context.Context
+			resourceReconciler *ElasticIpReconciler
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			auth := new(mocks.MockITokenManager)
+			auth.On("GetActiveToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token 123", nil)
+
+			// Create mock HTTP client that returns a validation error for all requests
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.AnythingOfType("*http.Request")).Return(
+				func(*http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: 400,
+						Body: io.NopCloser(strings.NewReader(`{
+							"type": "https://tools.ietf.org/html/rfc9110#section-15.5.1",
+							"title": "One or more validation errors occurred.",
+							"status": 400,
+							"traceId": "trace-123",
+							"parentId": "parent-456",
+							"errors": [{"field": "properties.billingPlan.billingPeriod", "message": "billing period is not supported"}]
+						}`)),
+						Header: make(http.Header),
+					}
+				}, nil)
+
+			helperClient := client.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com")
+
+			resourceReconciler = &ElasticIpReconciler{
+				Reconciler: &reconciler.Reconciler{
+					Client:       k8sClient,
+					Scheme:       k8sClient.Scheme(),
+					HelperClient: helperClient,
+					TokenManager: auth,
+				},
+			}
+		})
+
+		It("should fail and record the error details in status", func() {
+			By("Creating a test Project with a project ID")
+			projectName := fmt.Sprintf("test-last-error-project-%d", GinkgoRandomSeed())
+			testProject := &v1alpha1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      projectName,
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ProjectSpec{},
+			}
+			Expect(k8sClient.Create(ctx, testProject)).To(Succeed())
+			testProject.Status.ResourceID = "project-12345"
+			Expect(k8sClient.Status().Update(ctx, testProject)).To(Succeed())
+
+			By("Creating the elastic IP in the Creating phase")
+			testName := fmt.Sprintf("test-last-error-eip-%d", GinkgoRandomSeed())
+			elasticIp := &v1alpha1.ElasticIp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ElasticIpSpec{
+					Tenant: "test-tenant",
+					Location: v1alpha1.Location{
+						Value: "ITBG-Bergamo",
+					},
+					BillingPlan: v1alpha1.BillingPlan{
+						BillingPeriod: "Decade",
+					},
+					ProjectReference: v1alpha1.ResourceReference{
+						Name:      projectName,
+						Namespace: "default",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, elasticIp)).To(Succeed())
+			elasticIp.Status.Phase = v1alpha1.ResourcePhaseCreating
+			Expect(k8sClient.Status().Update(ctx, elasticIp)).To(Succeed())
+
+			By("Reconciling the resource")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: testName, Namespace: "default"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the resource failed with the error details")
+			updated := &v1alpha1.ElasticIp{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testName, Namespace: "default"}, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(v1alpha1.ResourcePhaseFailed))
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionTypeSynchronized).Reason).To(Equal("ValidationError"))
+			Expect(updated.Status.LastError).NotTo(BeNil())
+			Expect(updated.Status.LastError.HTTPStatus).To(Equal(400))
+			Expect(updated.Status.LastError.Title).To(Equal("One or more validation errors occurred."))
+			Expect(updated.Status.LastError.TraceID).To(Equal("trace-123"))
+			Expect(updated.Status.LastError.ParentID).To(Equal("parent-456"))
+			Expect(updated.Status.LastError.ObservedTime.IsZero()).To(BeFalse())
+			Expect(updated.Status.LastError.FieldErrors).To(ConsistOf(v1alpha1.FieldError{
+				Field:   "spec.billingPlan.billingPeriod",
+				Message: "billing period is not supported",
+			}))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, elasticIp)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, testProject)).To(Succeed())
+		})
+	})
+})
@@ -18,9 +18,18 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
@@ -43,7 +52,9 @@ func NewKeyPairReconciler(reconciler *reconciler.Reconciler) *KeyPairReconciler
 // +kubebuilder:rbac:groups=arubacloud.com,resources=keypairs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=arubacloud.com,resources=keypairs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=arubacloud.com,resources=projects,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=cloudservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=cloudservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 func (r *KeyPairReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -55,6 +66,13 @@ func (r *KeyPairReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 func (r *KeyPairReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.KeyPair{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.keyPairsReadingFrom(func(source *v1alpha1.KeyPairValueSource) *v1alpha1.LocalKeySelector {
+			return source.SecretKeyRef
+		})), builder.OnlyMetadata).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.keyPairsReadingFrom(func(source *v1alpha1.KeyPairValueSource) *v1alpha1.LocalKeySelector {
+			return source.ConfigMapKeyRef
+		})), builder.OnlyMetadata).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.KeyPairList{}))).
 		Named("keypair").
 		Complete(r)
 }
@@ -70,25 +88,29 @@ func (r *KeyPairReconciler) Init(ctx context.Context, obj client.Object, status
 func (r *KeyPairReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	keyPair := obj.(*v1alpha1.KeyPair)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
-		projectID, err := r.GetProjectID(
-			ctx,
-			keyPair.Spec.ProjectReference.Name,
-			keyPair.Spec.ProjectReference.Namespace,
-		)
+		projectID, err := r.ProjectIDOf(ctx, keyPair.Spec.ProjectReference)
 		if err != nil {
 			return "", "", err
 		}
 
+		// A generated key pair is stored before being sent, so a retry reuses it
+		publicKey, err := r.KeyPairPublicKeyOf(ctx, keyPair)
+		if err != nil {
+			return "", "", err
+		}
+		keyPair.Status.PublicKey = publicKey.PublicKey
+		keyPair.Status.Fingerprint = publicKey.Fingerprint
+
 		keyPairReq := arubaClient.KeyPairRequest{
 			Metadata: arubaClient.KeyPairMetadata{
 				Name: keyPair.Name,
-				Tags: keyPair.Spec.Tags,
+				Tags: r.ResourceTags(keyPair),
 				Location: arubaClient.KeyPairLocation{
 					Value: keyPair.Spec.Location.Value,
 				},
 			},
 			Properties: arubaClient.KeyPairProperties{
-				Value: keyPair.Spec.Value,
+				Value: publicKey.PublicKey,
 			},
 		}
 
@@ -125,11 +147,28 @@ func (r *KeyPairReconciler) Provisioning(ctx context.Context, obj client.Object,
 
 func (r *KeyPairReconciler) Updating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	keyPair := obj.(*v1alpha1.KeyPair)
+
+	// A rotation in progress is completed before anything else
+	if rotation := keyPair.Status.Rotation; rotation != nil && rotation.PreviousResourceID != "" {
+		if rotation.Phase == v1alpha1.KeyPairRotationDeletingPreviousKey {
+			return r.deletePreviousKey(ctx, keyPair, status)
+		}
+		return r.moveCloudServers(ctx, keyPair, status)
+	}
+
+	publicKey, err := r.KeyPairPublicKeyOf(ctx, keyPair)
+	if err != nil {
+		return r.NextToFailedOnApiError(ctx, obj, status, err)
+	}
+	if reconciler.KeyMaterialChanged(keyPair, publicKey) {
+		return r.rotateKey(ctx, keyPair, status, publicKey)
+	}
+
 	return r.HandleUpdating(ctx, obj, status, func(ctx context.Context) error {
 		keyPairReq := arubaClient.KeyPairUpdateRequest{
 			Metadata: arubaClient.KeyPairMetadata{
 				Name: keyPair.Name,
-				Tags: keyPair.Spec.Tags,
+				Tags: r.ResourceTags(keyPair),
 				Location: arubaClient.KeyPairLocation{
 					Value: keyPair.Spec.Location.Value,
 				},
@@ -141,13 +180,157 @@ func (r *KeyPairReconciler) Updating(ctx context.Context, obj client.Object, sta
 	})
 }
 
+// rotateKey replaces the remote keypair with one created with publicKey. The cloud servers using
+// the previous keypair are moved to the new one before the previous one is deleted.
+func (r *KeyPairReconciler) rotateKey(ctx context.Context, keyPair *v1alpha1.KeyPair, status *v1alpha1.ResourceStatus, publicKey *reconciler.KeyPairPublicKey) (ctrl.Result, error) {
+	keyPairResp, err := r.CreateKeyPair(ctx, keyPair.Status.ProjectID, arubaClient.KeyPairRequest{
+		Metadata: arubaClient.KeyPairMetadata{
+			Name: keyPair.Name,
+			Tags: r.ResourceTags(keyPair),
+			Location: arubaClient.KeyPairLocation{
+				Value: keyPair.Spec.Location.Value,
+			},
+		},
+		Properties: arubaClient.KeyPairProperties{
+			Value: publicKey.PublicKey,
+		},
+	})
+	if err != nil {
+		return r.NextToFailedOnApiError(ctx, keyPair, status, err)
+	}
+
+	keyPair.Status.Rotation = &v1alpha1.KeyPairRotation{
+		Phase:               v1alpha1.KeyPairRotationMovingCloudServers,
+		PreviousFingerprint: keyPair.Status.Fingerprint,
+		Fingerprint:         publicKey.Fingerprint,
+		PreviousResourceID:  status.ResourceID,
+		Message:             "the new keypair was created, the cloud servers using the previous one are being moved to it",
+	}
+	status.ResourceID = keyPairResp.Metadata.ID
+	status.AppliedTags = r.ResourceTags(keyPair)
+	keyPair.Status.PublicKey = publicKey.PublicKey
+	keyPair.Status.Fingerprint = publicKey.Fingerprint
+
+	// The new keypair is recorded before anything else can fail, a keypair missing from the
+	// status would never be deleted
+	if err := r.Client.Status().Update(ctx, keyPair); err != nil {
+		if deleteErr := r.DeleteKeyPair(ctx, keyPair.Status.ProjectID, keyPairResp.Metadata.ID); deleteErr != nil {
+			ctrl.Log.Error(deleteErr, "failed to delete the keypair missing from the status", "Name", keyPair.Name, "ID", keyPairResp.Metadata.ID)
+		}
+		return ctrl.Result{}, err
+	}
+	return r.moveCloudServers(ctx, keyPair, status)
+}
+
+// moveCloudServers moves the cloud servers using the keypair replaced by the rotation to the new
+// one. The rotation is blocked while the API refuses to update some of them.
+func (r *KeyPairReconciler) moveCloudServers(ctx context.Context, keyPair *v1alpha1.KeyPair, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	rotation := keyPair.Status.Rotation
+	refused, err := r.MoveCloudServersToKeyPair(ctx, keyPair.Status.ProjectID, rotation.PreviousResourceID, status.ResourceID)
+	if err != nil {
+		return r.NextToFailedOnApiError(ctx, keyPair, status, err)
+	}
+
+	if len(refused) > 0 {
+		message := fmt.Sprintf("the API refused to move CloudServers %s to the new keypair, the previous keypair %s is kept until they no longer use it",
+			strings.Join(refused, ", "), rotation.PreviousResourceID)
+		if rotation.Phase != v1alpha1.KeyPairRotationBlocked || rotation.Message != message {
+			r.RecordEvent(keyPair, corev1.EventTypeWarning, "KeyRotationBlocked", message)
+		}
+		rotation.Phase = v1alpha1.KeyPairRotationBlocked
+		rotation.Message = message
+		return r.Next(ctx, keyPair, status, v1alpha1.ResourcePhaseUpdating, metav1.ConditionFalse, "KeyRotationBlocked", message, false)
+	}
+
+	rotation.Phase = v1alpha1.KeyPairRotationDeletingPreviousKey
+	rotation.Message = "the cloud servers were moved to the new keypair, the previous one is being deleted"
+	return r.deletePreviousKey(ctx, keyPair, status)
+}
+
+// deletePreviousKey completes a rotation by deleting the replaced remote keypair
+func (r *KeyPairReconciler) deletePreviousKey(ctx context.Context, keyPair *v1alpha1.KeyPair, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	rotation := keyPair.Status.Rotation
+	err := r.DeleteKeyPair(ctx, keyPair.Status.ProjectID, rotation.PreviousResourceID)
+	var apiErr *arubaClient.ApiError
+	if err != nil && (!errors.As(err, &apiErr) || !apiErr.IsNotFound()) {
+		return r.NextToFailedOnApiError(ctx, keyPair, status, err)
+	}
+
+	rotation.Phase = v1alpha1.KeyPairRotationCompleted
+	rotation.PreviousResourceID = ""
+	rotation.Message = fmt.Sprintf("the key was rotated from %s to %s", rotation.PreviousFingerprint, rotation.Fingerprint)
+	r.RecordEvent(keyPair, corev1.EventTypeNormal, "KeyRotated", rotation.Message)
+	return r.Next(ctx, keyPair, status, v1alpha1.ResourcePhaseCreated, metav1.ConditionTrue, "KeyRotated", rotation.Message, false)
+}
+
 func (r *KeyPairReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	keyPair := obj.(*v1alpha1.KeyPair)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, keyPair.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetKeyPair(ctx, keyPair.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		// Keypairs created by previous versions don't record their key
+		if keyPair.Status.PublicKey == "" && resp.Properties != nil && resp.Properties.Value != "" {
+			keyPair.Status.PublicKey = resp.Properties.Value
+			if publicKey, err := reconciler.ParsePublicKey(resp.Properties.Value); err == nil {
+				keyPair.Status.Fingerprint = publicKey.Fingerprint
+			}
+		}
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
+	// Changes of the key read from valueFrom don't bump the generation
+	if keyPair.Spec.Generate == nil {
+		publicKey, err := r.KeyPairPublicKeyOf(ctx, keyPair)
+		if err != nil {
+			r.RecordEvent(keyPair, corev1.EventTypeWarning, "KeyMaterialError", err.Error())
+		} else if reconciler.KeyMaterialChanged(keyPair, publicKey) {
+			return r.Next(ctx, obj, status, v1alpha1.ResourcePhaseUpdating, metav1.ConditionFalse, "KeyChanged", "Key rotation initiated", true)
+		}
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
+// keyPairsReadingFrom returns a map function enqueuing the keypairs whose valueFrom selector,
+// returned by refOf, names the mapped object
+func (r *KeyPairReconciler) keyPairsReadingFrom(refOf func(*v1alpha1.KeyPairValueSource) *v1alpha1.LocalKeySelector) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		keyPairs := &v1alpha1.KeyPairList{}
+		if err := r.List(ctx, keyPairs, client.InNamespace(obj.GetNamespace())); err != nil {
+			ctrl.Log.Error(err, "failed to list keypairs", "Namespace", obj.GetNamespace())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, keyPair := range keyPairs.Items {
+			if keyPair.Spec.ValueFrom == nil {
+				continue
+			}
+			if ref := refOf(keyPair.Spec.ValueFrom); ref != nil && ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: keyPair.Name, Namespace: keyPair.Namespace}})
+			}
+		}
+		return requests
+	}
+}
+
 func (r *KeyPairReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	keyPair := obj.(*v1alpha1.KeyPair)
 	return r.HandleDeletion(ctx, obj, status, keyPairFinalizerName, func(ctx context.Context) error {
+		// The keypair replaced by an unfinished rotation is deleted too
+		if rotation := keyPair.Status.Rotation; rotation != nil && rotation.PreviousResourceID != "" {
+			err := r.DeleteKeyPair(ctx, keyPair.Status.ProjectID, rotation.PreviousResourceID)
+			var apiErr *arubaClient.ApiError
+			if err != nil && (!errors.As(err, &apiErr) || !apiErr.IsNotFound()) {
+				return err
+			}
+		}
 		return r.DeleteKeyPair(ctx, keyPair.Status.ProjectID, status.ResourceID)
 	})
 }
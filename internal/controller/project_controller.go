@@ -72,7 +72,7 @@ func (r *ProjectReconciler) Creating(ctx context.Context, obj client.Object, sta
 		projectReq := arubaClient.ProjectRequest{
 			Metadata: arubaClient.ProjectMetadata{
 				Name: project.Name,
-				Tags: project.Spec.Tags,
+				Tags: r.ResourceTags(project),
 			},
 			Properties: arubaClient.ProjectProperties{
 				Description: project.Spec.Description,
@@ -99,7 +99,7 @@ func (r *ProjectReconciler) Updating(ctx context.Context, obj client.Object, sta
 		projectReq := arubaClient.ProjectRequest{
 			Metadata: arubaClient.ProjectMetadata{
 				Name: project.Name,
-				Tags: project.Spec.Tags,
+				Tags: r.ResourceTags(project),
 			},
 			Properties: arubaClient.ProjectProperties{
 				Description: project.Spec.Description,
@@ -113,6 +113,19 @@ func (r *ProjectReconciler) Updating(ctx context.Context, obj client.Object, sta
 }
 
 func (r *ProjectReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	project := obj.(*v1alpha1.Project)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, project.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetProject(ctx, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(nil))
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
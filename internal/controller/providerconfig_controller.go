@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	apiError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+)
+
+const (
+	// providerCheckInterval defines how often the credentials of a ProviderConfig are checked
+	providerCheckInterval = 5 * time.Minute
+	// providerRetryInterval defines how often the credentials are checked again after a failure
+	providerRetryInterval = time.Minute
+)
+
+// ProviderConfigReconciler reports the authentication health of the ProviderConfigs
+type ProviderConfigReconciler struct {
+	*reconciler.Reconciler
+}
+
+// NewProviderConfigReconciler creates a new ProviderConfigReconciler
+func NewProviderConfigReconciler(reconciler *reconciler.Reconciler) *ProviderConfigReconciler {
+	return &ProviderConfigReconciler{
+		Reconciler: reconciler,
+	}
+}
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=providerconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *ProviderConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.Get(ctx, req.NamespacedName, pc); err != nil {
+		if apiError.IsNotFound(err) {
+			r.Providers.Forget(req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeAuthenticated,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Authenticated",
+		Message:            "Credentials accepted",
+		ObservedGeneration: pc.Generation,
+	}
+	requeue := providerCheckInterval
+
+	if err := r.Providers.Check(ctx, pc); err != nil {
+		ctrl.Log.Error(err, "ProviderConfig authentication failed", "ProviderConfig", pc.Name)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "AuthenticationFailed"
+		condition.Message = err.Error()
+		requeue = providerRetryInterval
+	} else {
+		now := metav1.Now()
+		pc.Status.LastAuthenticationTime = &now
+	}
+
+	meta.SetStatusCondition(&pc.Status.Conditions, condition)
+	pc.Status.ObservedGeneration = pc.Generation
+	if err := r.Status().Update(ctx, pc); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Status updates are
+// ignored, the credentials are checked again on spec changes and periodically.
+func (r *ProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("providerconfig").
+		Complete(r)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+var _ = Describe("ProviderConfig Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-providerconfig"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+		newReconciler := func(auth *mocks.MockITokenManager) *ProviderConfigReconciler {
+			providers := reconciler.NewProviderRegistry(k8sClient)
+			providers.NewTokenManager = func(keycloakURL, realm string) arubaClient.ITokenManager {
+				return auth
+			}
+			return NewProviderConfigReconciler(&reconciler.Reconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				Providers: providers,
+			})
+		}
+
+		BeforeEach(func() {
+			By("creating the credentials Secret and the ProviderConfig")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider-credentials", Namespace: "default"},
+				StringData: map[string]string{"client-id": "id", "client-secret": "secret"},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			resource := &v1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec: v1alpha1.ProviderConfigSpec{
+					APIGateway:  "https://api.example.com",
+					KeycloakURL: "https://login.example.com/auth",
+					Realm:       "test-realm",
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: v1alpha1.CredentialSourceSecret,
+						SecretRef: &v1alpha1.SecretCredentials{
+							Name:      "provider-credentials",
+							Namespace: "default",
+						},
+					},
+					AllowedNamespaces: v1alpha1.ProviderConfigNamespaces{Namespaces: []string{"default"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the ProviderConfig and its Secret")
+			Expect(k8sClient.Delete(ctx, &v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: resourceName}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "provider-credentials", Namespace: "default"}})).To(Succeed())
+		})
+
+		It("should report the accepted credentials", func() {
+			auth := new(mocks.MockITokenManager)
+			auth.On("SetClientIdAndSecret", "id", "secret").Return()
+			auth.On("GetAccessToken", false, "").Return("token 123", nil)
+
+			result, err := newReconciler(auth).Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(providerCheckInterval))
+
+			pc := &v1alpha1.ProviderConfig{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, pc)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(pc.Status.Conditions, v1alpha1.ConditionTypeAuthenticated)).To(BeTrue())
+			Expect(pc.Status.LastAuthenticationTime).NotTo(BeNil())
+		})
+
+		It("should report the rejected credentials", func() {
+			auth := new(mocks.MockITokenManager)
+			auth.On("SetClientIdAndSecret", mock.Anything, mock.Anything).Return()
+			auth.On("GetAccessToken", false, "").Return("", fmt.Errorf("invalid_client"))
+
+			result, err := newReconciler(auth).Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(providerRetryInterval))
+
+			pc := &v1alpha1.ProviderConfig{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, pc)).To(Succeed())
+			condition := meta.FindStatusCondition(pc.Status.Conditions, v1alpha1.ConditionTypeAuthenticated)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("invalid_client"))
+		})
+	})
+
+	Context("When a resource references a ProviderConfig", func() {
+		const resourceName = "restricted-providerconfig"
+
+		ctx := context.Background()
+
+		vpcName := types.NamespacedName{Name: "test-vpc-restricted", Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating a ProviderConfig not allowing the default namespace and a Vpc referencing it")
+			Expect(k8sClient.Create(ctx, &v1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec: v1alpha1.ProviderConfigSpec{
+					APIGateway:  "https://api.example.com",
+					KeycloakURL: "https://login.example.com/auth",
+					Realm:       "test-realm",
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: v1alpha1.CredentialSourceStatic,
+						Static: &v1alpha1.StaticCredentials{ClientID: "id", ClientSecret: "secret"},
+					},
+					AllowedNamespaces: v1alpha1.ProviderConfigNamespaces{Namespaces: []string{"team-a"}},
+				},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &v1alpha1.Vpc{
+				ObjectMeta: metav1.ObjectMeta{Name: vpcName.Name, Namespace: vpcName.Namespace},
+				Spec: v1alpha1.VpcSpec{
+					Tenant:            "test-tenant",
+					Location:          v1alpha1.Location{Value: "ITBG-Bergamo"},
+					ProjectReference:  v1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+					ProviderConfigRef: &v1alpha1.ProviderConfigReference{Name: resourceName},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Vpc and the ProviderConfig")
+			Expect(k8sClient.Delete(ctx, &v1alpha1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: vpcName.Name, Namespace: vpcName.Namespace}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: resourceName}})).To(Succeed())
+		})
+
+		It("should deny a namespace outside of the allowed namespaces", func() {
+			providers := reconciler.NewProviderRegistry(k8sClient)
+			providers.NewTokenManager = func(keycloakURL, realm string) arubaClient.ITokenManager {
+				Fail("the credentials of a ProviderConfig must not be used by a namespace it doesn't allow")
+				return nil
+			}
+			vpcReconciler := &VpcReconciler{Reconciler: &reconciler.Reconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				Providers: providers,
+			}}
+
+			result, err := vpcReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: vpcName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			vpc := &v1alpha1.Vpc{}
+			Expect(k8sClient.Get(ctx, vpcName, vpc)).To(Succeed())
+			condition := meta.FindStatusCondition(vpc.Status.Conditions, v1alpha1.ConditionTypeSynchronized)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(tenancy.ReasonProviderConfigNotAllowed))
+			Expect(vpc.Status.Phase).To(BeEmpty())
+		})
+
+		It("should require the allowed namespaces", func() {
+			pc := &v1alpha1.ProviderConfig{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName}, pc)).To(Succeed())
+			pc.Spec.AllowedNamespaces = v1alpha1.ProviderConfigNamespaces{}
+			Expect(k8sClient.Update(ctx, pc)).To(MatchError(ContainSubstring("namespaces or namespaceSelector is required")))
+		})
+	})
+})
@@ -21,6 +21,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
@@ -55,6 +56,7 @@ func (r *SecurityGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 func (r *SecurityGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.SecurityGroup{}).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.SecurityGroupList{}))).
 		Named("securitygroup").
 		Complete(r)
 }
@@ -70,16 +72,12 @@ func (r *SecurityGroupReconciler) Init(ctx context.Context, obj client.Object, s
 func (r *SecurityGroupReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	securityGroup := obj.(*v1alpha1.SecurityGroup)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
-		projectID, err := r.GetProjectID(
-			ctx,
-			securityGroup.Spec.ProjectReference.Name,
-			securityGroup.Spec.ProjectReference.Namespace,
-		)
+		projectID, err := r.ProjectIDOf(ctx, securityGroup.Spec.ProjectReference)
 		if err != nil {
 			return "", "", err
 		}
 
-		vpcID, err := r.GetVpcID(ctx, securityGroup.Spec.VpcReference.Name, securityGroup.Spec.VpcReference.Namespace)
+		vpcID, err := r.VpcIDOf(ctx, securityGroup.Spec.VpcReference, projectID)
 		if err != nil {
 			return "", "", err
 		}
@@ -87,7 +85,7 @@ func (r *SecurityGroupReconciler) Creating(ctx context.Context, obj client.Objec
 		securityGroupReq := arubaClient.SecurityGroupRequest{
 			Metadata: arubaClient.SecurityGroupMetadata{
 				Name: securityGroup.Name,
-				Tags: securityGroup.Spec.Tags,
+				Tags: r.ResourceTags(securityGroup),
 				Location: arubaClient.SecurityGroupLocation{
 					Value: securityGroup.Spec.Location.Value,
 				},
@@ -135,7 +133,7 @@ func (r *SecurityGroupReconciler) Updating(ctx context.Context, obj client.Objec
 		securityGroupReq := arubaClient.SecurityGroupRequest{
 			Metadata: arubaClient.SecurityGroupMetadata{
 				Name: securityGroup.Name,
-				Tags: securityGroup.Spec.Tags,
+				Tags: r.ResourceTags(securityGroup),
 				Location: arubaClient.SecurityGroupLocation{
 					Value: securityGroup.Spec.Location.Value,
 				},
@@ -151,6 +149,19 @@ func (r *SecurityGroupReconciler) Updating(ctx context.Context, obj client.Objec
 }
 
 func (r *SecurityGroupReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	securityGroup := obj.(*v1alpha1.SecurityGroup)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, securityGroup.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetSecurityGroup(ctx, securityGroup.Status.ProjectID, securityGroup.Status.VpcID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
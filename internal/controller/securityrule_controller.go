@@ -21,6 +21,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
@@ -55,6 +56,7 @@ func (r *SecurityRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request
 func (r *SecurityRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.SecurityRule{}).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.SecurityRuleList{}))).
 		Named("securityrule").
 		Complete(r)
 }
@@ -70,25 +72,17 @@ func (r *SecurityRuleReconciler) Init(ctx context.Context, obj client.Object, st
 func (r *SecurityRuleReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	securityRule := obj.(*v1alpha1.SecurityRule)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
-		projectID, err := r.GetProjectID(
-			ctx,
-			securityRule.Spec.ProjectReference.Name,
-			securityRule.Spec.ProjectReference.Namespace,
-		)
+		projectID, err := r.ProjectIDOf(ctx, securityRule.Spec.ProjectReference)
 		if err != nil {
 			return "", "", err
 		}
 
-		vpcID, err := r.GetVpcID(ctx, securityRule.Spec.VpcReference.Name, securityRule.Spec.VpcReference.Namespace)
+		vpcID, err := r.VpcIDOf(ctx, securityRule.Spec.VpcReference, projectID)
 		if err != nil {
 			return "", "", err
 		}
 
-		securityGroupID, err := r.GetSecurityGroupID(
-			ctx,
-			securityRule.Spec.SecurityGroupReference.Name,
-			securityRule.Spec.SecurityGroupReference.Namespace,
-		)
+		securityGroupID, err := r.SecurityGroupIDOf(ctx, securityRule.Spec.SecurityGroupReference, projectID, vpcID)
 		if err != nil {
 			return "", "", err
 		}
@@ -96,7 +90,7 @@ func (r *SecurityRuleReconciler) Creating(ctx context.Context, obj client.Object
 		securityRuleReq := arubaClient.SecurityRuleRequest{
 			Metadata: arubaClient.SecurityRuleMetadata{
 				Name: securityRule.Name,
-				Tags: securityRule.Spec.Tags,
+				Tags: r.ResourceTags(securityRule),
 				Location: arubaClient.SecurityRuleLocation{
 					Value: securityRule.Spec.Location.Value,
 				},
@@ -151,7 +145,7 @@ func (r *SecurityRuleReconciler) Updating(ctx context.Context, obj client.Object
 		securityRuleReq := arubaClient.SecurityRuleRequest{
 			Metadata: arubaClient.SecurityRuleMetadata{
 				Name: securityRule.Name,
-				Tags: securityRule.Spec.Tags,
+				Tags: r.ResourceTags(securityRule),
 				Location: arubaClient.SecurityRuleLocation{
 					Value: securityRule.Spec.Location.Value,
 				},
@@ -173,6 +167,19 @@ func (r *SecurityRuleReconciler) Updating(ctx context.Context, obj client.Object
 }
 
 func (r *SecurityRuleReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	securityRule := obj.(*v1alpha1.SecurityRule)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, securityRule.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetSecurityRule(ctx, securityRule.Status.ProjectID, securityRule.Status.VpcID, securityRule.Status.SecurityGroupID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
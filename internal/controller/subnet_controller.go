@@ -21,6 +21,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
@@ -55,6 +56,7 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 func (r *SubnetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Subnet{}).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.SubnetList{}))).
 		Named("subnet").
 		Complete(r)
 }
@@ -70,12 +72,12 @@ func (r *SubnetReconciler) Init(ctx context.Context, obj client.Object, status *
 func (r *SubnetReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	subnet := obj.(*v1alpha1.Subnet)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
-		projectID, err := r.GetProjectID(ctx, subnet.Spec.ProjectReference.Name, subnet.Spec.ProjectReference.Namespace)
+		projectID, err := r.ProjectIDOf(ctx, subnet.Spec.ProjectReference)
 		if err != nil {
 			return "", "", err
 		}
 
-		vpcID, err := r.GetVpcID(ctx, subnet.Spec.VpcReference.Name, subnet.Spec.VpcReference.Namespace)
+		vpcID, err := r.VpcIDOf(ctx, subnet.Spec.VpcReference, projectID)
 		if err != nil {
 			return "", "", err
 		}
@@ -83,7 +85,7 @@ func (r *SubnetReconciler) Creating(ctx context.Context, obj client.Object, stat
 		subnetReq := arubaClient.SubnetRequest{
 			Metadata: arubaClient.SubnetMetadata{
 				Name: subnet.Name,
-				Tags: subnet.Spec.Tags,
+				Tags: r.ResourceTags(subnet),
 			},
 			Properties: arubaClient.SubnetProperties{
 				Type:    subnet.Spec.Type,
@@ -135,7 +137,7 @@ func (r *SubnetReconciler) Updating(ctx context.Context, obj client.Object, stat
 		subnetReq := arubaClient.SubnetRequest{
 			Metadata: arubaClient.SubnetMetadata{
 				Name: subnet.Name,
-				Tags: subnet.Spec.Tags,
+				Tags: r.ResourceTags(subnet),
 			},
 			Properties: arubaClient.SubnetProperties{
 				Type:    subnet.Spec.Type,
@@ -155,6 +157,21 @@ func (r *SubnetReconciler) Updating(ctx context.Context, obj client.Object, stat
 }
 
 func (r *SubnetReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	subnet := obj.(*v1alpha1.Subnet)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, subnet.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetSubnet(ctx, subnet.Status.ProjectID, subnet.Status.VpcID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		subnet.Status.Network = resp.Properties.Network.Address
+		subnet.Status.Gateway = resp.Properties.Network.Gateway
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
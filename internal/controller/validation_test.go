@@ -0,0 +1,334 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+)
+
+var _ = Describe("CRD Validation Rules", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	projectReference := v1alpha1.ResourceReference{Name: "test-project", Namespace: "default"}
+	vpcReference := v1alpha1.ResourceReference{Name: "test-vpc", Namespace: "default"}
+
+	newBlockStorage := func(name string) *v1alpha1.BlockStorage {
+		return &v1alpha1.BlockStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", name, GinkgoRandomSeed()),
+				Namespace: "default",
+			},
+			Spec: v1alpha1.BlockStorageSpec{
+				Tenant:           "test-tenant",
+				Location:         v1alpha1.Location{Value: "ITBG-Bergamo"},
+				SizeGb:           20,
+				BillingPeriod:    "Hour",
+				DataCenter:       "ITBG-1",
+				ProjectReference: projectReference,
+			},
+		}
+	}
+
+	newSecurityRule := func(name string) *v1alpha1.SecurityRule {
+		return &v1alpha1.SecurityRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", name, GinkgoRandomSeed()),
+				Namespace: "default",
+			},
+			Spec: v1alpha1.SecurityRuleSpec{
+				Tenant:    "test-tenant",
+				Location:  v1alpha1.Location{Value: "ITBG-Bergamo"},
+				Protocol:  "TCP",
+				Port:      "80",
+				Direction: "Ingress",
+				Target: v1alpha1.SecurityRuleTarget{
+					Kind:  "Ip",
+					Value: "0.0.0.0/0",
+				},
+				SecurityGroupReference: v1alpha1.ResourceReference{Name: "test-sg", Namespace: "default"},
+				VpcReference:           vpcReference,
+				ProjectReference:       projectReference,
+			},
+		}
+	}
+
+	newSubnet := func(name string) *v1alpha1.Subnet {
+		return &v1alpha1.Subnet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", name, GinkgoRandomSeed()),
+				Namespace: "default",
+			},
+			Spec: v1alpha1.SubnetSpec{
+				Tenant:           "test-tenant",
+				Type:             "Advanced",
+				Network:          v1alpha1.SubnetNetwork{Address: "192.168.1.0/24"},
+				DHCP:             v1alpha1.SubnetDHCP{Enabled: true},
+				VpcReference:     vpcReference,
+				ProjectReference: projectReference,
+			},
+		}
+	}
+
+	Context("BlockStorage", func() {
+		It("should require an image when bootable is true", func() {
+			blockStorage := newBlockStorage("test-cel-bootable")
+			blockStorage.Spec.Bootable = true
+			Expect(k8sClient.Create(ctx, blockStorage)).To(MatchError(ContainSubstring("image is required when bootable is true")))
+
+			blockStorage.Spec.Image = "LU22-001"
+			Expect(k8sClient.Create(ctx, blockStorage)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, blockStorage)).To(Succeed())
+		})
+
+		It("should only allow sizeGb to grow", func() {
+			blockStorage := newBlockStorage("test-cel-size")
+			Expect(k8sClient.Create(ctx, blockStorage)).To(Succeed())
+
+			blockStorage.Spec.SizeGb = 10
+			Expect(k8sClient.Update(ctx, blockStorage)).To(MatchError(ContainSubstring("sizeGb can only be increased")))
+
+			blockStorage.Spec.SizeGb = 40
+			Expect(k8sClient.Update(ctx, blockStorage)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, blockStorage)).To(Succeed())
+		})
+
+		DescribeTable("should reject changes to immutable fields",
+			func(mutate func(*v1alpha1.BlockStorageSpec), message string) {
+				blockStorage := newBlockStorage("test-cel-immutable")
+				blockStorage.Spec.Bootable = true
+				blockStorage.Spec.Image = "LU22-001"
+				Expect(k8sClient.Create(ctx, blockStorage)).To(Succeed())
+
+				mutate(&blockStorage.Spec)
+				Expect(k8sClient.Update(ctx, blockStorage)).To(MatchError(ContainSubstring(message)))
+
+				Expect(k8sClient.Delete(ctx, blockStorage)).To(Succeed())
+			},
+			Entry("tenant", func(s *v1alpha1.BlockStorageSpec) { s.Tenant = "other-tenant" }, "tenant is immutable"),
+			Entry("location", func(s *v1alpha1.BlockStorageSpec) { s.Location.Value = "ITMI-Milano" }, "location is immutable"),
+			Entry("dataCenter", func(s *v1alpha1.BlockStorageSpec) { s.DataCenter = "ITBG-2" }, "dataCenter is immutable"),
+			Entry("bootable", func(s *v1alpha1.BlockStorageSpec) { s.Bootable = false; s.Image = "" }, "bootable is immutable"),
+			Entry("image", func(s *v1alpha1.BlockStorageSpec) { s.Image = "LU24-001" }, "image is immutable"),
+			Entry("projectReference", func(s *v1alpha1.BlockStorageSpec) { s.ProjectReference.Name = "other-project" }, "projectReference is immutable"),
+		)
+	})
+
+	Context("SecurityRule", func() {
+		It("should require a CIDR or an IP address for Ip targets", func() {
+			securityRule := newSecurityRule("test-cel-target")
+			securityRule.Spec.Target.Value = "not-an-ip"
+			Expect(k8sClient.Create(ctx, securityRule)).To(MatchError(ContainSubstring("value must be an IP address or a CIDR when kind is Ip")))
+
+			securityRule.Spec.Target.Value = "10.0.0.1"
+			Expect(k8sClient.Create(ctx, securityRule)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, securityRule)).To(Succeed())
+		})
+
+		It("should accept any value for SecurityGroup targets", func() {
+			securityRule := newSecurityRule("test-cel-target-sg")
+			securityRule.Spec.Target = v1alpha1.SecurityRuleTarget{Kind: "SecurityGroup", Value: "test-sg"}
+			Expect(k8sClient.Create(ctx, securityRule)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, securityRule)).To(Succeed())
+		})
+
+		It("should require port ALL for ICMP", func() {
+			securityRule := newSecurityRule("test-cel-icmp")
+			securityRule.Spec.Protocol = "ICMP"
+			Expect(k8sClient.Create(ctx, securityRule)).To(MatchError(ContainSubstring("port must be ALL when protocol is ICMP or ALL")))
+
+			securityRule.Spec.Port = "ALL"
+			Expect(k8sClient.Create(ctx, securityRule)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, securityRule)).To(Succeed())
+		})
+
+		It("should reject moving the rule to another security group", func() {
+			securityRule := newSecurityRule("test-cel-sg-ref")
+			Expect(k8sClient.Create(ctx, securityRule)).To(Succeed())
+
+			securityRule.Spec.SecurityGroupReference.Name = "other-sg"
+			Expect(k8sClient.Update(ctx, securityRule)).To(MatchError(ContainSubstring("securityGroupReference is immutable")))
+			Expect(k8sClient.Delete(ctx, securityRule)).To(Succeed())
+		})
+	})
+
+	Context("Subnet", func() {
+		It("should reject a CIDR with host bits set", func() {
+			subnet := newSubnet("test-cel-cidr")
+			subnet.Spec.Network.Address = "192.168.1.10/24"
+			Expect(k8sClient.Create(ctx, subnet)).To(MatchError(ContainSubstring("address must be a CIDR without host bits set")))
+		})
+
+		It("should reject moving the subnet to another VPC", func() {
+			subnet := newSubnet("test-cel-vpc-ref")
+			Expect(k8sClient.Create(ctx, subnet)).To(Succeed())
+
+			subnet.Spec.VpcReference.Name = "other-vpc"
+			Expect(k8sClient.Update(ctx, subnet)).To(MatchError(ContainSubstring("vpcReference is immutable")))
+			Expect(k8sClient.Delete(ctx, subnet)).To(Succeed())
+		})
+	})
+
+	Context("ResourceReference", func() {
+		newVpc := func(suffix string, ref v1alpha1.ResourceReference) *v1alpha1.Vpc {
+			return &v1alpha1.Vpc{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-cel-ref-%s-%d", suffix, GinkgoRandomSeed()),
+					Namespace: "default",
+				},
+				Spec: v1alpha1.VpcSpec{
+					Tenant:           "test-tenant",
+					Location:         v1alpha1.Location{Value: "ITBG-Bergamo"},
+					ProjectReference: ref,
+				},
+			}
+		}
+
+		It("should default a missing namespace to the namespace of the referrer", func() {
+			vpc := newVpc("namespace", v1alpha1.ResourceReference{Name: "test-project"})
+			Expect(k8sClient.Create(ctx, vpc)).To(Succeed())
+			Expect(reconciler.ReferencesOf(vpc)).To(ConsistOf(reconciler.KindReference{
+				Kind:              "Project",
+				ResourceReference: v1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+			}))
+			Expect(k8sClient.Delete(ctx, vpc)).To(Succeed())
+		})
+
+		It("should require a valid namespace", func() {
+			vpc := newVpc("bad-namespace", v1alpha1.ResourceReference{Name: "test-project", Namespace: "Not_A_Namespace"})
+			Expect(k8sClient.Create(ctx, vpc)).To(MatchError(ContainSubstring("spec.projectReference.namespace")))
+		})
+
+		DescribeTable("should require exactly one of name or id",
+			func(ref v1alpha1.ResourceReference, message string) {
+				vpc := newVpc("name-or-id", ref)
+				if message == "" {
+					Expect(k8sClient.Create(ctx, vpc)).To(Succeed())
+					Expect(k8sClient.Delete(ctx, vpc)).To(Succeed())
+					return
+				}
+				Expect(k8sClient.Create(ctx, vpc)).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("name", v1alpha1.ResourceReference{Name: "test-project"}, ""),
+			Entry("id", v1alpha1.ResourceReference{ID: "66a10244f62b99c686572a9f"}, ""),
+			Entry("neither", v1alpha1.ResourceReference{}, "exactly one of name or id must be set"),
+			Entry("both", v1alpha1.ResourceReference{Name: "test-project", ID: "66a10244f62b99c686572a9f"}, "exactly one of name or id must be set"),
+			Entry("id with a namespace", v1alpha1.ResourceReference{ID: "66a10244f62b99c686572a9f", Namespace: "default"}, "namespace can't be set with id"),
+		)
+	})
+
+	DescribeTable("should reject location changes",
+		func(obj func(name string) (create, mutate func() error)) {
+			create, mutate := obj(fmt.Sprintf("test-cel-location-%d", GinkgoRandomSeed()))
+			Expect(create()).To(Succeed())
+			Expect(mutate()).To(MatchError(ContainSubstring("location is immutable")))
+		},
+		Entry("ElasticIp", func(name string) (func() error, func() error) {
+			elasticIp := &v1alpha1.ElasticIp{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: v1alpha1.ElasticIpSpec{
+					Location:         v1alpha1.Location{Value: "ITBG-Bergamo"},
+					BillingPlan:      v1alpha1.BillingPlan{BillingPeriod: "Hour"},
+					ProjectReference: projectReference,
+				},
+			}
+			return func() error { return k8sClient.Create(ctx, elasticIp) }, func() error {
+				elasticIp.Spec.Location.Value = "ITMI-Milano"
+				return k8sClient.Update(ctx, elasticIp)
+			}
+		}),
+		Entry("KeyPair", func(name string) (func() error, func() error) {
+			keyPair := &v1alpha1.KeyPair{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: v1alpha1.KeyPairSpec{
+					Location:         v1alpha1.Location{Value: "ITBG-Bergamo"},
+					Value:            "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDtest test@example.com",
+					ProjectReference: projectReference,
+				},
+			}
+			return func() error { return k8sClient.Create(ctx, keyPair) }, func() error {
+				keyPair.Spec.Location.Value = "ITMI-Milano"
+				return k8sClient.Update(ctx, keyPair)
+			}
+		}),
+		Entry("SecurityGroup", func(name string) (func() error, func() error) {
+			securityGroup := &v1alpha1.SecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: v1alpha1.SecurityGroupSpec{
+					Location:         v1alpha1.Location{Value: "ITBG-Bergamo"},
+					VpcReference:     vpcReference,
+					ProjectReference: projectReference,
+				},
+			}
+			return func() error { return k8sClient.Create(ctx, securityGroup) }, func() error {
+				securityGroup.Spec.Location.Value = "ITMI-Milano"
+				return k8sClient.Update(ctx, securityGroup)
+			}
+		}),
+	)
+
+	It("should reject a CloudServer data center change", func() {
+		cloudServer := &v1alpha1.CloudServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-cel-datacenter-%d", GinkgoRandomSeed()),
+				Namespace: "default",
+			},
+			Spec: v1alpha1.CloudServerSpec{
+				Location:                v1alpha1.Location{Value: "ITBG-Bergamo"},
+				DataCenter:              "ITBG-1",
+				FlavorName:              "CSO4A8",
+				VpcReference:            vpcReference,
+				KeyPairReference:        v1alpha1.ResourceReference{Name: "test-keypair", Namespace: "default"},
+				SubnetReferences:        []v1alpha1.ResourceReference{{Name: "test-subnet", Namespace: "default"}},
+				SecurityGroupReferences: []v1alpha1.ResourceReference{{Name: "test-sg", Namespace: "default"}},
+				BootVolumeReference:     v1alpha1.ResourceReference{Name: "test-boot", Namespace: "default"},
+				ProjectReference:        projectReference,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cloudServer)).To(Succeed())
+
+		cloudServer.Spec.DataCenter = "ITBG-2"
+		Expect(k8sClient.Update(ctx, cloudServer)).To(MatchError(ContainSubstring("dataCenter is immutable")))
+		Expect(k8sClient.Delete(ctx, cloudServer)).To(Succeed())
+	})
+
+	It("should reject a Project tenant change", func() {
+		project := &v1alpha1.Project{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-cel-tenant-%d", GinkgoRandomSeed()),
+				Namespace: "default",
+			},
+			Spec: v1alpha1.ProjectSpec{Tenant: "test-tenant"},
+		}
+		Expect(k8sClient.Create(ctx, project)).To(Succeed())
+
+		project.Spec.Tenant = "other-tenant"
+		Expect(k8sClient.Update(ctx, project)).To(MatchError(ContainSubstring("tenant is immutable")))
+		Expect(k8sClient.Delete(ctx, project)).To(Succeed())
+	})
+})
@@ -21,6 +21,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
@@ -55,6 +56,7 @@ func (r *VpcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 func (r *VpcReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Vpc{}).
+		Watches(&v1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.ReferrersGrantedBy(&v1alpha1.VpcList{}))).
 		Named("vpc").
 		Complete(r)
 }
@@ -70,11 +72,7 @@ func (r *VpcReconciler) Init(ctx context.Context, obj client.Object, status *v1a
 func (r *VpcReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	vpc := obj.(*v1alpha1.Vpc)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
-		projectID, err := r.GetProjectID(
-			ctx,
-			vpc.Spec.ProjectReference.Name,
-			vpc.Spec.ProjectReference.Namespace,
-		)
+		projectID, err := r.ProjectIDOf(ctx, vpc.Spec.ProjectReference)
 		if err != nil {
 			return "", "", err
 		}
@@ -82,7 +80,7 @@ func (r *VpcReconciler) Creating(ctx context.Context, obj client.Object, status
 		vpcReq := arubaClient.VpcRequest{
 			Metadata: arubaClient.VpcMetadata{
 				Name: vpc.Name,
-				Tags: vpc.Spec.Tags,
+				Tags: r.ResourceTags(vpc),
 				Location: arubaClient.VpcLocation{
 					Value: vpc.Spec.Location.Value,
 				},
@@ -130,7 +128,7 @@ func (r *VpcReconciler) Updating(ctx context.Context, obj client.Object, status
 		vpcReq := arubaClient.VpcRequest{
 			Metadata: arubaClient.VpcMetadata{
 				Name: vpc.Name,
-				Tags: vpc.Spec.Tags,
+				Tags: r.ResourceTags(vpc),
 				Location: arubaClient.VpcLocation{
 					Value: vpc.Spec.Location.Value,
 				},
@@ -143,6 +141,19 @@ func (r *VpcReconciler) Updating(ctx context.Context, obj client.Object, status
 }
 
 func (r *VpcReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	vpc := obj.(*v1alpha1.Vpc)
+	isMissing, remoteResult, remoteErr := r.HandleRemoteMissing(ctx, obj, status, vpc.Spec.RemoteDeletionPolicy, func(ctx context.Context) error {
+		resp, err := r.GetVpc(ctx, vpc.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return err
+		}
+		status.Remote = reconciler.RemoteOutputsOf(resp.Metadata.Info(resp.Status))
+		return nil
+	})
+	if isMissing {
+		return remoteResult, remoteErr
+	}
+
 	return r.CheckForUpdates(ctx, obj, status)
 }
 
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -120,3 +121,93 @@ var _ = Describe("Vpc Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Vpc Controller Tag Propagation", func() {
+	Context("When the labels of a created VPC change", func() {
+		var (
+			ctx                context.Context
+			resourceReconciler *VpcReconciler
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			auth := new(mocks.MockITokenManager)
+			auth.On("GetActiveToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token 123", nil)
+
+			// Create mock HTTP client that returns 200 for all requests
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.AnythingOfType("*http.Request")).Return(
+				func(*http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: 200,
+						Body:       io.NopCloser(strings.NewReader(`{"metadata": {"id": "vpc-12345"}}`)),
+						Header:     make(http.Header),
+					}
+				}, nil)
+
+			helperClient := arubaClient.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com")
+
+			resourceReconciler = &VpcReconciler{
+				Reconciler: &reconciler.Reconciler{
+					Client:           k8sClient,
+					Scheme:           k8sClient.Scheme(),
+					HelperClient:     helperClient,
+					TokenManager:     auth,
+					PropagatedLabels: []string{"team", "cost-center"},
+					ClusterID:        "test-cluster",
+				},
+			}
+		})
+
+		It("should update the remote tags without a spec change", func() {
+			By("Creating a created VPC whose tags were applied before the label change")
+			testName := fmt.Sprintf("test-tags-vpc-%d", GinkgoRandomSeed())
+			vpc := &v1alpha1.Vpc{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: "default",
+					Labels:    map[string]string{"team": "core", "app": "web"},
+				},
+				Spec: v1alpha1.VpcSpec{
+					Tenant: "test-tenant",
+					Tags:   []string{"env=prod"},
+					Location: v1alpha1.Location{
+						Value: "ITBG-Bergamo",
+					},
+					ProjectReference: v1alpha1.ResourceReference{
+						Name:      "test-project",
+						Namespace: "default",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, vpc)).To(Succeed())
+			vpc.Status.Phase = v1alpha1.ResourcePhaseCreated
+			vpc.Status.ResourceID = "vpc-12345"
+			vpc.Status.ProjectID = "project-12345"
+			vpc.Status.ObservedGeneration = vpc.Generation
+			vpc.Status.AppliedTags = resourceReconciler.ResourceTags(vpc)
+			Expect(k8sClient.Status().Update(ctx, vpc)).To(Succeed())
+			Expect(vpc.Status.AppliedTags).To(Equal([]string{
+				"env=prod", "team=core", "k8s-namespace=default", "k8s-name=" + testName, "k8s-cluster=test-cluster",
+			}))
+
+			By("Changing a propagated label")
+			vpc.Labels["team"] = "platform"
+			Expect(k8sClient.Update(ctx, vpc)).To(Succeed())
+
+			By("Reconciling the created resource")
+			_, err := resourceReconciler.Created(ctx, vpc, &vpc.Status.ResourceStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vpc.Status.Phase).To(Equal(v1alpha1.ResourcePhaseUpdating))
+
+			By("Reconciling the update")
+			_, err = resourceReconciler.Updating(ctx, vpc, &vpc.Status.ResourceStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vpc.Status.Phase).To(Equal(v1alpha1.ResourcePhaseCreated))
+			Expect(vpc.Status.AppliedTags).To(ContainElement("team=platform"))
+			Expect(vpc.Status.AppliedTags).NotTo(ContainElement("app=web"))
+
+			Expect(k8sClient.Delete(ctx, vpc)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,86 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// ConnectionSecretOwnerLabel is set on the connection Secrets to the UID of the resource writing them
+const ConnectionSecretOwnerLabel = "arubacloud.com/connection-secret-owner"
+
+// Connection detail keys shared by the kinds writing a connection Secret
+const (
+	ConnectionKeyPublicIP  = "publicIP"
+	ConnectionKeyPrivateIP = "privateIP"
+	ConnectionKeyUsername  = "username"
+	ConnectionKeyKeyPair   = "keyPair"
+)
+
+// PublishConnectionSecret writes data into the Secret named by ref, owned by obj, and deletes the
+// connection Secrets obj wrote before under another name. With a nil ref, every connection
+// Secret of obj is deleted. Empty values are left out of the Secret.
+func (r *Reconciler) PublishConnectionSecret(ctx context.Context, obj client.Object, ref *v1alpha1.ConnectionSecretReference, data map[string]string) error {
+	if err := r.deleteStaleConnectionSecrets(ctx, obj, ref); err != nil {
+		return err
+	}
+	if ref == nil {
+		return nil
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: obj.GetNamespace()}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		// Never take over a Secret created by someone else
+		if secret.ResourceVersion != "" && secret.Labels[ConnectionSecretOwnerLabel] != string(obj.GetUID()) {
+			return fmt.Errorf("secret already exists and is not a connection secret of %s", obj.GetName())
+		}
+		if err := controllerutil.SetControllerReference(obj, secret, r.Scheme); err != nil {
+			return err
+		}
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[ConnectionSecretOwnerLabel] = string(obj.GetUID())
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{}
+		for key, value := range data {
+			if value != "" {
+				secret.Data[key] = []byte(value)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write connection secret %s/%s: %w", obj.GetNamespace(), ref.Name, err)
+	}
+	return nil
+}
+
+// deleteStaleConnectionSecrets deletes the connection Secrets of obj not named by ref
+func (r *Reconciler) deleteStaleConnectionSecrets(ctx context.Context, obj client.Object, ref *v1alpha1.ConnectionSecretReference) error {
+	// Only the names are needed, the data of the Secrets is never read
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := r.List(ctx, secrets, client.InNamespace(obj.GetNamespace()), client.MatchingLabels{ConnectionSecretOwnerLabel: string(obj.GetUID())}); err != nil {
+		return fmt.Errorf("failed to list connection secrets: %w", err)
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if ref != nil && secret.Name == ref.Name {
+			continue
+		}
+		secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		if err := client.IgnoreNotFound(r.Delete(ctx, secret)); err != nil {
+			return fmt.Errorf("failed to delete connection secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+func TestPublishConnectionSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	elasticIp := &v1alpha1.ElasticIp{ObjectMeta: metav1.ObjectMeta{Name: "eip", Namespace: "app", UID: "uid-1"}}
+	foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "app"}}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(elasticIp, foreign).Build(),
+		Scheme: scheme,
+	}
+	ctx := context.Background()
+	data := map[string]string{ConnectionKeyPublicIP: "203.0.113.10", ConnectionKeyUsername: ""}
+
+	require.NoError(t, r.PublishConnectionSecret(ctx, elasticIp, &v1alpha1.ConnectionSecretReference{Name: "eip-conn"}, data))
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "eip-conn", Namespace: "app"}, secret))
+	assert.Equal(t, map[string][]byte{ConnectionKeyPublicIP: []byte("203.0.113.10")}, secret.Data)
+	require.Len(t, secret.OwnerReferences, 1)
+	assert.Equal(t, "eip", secret.OwnerReferences[0].Name)
+
+	t.Run("renamed", func(t *testing.T) {
+		require.NoError(t, r.PublishConnectionSecret(ctx, elasticIp, &v1alpha1.ConnectionSecretReference{Name: "eip-conn-2"}, data))
+		err := r.Get(ctx, types.NamespacedName{Name: "eip-conn", Namespace: "app"}, &corev1.Secret{})
+		assert.True(t, apierrors.IsNotFound(err), "the previous secret is deleted, got %v", err)
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "eip-conn-2", Namespace: "app"}, &corev1.Secret{}))
+	})
+
+	t.Run("existing secret", func(t *testing.T) {
+		err := r.PublishConnectionSecret(ctx, elasticIp, &v1alpha1.ConnectionSecretReference{Name: "foreign"}, data)
+		assert.ErrorContains(t, err, "not a connection secret")
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		require.NoError(t, r.PublishConnectionSecret(ctx, elasticIp, nil, data))
+		err := r.Get(ctx, types.NamespacedName{Name: "eip-conn-2", Namespace: "app"}, &corev1.Secret{})
+		assert.True(t, apierrors.IsNotFound(err), "the secret is deleted, got %v", err)
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "foreign", Namespace: "app"}, &corev1.Secret{}))
+	})
+}
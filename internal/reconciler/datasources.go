@@ -0,0 +1,58 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// ApplyBlockStorageDataSource resolves the snapshot or block storage referenced by ds in the project
+// with projectID and sets its URI reference in properties. It returns an *InvalidSpecError when
+// properties.SizeGb is smaller than the size of the source.
+func (r *Reconciler) ApplyBlockStorageDataSource(ctx context.Context, ds *v1alpha1.BlockStorageDataSource, projectID string, properties *arubaClient.BlockStorageProperties) error {
+	if ds == nil {
+		return nil
+	}
+
+	var sizeGb int32
+	switch ds.Kind {
+	case v1alpha1.BlockStorageDataSourceSnapshot:
+		id, err := r.SnapshotIDOf(ctx, ds.ResourceReference, projectID)
+		if err != nil {
+			return err
+		}
+		snapshot, err := r.GetSnapshot(ctx, projectID, id)
+		if err != nil {
+			return err
+		}
+		properties.Snapshot = &arubaClient.BlockStorageSource{
+			URI: fmt.Sprintf("/projects/%s/providers/Aruba.Storage/snapshots/%s", projectID, id),
+		}
+		sizeGb = snapshot.Properties.SizeGb
+	case v1alpha1.BlockStorageDataSourceBlockStorage:
+		id, err := r.BlockStorageIDOf(ctx, ds.ResourceReference, projectID)
+		if err != nil {
+			return err
+		}
+		source, err := r.GetBlockStorage(ctx, projectID, id)
+		if err != nil {
+			return err
+		}
+		properties.SourceVolume = &arubaClient.BlockStorageSource{
+			URI: fmt.Sprintf("/projects/%s/providers/Aruba.Storage/blockStorages/%s", projectID, id),
+		}
+		sizeGb = source.Properties.SizeGb
+	default:
+		return &InvalidSpecError{Field: "spec.dataSource.kind", Message: fmt.Sprintf("unsupported kind %q", ds.Kind)}
+	}
+
+	if properties.SizeGb < sizeGb {
+		return &InvalidSpecError{
+			Field:   "spec.sizeGb",
+			Message: fmt.Sprintf("%dGB is smaller than the %dGB of the %s %s", properties.SizeGb, sizeGb, ds.Kind, ds.ResourceReference),
+		}
+	}
+	return nil
+}
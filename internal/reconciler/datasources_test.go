@@ -0,0 +1,136 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// sizedHTTPClient answers the paths ending with one of the IDs with a resource of the matching size
+type sizedHTTPClient map[string]string
+
+func (c sizedHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	for id, sizeGb := range c {
+		if strings.HasSuffix(req.URL.Path, "/"+id) {
+			body := `{"metadata":{"id":"` + id + `"},"properties":{"sizeGb":` + sizeGb + `}}`
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+	return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(`{"title":"Not Found","status":404}`))}, nil
+}
+
+func TestApplyBlockStorageDataSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	snapshot := &v1alpha1.BlockStorageSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "app"},
+		Status:     v1alpha1.BlockStorageSnapshotStatus{ResourceStatus: v1alpha1.ResourceStatus{ResourceID: "snap-1"}},
+	}
+	pending := &v1alpha1.BlockStorageSnapshot{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "app"}}
+	source := &v1alpha1.BlockStorage{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "app"},
+		Status:     v1alpha1.BlockStorageStatus{ResourceStatus: v1alpha1.ResourceStatus{ResourceID: "bs-1"}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(snapshot, pending, source).Build()
+	r := &Reconciler{
+		Client:       k8sClient,
+		Scheme:       scheme,
+		HelperClient: arubaClient.NewHelperClient(k8sClient, sizedHTTPClient{"snap-1": "50", "bs-1": "100", "bs-2": "20"}, "https://api.example.com"),
+	}
+	blockStorage := &v1alpha1.BlockStorage{ObjectMeta: metav1.ObjectMeta{Name: "copy", Namespace: "app"}}
+	ctx := withValidatedIDs(withReferrer(context.Background(), blockStorage), &blockStorage.Status.ResourceStatus)
+
+	tests := []struct {
+		name         string
+		ds           *v1alpha1.BlockStorageDataSource
+		sizeGb       int32
+		wantSnapshot string
+		wantVolume   string
+		wantInvalid  bool
+		wantErr      bool
+	}{
+		{
+			name:   "no data source",
+			sizeGb: 10,
+		},
+		{
+			name:         "snapshot by name",
+			ds:           &v1alpha1.BlockStorageDataSource{Kind: v1alpha1.BlockStorageDataSourceSnapshot, ResourceReference: v1alpha1.ResourceReference{Name: "nightly"}},
+			sizeGb:       50,
+			wantSnapshot: "/projects/project-1/providers/Aruba.Storage/snapshots/snap-1",
+		},
+		{
+			name:        "snapshot larger than the size",
+			ds:          &v1alpha1.BlockStorageDataSource{Kind: v1alpha1.BlockStorageDataSourceSnapshot, ResourceReference: v1alpha1.ResourceReference{Name: "nightly"}},
+			sizeGb:      40,
+			wantInvalid: true,
+		},
+		{
+			name:    "snapshot not created yet",
+			ds:      &v1alpha1.BlockStorageDataSource{Kind: v1alpha1.BlockStorageDataSourceSnapshot, ResourceReference: v1alpha1.ResourceReference{Name: "pending"}},
+			sizeGb:  50,
+			wantErr: true,
+		},
+		{
+			name:       "block storage by name",
+			ds:         &v1alpha1.BlockStorageDataSource{Kind: v1alpha1.BlockStorageDataSourceBlockStorage, ResourceReference: v1alpha1.ResourceReference{Name: "data"}},
+			sizeGb:     200,
+			wantVolume: "/projects/project-1/providers/Aruba.Storage/blockStorages/bs-1",
+		},
+		{
+			name:       "block storage by ID",
+			ds:         &v1alpha1.BlockStorageDataSource{Kind: v1alpha1.BlockStorageDataSourceBlockStorage, ResourceReference: v1alpha1.ResourceReference{ID: "bs-2"}},
+			sizeGb:     20,
+			wantVolume: "/projects/project-1/providers/Aruba.Storage/blockStorages/bs-2",
+		},
+		{
+			name:        "block storage larger than the size",
+			ds:          &v1alpha1.BlockStorageDataSource{Kind: v1alpha1.BlockStorageDataSourceBlockStorage, ResourceReference: v1alpha1.ResourceReference{ID: "bs-2"}},
+			sizeGb:      10,
+			wantInvalid: true,
+		},
+		{
+			name:    "missing snapshot ID",
+			ds:      &v1alpha1.BlockStorageDataSource{Kind: v1alpha1.BlockStorageDataSourceSnapshot, ResourceReference: v1alpha1.ResourceReference{ID: "snap-missing"}},
+			sizeGb:  50,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			properties := arubaClient.BlockStorageProperties{SizeGb: tt.sizeGb}
+			err := r.ApplyBlockStorageDataSource(ctx, tt.ds, "project-1", &properties)
+
+			var invalidSpec *InvalidSpecError
+			assert.Equal(t, tt.wantInvalid, errors.As(err, &invalidSpec), "InvalidSpecError, got %v", err)
+			if tt.wantInvalid || tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSnapshot, sourceURI(properties.Snapshot))
+			assert.Equal(t, tt.wantVolume, sourceURI(properties.SourceVolume))
+		})
+	}
+}
+
+func sourceURI(source *arubaClient.BlockStorageSource) string {
+	if source == nil {
+		return ""
+	}
+	return source.URI
+}
@@ -0,0 +1,157 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// KindReference is a ResourceReference qualified with the kind of the referenced resource
+type KindReference struct {
+	Kind string
+	v1alpha1.ResourceReference
+}
+
+// ReferencesOf returns every resource referenced by obj. References without a
+// namespace are resolved in the namespace of obj.
+func ReferencesOf(obj client.Object) []KindReference {
+	var refs []KindReference
+	add := func(kind string, ref v1alpha1.ResourceReference) {
+		if ref.Name == "" {
+			return
+		}
+		if ref.Namespace == "" {
+			ref.Namespace = obj.GetNamespace()
+		}
+		refs = append(refs, KindReference{Kind: kind, ResourceReference: ref})
+	}
+
+	switch o := obj.(type) {
+	case *v1alpha1.ElasticIp:
+		add("Project", o.Spec.ProjectReference)
+	case *v1alpha1.BlockStorage:
+		add("Project", o.Spec.ProjectReference)
+		if o.Spec.DataSource != nil {
+			add(string(o.Spec.DataSource.Kind), o.Spec.DataSource.ResourceReference)
+		}
+	case *v1alpha1.BlockStorageSnapshot:
+		add("Project", o.Spec.ProjectReference)
+		add("BlockStorage", o.Spec.BlockStorageReference)
+	case *v1alpha1.KeyPair:
+		add("Project", o.Spec.ProjectReference)
+	case *v1alpha1.Vpc:
+		add("Project", o.Spec.ProjectReference)
+	case *v1alpha1.Subnet:
+		add("Project", o.Spec.ProjectReference)
+		add("Vpc", o.Spec.VpcReference)
+	case *v1alpha1.SecurityGroup:
+		add("Project", o.Spec.ProjectReference)
+		add("Vpc", o.Spec.VpcReference)
+	case *v1alpha1.SecurityRule:
+		add("Project", o.Spec.ProjectReference)
+		add("Vpc", o.Spec.VpcReference)
+		add("SecurityGroup", o.Spec.SecurityGroupReference)
+	case *v1alpha1.CloudServer:
+		add("Project", o.Spec.ProjectReference)
+		add("Vpc", o.Spec.VpcReference)
+		add("KeyPair", o.Spec.KeyPairReference)
+		add("BlockStorage", o.Spec.BootVolumeReference)
+		if o.Spec.ElasticIpReference != nil {
+			add("ElasticIp", *o.Spec.ElasticIpReference)
+		}
+		for _, ref := range o.Spec.SubnetReferences {
+			add("Subnet", ref)
+		}
+		for _, ref := range o.Spec.SecurityGroupReferences {
+			add("SecurityGroup", ref)
+		}
+		for _, ref := range o.Spec.DataVolumeReferences {
+			add("BlockStorage", ref)
+		}
+		// Selected resources are referenced once attached
+		for _, ref := range o.Status.ResolvedSecurityGroups {
+			add("SecurityGroup", ref)
+		}
+		for _, ref := range o.Status.ResolvedDataVolumes {
+			add("BlockStorage", ref)
+		}
+	}
+
+	return refs
+}
+
+// dependentLists returns empty lists of every kind that can reference other resources
+func dependentLists() []client.ObjectList {
+	return []client.ObjectList{
+		&v1alpha1.ElasticIpList{},
+		&v1alpha1.BlockStorageList{},
+		&v1alpha1.BlockStorageSnapshotList{},
+		&v1alpha1.KeyPairList{},
+		&v1alpha1.VpcList{},
+		&v1alpha1.SubnetList{},
+		&v1alpha1.SecurityGroupList{},
+		&v1alpha1.SecurityRuleList{},
+		&v1alpha1.CloudServerList{},
+	}
+}
+
+// FindDependents returns every resource that references obj
+func (r *Reconciler) FindDependents(ctx context.Context, obj client.Object) ([]client.Object, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []client.Object
+	for _, list := range dependentLists() {
+		if err := r.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("failed to list %T: %w", list, err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			dependent, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			for _, ref := range ReferencesOf(dependent) {
+				if ref.Kind == gvk.Kind && ref.Name == obj.GetName() && ref.Namespace == obj.GetNamespace() {
+					dependents = append(dependents, dependent)
+					break
+				}
+			}
+		}
+	}
+
+	return dependents, nil
+}
+
+// NotifyDependents records a warning event on every resource that references obj
+func (r *Reconciler) NotifyDependents(ctx context.Context, obj client.Object, message string) error {
+	dependents, err := r.FindDependents(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return err
+	}
+
+	for _, dependent := range dependents {
+		r.RecordEvent(dependent, corev1.EventTypeWarning, "DependencyRemoteMissing",
+			fmt.Sprintf("Referenced %s %s/%s: %s", gvk.Kind, obj.GetNamespace(), obj.GetName(), message))
+	}
+
+	return nil
+}
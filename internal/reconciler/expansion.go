@@ -0,0 +1,83 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// ExpansionPlan is the next step bringing a block storage to its desired size
+type ExpansionPlan struct {
+	// Expand requests the desired size now
+	Expand bool
+	// Phase is the phase of the expansion after this step, empty when no expansion was ever run
+	Phase v1alpha1.BlockStorageExpansionPhase
+}
+
+// PlanExpansion returns the next step expanding blockStorage from the capacity reported by
+// Aruba Cloud to spec.sizeGb. Nothing is planned while the capacity is unknown, and an
+// expansion in progress completes once the capacity reaches the requested size.
+func PlanExpansion(blockStorage *v1alpha1.BlockStorage) ExpansionPlan {
+	capacity := blockStorage.Status.CapacityGb
+	expansion := blockStorage.Status.Expansion
+
+	plan := ExpansionPlan{}
+	if expansion != nil {
+		plan.Phase = expansion.Phase
+		if expansion.Phase == v1alpha1.BlockStorageExpansionExpanding {
+			if capacity >= expansion.ToGb {
+				plan.Phase = v1alpha1.BlockStorageExpansionCompleted
+			}
+			return plan
+		}
+	}
+
+	if capacity != 0 && blockStorage.Spec.SizeGb > capacity {
+		plan.Expand = true
+		plan.Phase = v1alpha1.BlockStorageExpansionExpanding
+	}
+	return plan
+}
+
+// CloudServersAttaching returns the namespace/name of the cloud servers booting from the remote
+// block storage with blockStorageID, and of the ones attaching it as a data volume
+func CloudServersAttaching(ctx context.Context, c client.Reader, blockStorageID string) ([]string, []string, error) {
+	if blockStorageID == "" {
+		return nil, nil, nil
+	}
+	cloudServers := &v1alpha1.CloudServerList{}
+	if err := c.List(ctx, cloudServers); err != nil {
+		return nil, nil, fmt.Errorf("failed to list cloud servers: %w", err)
+	}
+
+	var booting, attaching []string
+	for _, cloudServer := range cloudServers.Items {
+		name := cloudServer.Namespace + "/" + cloudServer.Name
+		if cloudServer.Status.BootVolumeID == blockStorageID {
+			booting = append(booting, name)
+		} else if slices.Contains(cloudServer.Status.DataVolumeIDs, blockStorageID) {
+			attaching = append(attaching, name)
+		}
+	}
+	slices.Sort(booting)
+	slices.Sort(attaching)
+	return booting, attaching, nil
+}
+
+// ExpansionActionFor returns the action needed to use the new size of a block storage the cloud
+// servers boot from or attach as a data volume: the root filesystem is only grown on boot, while
+// the partition and filesystem of a data volume can be grown online.
+func ExpansionActionFor(booting, attaching []string) v1alpha1.BlockStorageExpansionAction {
+	switch {
+	case len(booting) > 0:
+		return v1alpha1.BlockStorageExpansionActionReboot
+	case len(attaching) > 0:
+		return v1alpha1.BlockStorageExpansionActionGrowFilesystem
+	default:
+		return v1alpha1.BlockStorageExpansionActionNone
+	}
+}
@@ -0,0 +1,88 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+func TestPlanExpansion(t *testing.T) {
+	const (
+		expanding = v1alpha1.BlockStorageExpansionExpanding
+		completed = v1alpha1.BlockStorageExpansionCompleted
+	)
+	tests := []struct {
+		name      string
+		sizeGb    int32
+		capacity  int32
+		expansion *v1alpha1.BlockStorageExpansion
+		want      ExpansionPlan
+	}{
+		{name: "size applied", sizeGb: 20, capacity: 20, want: ExpansionPlan{}},
+		{name: "capacity unknown", sizeGb: 40, want: ExpansionPlan{}},
+		{name: "size increased", sizeGb: 40, capacity: 20, want: ExpansionPlan{Expand: true, Phase: expanding}},
+		{name: "expanding", sizeGb: 40, capacity: 20,
+			expansion: &v1alpha1.BlockStorageExpansion{Phase: expanding, FromGb: 20, ToGb: 40},
+			want:      ExpansionPlan{Phase: expanding}},
+		{name: "expanded", sizeGb: 40, capacity: 40,
+			expansion: &v1alpha1.BlockStorageExpansion{Phase: expanding, FromGb: 20, ToGb: 40},
+			want:      ExpansionPlan{Phase: completed}},
+		{name: "size increased while expanding", sizeGb: 80, capacity: 20,
+			expansion: &v1alpha1.BlockStorageExpansion{Phase: expanding, FromGb: 20, ToGb: 40},
+			want:      ExpansionPlan{Phase: expanding}},
+		{name: "another expansion", sizeGb: 80, capacity: 40,
+			expansion: &v1alpha1.BlockStorageExpansion{Phase: completed, FromGb: 20, ToGb: 40},
+			want:      ExpansionPlan{Expand: true, Phase: expanding}},
+		{name: "completed", sizeGb: 40, capacity: 40,
+			expansion: &v1alpha1.BlockStorageExpansion{Phase: completed, FromGb: 20, ToGb: 40},
+			want:      ExpansionPlan{Phase: completed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockStorage := &v1alpha1.BlockStorage{
+				Spec:   v1alpha1.BlockStorageSpec{SizeGb: tt.sizeGb},
+				Status: v1alpha1.BlockStorageStatus{CapacityGb: tt.capacity, Expansion: tt.expansion},
+			}
+			assert.Equal(t, tt.want, PlanExpansion(blockStorage))
+		})
+	}
+}
+
+func TestCloudServersAttaching(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1alpha1.CloudServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "app"},
+			Status:     v1alpha1.CloudServerStatus{BootVolumeID: "bs-boot", DataVolumeIDs: []string{"bs-data"}},
+		},
+		&v1alpha1.CloudServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "data"},
+			Status:     v1alpha1.CloudServerStatus{BootVolumeID: "bs-other", DataVolumeIDs: []string{"bs-data"}},
+		},
+	).Build()
+	ctx := context.Background()
+
+	booting, attaching, err := CloudServersAttaching(ctx, k8sClient, "bs-boot")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app/web-1"}, booting)
+	assert.Empty(t, attaching)
+	assert.Equal(t, v1alpha1.BlockStorageExpansionActionReboot, ExpansionActionFor(booting, attaching))
+
+	booting, attaching, err = CloudServersAttaching(ctx, k8sClient, "bs-data")
+	require.NoError(t, err)
+	assert.Empty(t, booting)
+	assert.Equal(t, []string{"app/web-1", "data/db-1"}, attaching)
+	assert.Equal(t, v1alpha1.BlockStorageExpansionActionGrowFilesystem, ExpansionActionFor(booting, attaching))
+
+	booting, attaching, err = CloudServersAttaching(ctx, k8sClient, "")
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.BlockStorageExpansionActionNone, ExpansionActionFor(booting, attaching))
+}
@@ -0,0 +1,141 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=referencegrants,verbs=get;list;watch
+
+// referrerKey is the context key of the resource being reconciled
+type referrerKey struct{}
+
+// withReferrer returns a copy of ctx recording obj as the resource whose references are resolved
+func withReferrer(ctx context.Context, obj client.Object) context.Context {
+	return context.WithValue(ctx, referrerKey{}, obj)
+}
+
+// referrerOf returns the resource recorded in ctx by withReferrer, or nil
+func referrerOf(ctx context.Context) client.Object {
+	obj, _ := ctx.Value(referrerKey{}).(client.Object)
+	return obj
+}
+
+// ReferenceNotGrantedError is returned when a resource references a resource of another
+// namespace without a ReferenceGrant allowing it
+type ReferenceNotGrantedError struct {
+	FromKind      string
+	FromNamespace string
+	Kind          string
+	Name          string
+	Namespace     string
+}
+
+func (e *ReferenceNotGrantedError) Error() string {
+	return fmt.Sprintf("no ReferenceGrant in namespace %s allows %s resources of namespace %s to reference %s %s",
+		e.Namespace, e.FromKind, e.FromNamespace, e.Kind, e.Name)
+}
+
+// checkReferenceGrant returns a *ReferenceNotGrantedError when from may not reference the
+// resource of kind with name in namespace. References within the namespace of from, or
+// made outside of a reconciliation (from is nil), are always allowed.
+func (r *Reconciler) checkReferenceGrant(ctx context.Context, from client.Object, kind, name, namespace string) error {
+	if from == nil || namespace == "" || namespace == from.GetNamespace() {
+		return nil
+	}
+
+	gvk, err := apiutil.GVKForObject(from, r.Scheme)
+	if err != nil {
+		return err
+	}
+
+	grants := &v1alpha1.ReferenceGrantList{}
+	if err := r.List(ctx, grants, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list ReferenceGrants in namespace %s: %w", namespace, err)
+	}
+	for _, grant := range grants.Items {
+		if referenceGranted(grant.Spec, gvk.Kind, from.GetNamespace(), kind, name) {
+			return nil
+		}
+	}
+
+	return &ReferenceNotGrantedError{
+		FromKind:      gvk.Kind,
+		FromNamespace: from.GetNamespace(),
+		Kind:          kind,
+		Name:          name,
+		Namespace:     namespace,
+	}
+}
+
+// referenceGranted reports whether spec allows fromKind resources of fromNamespace to reference the named resource of kind
+func referenceGranted(spec v1alpha1.ReferenceGrantSpec, fromKind, fromNamespace, kind, name string) bool {
+	fromAllowed := false
+	for _, from := range spec.From {
+		if from.Kind == fromKind && from.Namespace == fromNamespace {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+
+	for _, to := range spec.To {
+		if to.Kind == kind && (to.Name == "" || to.Name == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReferrersGrantedBy returns a handler.MapFunc mapping a ReferenceGrant to the resources of the
+// kind of list in its from namespaces that reference its namespace, so that a reference waiting
+// for a grant is resolved as soon as the grant changes
+func (r *Reconciler) ReferrersGrantedBy(list client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		grant, ok := obj.(*v1alpha1.ReferenceGrant)
+		if !ok {
+			return nil
+		}
+		gvk, err := apiutil.GVKForObject(list, r.Scheme)
+		if err != nil {
+			return nil
+		}
+		kind := strings.TrimSuffix(gvk.Kind, "List")
+
+		var requests []reconcile.Request
+		for _, from := range grant.Spec.From {
+			if from.Kind != kind {
+				continue
+			}
+			referrers := list.DeepCopyObject().(client.ObjectList)
+			if err := r.List(ctx, referrers, client.InNamespace(from.Namespace)); err != nil {
+				ctrl.LoggerFrom(ctx).Error(err, "failed to list the referrers of a ReferenceGrant", "Kind", kind, "Namespace", from.Namespace)
+				continue
+			}
+			_ = meta.EachListItem(referrers, func(item runtime.Object) error {
+				referrer := item.(client.Object)
+				for _, ref := range ReferencesOf(referrer) {
+					if ref.Namespace == grant.Namespace {
+						requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(referrer)})
+						break
+					}
+				}
+				return nil
+			})
+		}
+		return requests
+	}
+}
@@ -0,0 +1,175 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+func TestCheckReferenceGrant(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	grant := &v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "network"},
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{{Kind: "Subnet", Namespace: "app"}},
+			To:   []v1alpha1.ReferenceGrantTo{{Kind: "Vpc", Name: "shared"}, {Kind: "Project"}},
+		},
+	}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(grant).Build(),
+		Scheme: scheme,
+	}
+	subnet := &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet", Namespace: "app"}}
+
+	tests := []struct {
+		name      string
+		from      *v1alpha1.Subnet
+		kind      string
+		refName   string
+		namespace string
+		granted   bool
+	}{
+		{name: "same namespace", from: subnet, kind: "Vpc", refName: "local", namespace: "app", granted: true},
+		{name: "granted by name", from: subnet, kind: "Vpc", refName: "shared", namespace: "network", granted: true},
+		{name: "granted for every name", from: subnet, kind: "Project", refName: "any", namespace: "network", granted: true},
+		{name: "other name", from: subnet, kind: "Vpc", refName: "private", namespace: "network"},
+		{name: "other kind", from: subnet, kind: "SecurityGroup", refName: "shared", namespace: "network"},
+		{name: "other referrer namespace", from: &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet", Namespace: "other"}},
+			kind: "Vpc", refName: "shared", namespace: "network"},
+		{name: "namespace without grants", from: subnet, kind: "Vpc", refName: "shared", namespace: "shared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.checkReferenceGrant(context.Background(), tt.from, tt.kind, tt.refName, tt.namespace)
+			if tt.granted {
+				assert.NoError(t, err)
+				return
+			}
+			var notGranted *ReferenceNotGrantedError
+			require.True(t, errors.As(err, &notGranted), "expected ReferenceNotGrantedError, got %v", err)
+			assert.Equal(t, "Subnet", notGranted.FromKind)
+		})
+	}
+}
+
+func TestGetVpcID_ReferenceGrant(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	vpc := &v1alpha1.Vpc{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "network"},
+		Status:     v1alpha1.VpcStatus{ResourceStatus: v1alpha1.ResourceStatus{ResourceID: "vpc-123"}},
+	}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(vpc).Build(),
+		Scheme: scheme,
+	}
+	ctx := withReferrer(context.Background(), &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet", Namespace: "app"}})
+
+	_, err := r.GetVpcID(ctx, "shared", "network")
+	var notGranted *ReferenceNotGrantedError
+	require.True(t, errors.As(err, &notGranted), "expected ReferenceNotGrantedError, got %v", err)
+
+	require.NoError(t, r.Create(ctx, &v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "network"},
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{{Kind: "Subnet", Namespace: "app"}},
+			To:   []v1alpha1.ReferenceGrantTo{{Kind: "Vpc"}},
+		},
+	}))
+	id, err := r.GetVpcID(ctx, "shared", "network")
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-123", id)
+}
+
+func TestReferrersGrantedBy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	shared := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "app"},
+		Spec:       v1alpha1.SubnetSpec{VpcReference: v1alpha1.ResourceReference{Name: "shared", Namespace: "network"}},
+	}
+	local := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "app"},
+		Spec:       v1alpha1.SubnetSpec{VpcReference: v1alpha1.ResourceReference{Name: "local"}},
+	}
+	other := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"},
+		Spec:       v1alpha1.SubnetSpec{VpcReference: v1alpha1.ResourceReference{Name: "shared", Namespace: "network"}},
+	}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(shared, local, other).Build(),
+		Scheme: scheme,
+	}
+	grant := &v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "network"},
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{{Kind: "Subnet", Namespace: "app"}, {Kind: "CloudServer", Namespace: "other"}},
+			To:   []v1alpha1.ReferenceGrantTo{{Kind: "Vpc"}},
+		},
+	}
+
+	requests := r.ReferrersGrantedBy(&v1alpha1.SubnetList{})(context.Background(), grant)
+	require.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Name: "shared", Namespace: "app"}, requests[0].NamespacedName)
+
+	assert.Empty(t, r.ReferrersGrantedBy(&v1alpha1.VpcList{})(context.Background(), grant))
+}
+
+func TestHandlePhaseTimeout_ReferenceNotGranted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	vpc := &v1alpha1.Vpc{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "network"},
+		Status:     v1alpha1.VpcStatus{ResourceStatus: v1alpha1.ResourceStatus{ResourceID: "vpc-123"}},
+	}
+	started := metav1.NewTime(time.Now().Add(-2 * maxPhaseTimeout))
+	subnet := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet", Namespace: "app"},
+		Status: v1alpha1.SubnetStatus{ResourceStatus: v1alpha1.ResourceStatus{
+			Phase:          v1alpha1.ResourcePhaseCreating,
+			PhaseStartTime: &started,
+			Conditions: []metav1.Condition{{
+				Type:   v1alpha1.ConditionTypeSynchronized,
+				Status: metav1.ConditionFalse,
+				Reason: "ReferenceNotGranted",
+			}},
+		}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vpc, subnet).WithStatusSubresource(subnet).Build()
+	r := &Reconciler{Client: k8sClient, Scheme: scheme}
+	ctx := withReferrer(context.Background(), subnet)
+
+	// A reference waiting for a grant doesn't time out
+	isTimeout, _, err := r.HandlePhaseTimeout(ctx, subnet, &subnet.Status.ResourceStatus)
+	require.NoError(t, err)
+	assert.False(t, isTimeout)
+	assert.Equal(t, v1alpha1.ResourcePhaseCreating, subnet.Status.Phase)
+
+	// so the grant still takes effect when created after the timeout
+	require.NoError(t, r.Create(ctx, &v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "network"},
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{{Kind: "Subnet", Namespace: "app"}},
+			To:   []v1alpha1.ReferenceGrantTo{{Kind: "Vpc"}},
+		},
+	}))
+	id, err := r.GetVpcID(ctx, "shared", "network")
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-123", id)
+}
@@ -0,0 +1,345 @@
+package reconciler
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// KeyPairSecretOwnerLabel is set on the Secrets of generated key pairs to the UID of the KeyPair
+const KeyPairSecretOwnerLabel = "arubacloud.com/keypair-owner"
+
+// Keys of the Secret of a generated key pair
+const (
+	KeyPairSecretKeyPublicOpenSSH = "ssh-publickey"
+	KeyPairSecretKeyPrivatePEM    = "private.pem"
+	KeyPairSecretKeyPublicPEM     = "public.pem"
+)
+
+// DefaultKeyPairRSABits is the size of the generated RSA keys when not set
+const DefaultKeyPairRSABits = 4096
+
+// KeyPairPublicKey is the public half of a key pair
+type KeyPairPublicKey struct {
+	// PublicKey is the public key in authorized_keys format
+	PublicKey string
+	// Fingerprint is the SHA256 fingerprint of the public key
+	Fingerprint string
+}
+
+// KeyPairPublicKeyOf returns the public key of keyPair, generated or read from valueFrom when
+// requested. The fingerprint of a public key set in value is left empty when it can't be parsed.
+func (r *Reconciler) KeyPairPublicKeyOf(ctx context.Context, keyPair *v1alpha1.KeyPair) (*KeyPairPublicKey, error) {
+	switch {
+	case keyPair.Spec.Generate != nil:
+		return r.EnsureGeneratedKeyPair(ctx, keyPair, keyPair.Spec.Generate)
+	case keyPair.Spec.ValueFrom != nil:
+		value, err := r.keyPairValueFrom(ctx, keyPair.Namespace, keyPair.Spec.ValueFrom)
+		if err != nil {
+			return nil, err
+		}
+		return ParsePublicKey(value)
+	default:
+		publicKey, err := ParsePublicKey(keyPair.Spec.Value)
+		if err != nil {
+			return &KeyPairPublicKey{PublicKey: keyPair.Spec.Value}, nil
+		}
+		return publicKey, nil
+	}
+}
+
+// ParsePublicKey parses an SSH public key in authorized_keys format
+func ParsePublicKey(value string) (*KeyPairPublicKey, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(value))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid SSH public key: %w", err)
+	}
+	return &KeyPairPublicKey{
+		PublicKey:   strings.TrimSpace(value),
+		Fingerprint: ssh.FingerprintSHA256(publicKey),
+	}, nil
+}
+
+// keyPairValueFrom returns the value of the Secret or ConfigMap key selected by source in namespace
+func (r *Reconciler) keyPairValueFrom(ctx context.Context, namespace string, source *v1alpha1.KeyPairValueSource) (string, error) {
+	if ref := source.SecretKeyRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			return "", fmt.Errorf("failed to get secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("secret %s/%s has no key %s", namespace, ref.Name, ref.Key)
+		}
+		return string(value), nil
+	}
+	if ref := source.ConfigMapKeyRef; ref != nil {
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, configMap); err != nil {
+			return "", fmt.Errorf("failed to get configmap %s/%s: %w", namespace, ref.Name, err)
+		}
+		if value, ok := configMap.Data[ref.Key]; ok {
+			return value, nil
+		}
+		if value, ok := configMap.BinaryData[ref.Key]; ok {
+			return string(value), nil
+		}
+		return "", fmt.Errorf("configmap %s/%s has no key %s", namespace, ref.Name, ref.Key)
+	}
+	return "", fmt.Errorf("valueFrom selects neither a secret nor a configmap")
+}
+
+// EnsureGeneratedKeyPair returns the public half of the key pair stored in the Secret described by
+// gen, generating the key pair and creating the Secret, owned by obj, when it does not exist yet.
+// The private key only ever leaves this function inside the Secret.
+func (r *Reconciler) EnsureGeneratedKeyPair(ctx context.Context, obj client.Object, gen *v1alpha1.KeyPairGeneration) (*KeyPairPublicKey, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: gen.SecretName}, secret)
+	if err == nil {
+		if secret.Labels[KeyPairSecretOwnerLabel] != string(obj.GetUID()) {
+			return nil, fmt.Errorf("secret %s/%s already exists and was not generated for %s", secret.Namespace, secret.Name, obj.GetName())
+		}
+		return generatedKeyPairOf(secret)
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get key pair secret %s/%s: %w", obj.GetNamespace(), gen.SecretName, err)
+	}
+
+	privateKey, err := generatePrivateKey(gen)
+	if err != nil {
+		return nil, err
+	}
+	secret, err = keyPairSecret(privateKey, gen.Formats)
+	if err != nil {
+		return nil, err
+	}
+	secret.Name = gen.SecretName
+	secret.Namespace = obj.GetNamespace()
+	secret.Labels = map[string]string{KeyPairSecretOwnerLabel: string(obj.GetUID())}
+	if err := controllerutil.SetControllerReference(obj, secret, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create key pair secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return generatedKeyPairOf(secret)
+}
+
+// generatePrivateKey generates a private key with the algorithm and size of gen
+func generatePrivateKey(gen *v1alpha1.KeyPairGeneration) (crypto.Signer, error) {
+	switch gen.Algorithm {
+	case v1alpha1.KeyPairAlgorithmRSA:
+		bits := int(gen.Bits)
+		if bits == 0 {
+			bits = DefaultKeyPairRSABits
+		}
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate rsa key: %w", err)
+		}
+		return key, nil
+	case v1alpha1.KeyPairAlgorithmEd25519, "":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ed25519 key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key pair algorithm %q", gen.Algorithm)
+	}
+}
+
+// keyPairSecret returns a Secret holding privateKey and its public key in formats
+func keyPairSecret(privateKey crypto.Signer, formats []v1alpha1.KeyFormat) (*corev1.Secret, error) {
+	if len(formats) == 0 {
+		formats = []v1alpha1.KeyFormat{v1alpha1.KeyFormatOpenSSH}
+	}
+	publicKey, err := ssh.NewPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	secret := &corev1.Secret{Type: corev1.SecretTypeOpaque, Data: map[string][]byte{}}
+	if slices.Contains(formats, v1alpha1.KeyFormatOpenSSH) {
+		block, err := ssh.MarshalPrivateKey(privateKey, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode private key: %w", err)
+		}
+		secret.Type = corev1.SecretTypeSSHAuth
+		secret.Data[corev1.SSHAuthPrivateKey] = pem.EncodeToMemory(block)
+		secret.Data[KeyPairSecretKeyPublicOpenSSH] = ssh.MarshalAuthorizedKey(publicKey)
+	}
+	if slices.Contains(formats, v1alpha1.KeyFormatPEM) {
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode private key: %w", err)
+		}
+		secret.Data[KeyPairSecretKeyPrivatePEM] = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		der, err = x509.MarshalPKIXPublicKey(privateKey.Public())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode public key: %w", err)
+		}
+		secret.Data[KeyPairSecretKeyPublicPEM] = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	}
+	return secret, nil
+}
+
+// generatedKeyPairOf returns the public half of the key pair stored in secret
+func generatedKeyPairOf(secret *corev1.Secret) (*KeyPairPublicKey, error) {
+	var (
+		key any
+		err error
+	)
+	switch {
+	case len(secret.Data[corev1.SSHAuthPrivateKey]) > 0:
+		key, err = ssh.ParseRawPrivateKey(secret.Data[corev1.SSHAuthPrivateKey])
+	case len(secret.Data[KeyPairSecretKeyPrivatePEM]) > 0:
+		block, _ := pem.Decode(secret.Data[KeyPairSecretKeyPrivatePEM])
+		if block == nil {
+			return nil, fmt.Errorf("secret %s/%s holds no PEM private key", secret.Namespace, secret.Name)
+		}
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("secret %s/%s holds no private key", secret.Namespace, secret.Name)
+	}
+	if err != nil {
+		// The parse error is not wrapped, so that nothing of the key can end up in the status
+		return nil, fmt.Errorf("secret %s/%s holds an invalid private key", secret.Namespace, secret.Name)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s holds an unsupported private key", secret.Namespace, secret.Name)
+	}
+	publicKey, err := ssh.NewPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	return &KeyPairPublicKey{
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		Fingerprint: ssh.FingerprintSHA256(publicKey),
+	}, nil
+}
+
+// KeyMaterialChanged reports whether publicKey differs from the key the remote keypair was created
+// with. It is false while that key is unknown.
+func KeyMaterialChanged(keyPair *v1alpha1.KeyPair, publicKey *KeyPairPublicKey) bool {
+	if keyPair.Status.PublicKey == "" && keyPair.Status.Fingerprint == "" {
+		return false
+	}
+	if publicKey.Fingerprint != "" && keyPair.Status.Fingerprint != "" {
+		return publicKey.Fingerprint != keyPair.Status.Fingerprint
+	}
+	return publicKey.PublicKey != keyPair.Status.PublicKey
+}
+
+// CloudServersUsingKeyPair returns the namespace/name of the cloud servers created with the
+// remote keypair with keyPairID, or referencing it by ID
+func CloudServersUsingKeyPair(ctx context.Context, c client.Reader, keyPairID string) ([]string, error) {
+	if keyPairID == "" {
+		return nil, nil
+	}
+	cloudServers := &v1alpha1.CloudServerList{}
+	if err := c.List(ctx, cloudServers); err != nil {
+		return nil, fmt.Errorf("failed to list cloud servers: %w", err)
+	}
+
+	var users []string
+	for _, cloudServer := range cloudServers.Items {
+		if cloudServer.Status.KeyPairID == keyPairID || cloudServer.Spec.KeyPairReference.ID == keyPairID {
+			users = append(users, cloudServer.Namespace+"/"+cloudServer.Name)
+		}
+	}
+	slices.Sort(users)
+	return users, nil
+}
+
+// MoveCloudServersToKeyPair updates the cloud servers created with the remote keypair previousID
+// to use the remote keypair keyPairID of the project projectID. It returns the namespace/name of
+// the cloud servers the API refused to update, which keep the previous keypair.
+func (r *Reconciler) MoveCloudServersToKeyPair(ctx context.Context, projectID, previousID, keyPairID string) ([]string, error) {
+	cloudServers := &v1alpha1.CloudServerList{}
+	if err := r.List(ctx, cloudServers); err != nil {
+		return nil, fmt.Errorf("failed to list cloud servers: %w", err)
+	}
+
+	keyPairURI := fmt.Sprintf("/projects/%s/providers/Aruba.Compute/keyPairs/%s", projectID, keyPairID)
+	var refused []string
+	for i := range cloudServers.Items {
+		cloudServer := &cloudServers.Items[i]
+		if cloudServer.Status.KeyPairID != previousID {
+			continue
+		}
+		name := cloudServer.Namespace + "/" + cloudServer.Name
+
+		err := r.moveCloudServerToKeyPair(ctx, cloudServer, keyPairURI)
+		var apiErr *arubaClient.ApiError
+		if errors.As(err, &apiErr) && (apiErr.IsValidationError() || (apiErr.Status >= 400 && apiErr.Status < 500 && !apiErr.IsInvalidStatus())) {
+			refused = append(refused, fmt.Sprintf("%s (%s)", name, apiErr.Title))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to move cloud server %s to keypair %s: %w", name, keyPairID, err)
+		}
+
+		// The cloud server controller sends the keypair of the status with every update
+		patch := client.MergeFrom(cloudServer.DeepCopy())
+		cloudServer.Status.KeyPairID = keyPairID
+		if err := r.Status().Patch(ctx, cloudServer, patch); err != nil {
+			return nil, fmt.Errorf("failed to update the keypair of cloud server %s: %w", name, err)
+		}
+	}
+	slices.Sort(refused)
+	return refused, nil
+}
+
+// moveCloudServerToKeyPair sends the remote cloud server back with its keypair replaced by keyPairURI
+func (r *Reconciler) moveCloudServerToKeyPair(ctx context.Context, cloudServer *v1alpha1.CloudServer, keyPairURI string) error {
+	if cloudServer.Status.ResourceID == "" {
+		return nil
+	}
+	resp, err := r.GetCloudServer(ctx, cloudServer.Status.ProjectID, cloudServer.Status.ResourceID)
+	var apiErr *arubaClient.ApiError
+	if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+		// A cloud server deleted outside of the operator doesn't use the previous keypair anymore
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if resp.Properties.KeyPair.URI == keyPairURI {
+		return nil
+	}
+
+	req := arubaClient.CloudServerRequest{
+		Metadata: arubaClient.CloudServerMetadata{
+			Name:     resp.Metadata.Name,
+			Tags:     resp.Metadata.Tags,
+			Location: resp.Metadata.Location,
+		},
+		Properties: resp.Properties,
+	}
+	req.Properties.KeyPair = arubaClient.CloudServerResourceReference{URI: keyPairURI}
+	// Outputs of the API, not part of the request
+	req.Properties.IPAddress = ""
+	req.Properties.Flavor = nil
+	_, err = r.UpdateCloudServer(ctx, cloudServer.Status.ProjectID, cloudServer.Status.ResourceID, req)
+	return err
+}
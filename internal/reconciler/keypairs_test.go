@@ -0,0 +1,259 @@
+package reconciler
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+func TestEnsureGeneratedKeyPair(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	keyPair := &v1alpha1.KeyPair{ObjectMeta: metav1.ObjectMeta{Name: "kp", Namespace: "app", UID: "uid-1"}}
+	foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "app"}}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(keyPair, foreign).Build(),
+		Scheme: scheme,
+	}
+	ctx := context.Background()
+
+	t.Run("ed25519", func(t *testing.T) {
+		gen := &v1alpha1.KeyPairGeneration{SecretName: "kp-ed25519"}
+		generated, err := r.EnsureGeneratedKeyPair(ctx, keyPair, gen)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(generated.PublicKey, "ssh-ed25519 "), generated.PublicKey)
+		assert.True(t, strings.HasPrefix(generated.Fingerprint, "SHA256:"), generated.Fingerprint)
+
+		secret := &corev1.Secret{}
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "kp-ed25519", Namespace: "app"}, secret))
+		assert.Equal(t, corev1.SecretTypeSSHAuth, secret.Type)
+		assert.Contains(t, string(secret.Data[corev1.SSHAuthPrivateKey]), "OPENSSH PRIVATE KEY")
+		assert.Equal(t, generated.PublicKey+"\n", string(secret.Data[KeyPairSecretKeyPublicOpenSSH]))
+		require.Len(t, secret.OwnerReferences, 1)
+		assert.Equal(t, "kp", secret.OwnerReferences[0].Name)
+
+		again, err := r.EnsureGeneratedKeyPair(ctx, keyPair, gen)
+		require.NoError(t, err)
+		assert.Equal(t, generated, again, "the stored key pair is reused")
+	})
+
+	t.Run("rsa in PEM", func(t *testing.T) {
+		gen := &v1alpha1.KeyPairGeneration{
+			Algorithm:  v1alpha1.KeyPairAlgorithmRSA,
+			Bits:       2048,
+			SecretName: "kp-rsa",
+			Formats:    []v1alpha1.KeyFormat{v1alpha1.KeyFormatPEM},
+		}
+		generated, err := r.EnsureGeneratedKeyPair(ctx, keyPair, gen)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(generated.PublicKey, "ssh-rsa "), generated.PublicKey)
+
+		secret := &corev1.Secret{}
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "kp-rsa", Namespace: "app"}, secret))
+		assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+		assert.ElementsMatch(t, []string{KeyPairSecretKeyPrivatePEM, KeyPairSecretKeyPublicPEM}, slices.Collect(maps.Keys(secret.Data)))
+
+		again, err := r.EnsureGeneratedKeyPair(ctx, keyPair, gen)
+		require.NoError(t, err)
+		assert.Equal(t, generated, again, "the stored key pair is reused")
+	})
+
+	t.Run("existing secret", func(t *testing.T) {
+		_, err := r.EnsureGeneratedKeyPair(ctx, keyPair, &v1alpha1.KeyPairGeneration{SecretName: "foreign"})
+		assert.ErrorContains(t, err, "was not generated for kp")
+	})
+}
+
+func TestKeyPairPublicKeyOf(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshKey, err := ssh.NewPublicKey(edKey)
+	require.NoError(t, err)
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshKey)))
+
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "app"},
+				Data:       map[string][]byte{"id.pub": []byte(authorizedKey + " user@example.com\n"), "invalid": []byte("not a key")},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "app"},
+				Data:       map[string]string{"id.pub": authorizedKey},
+			},
+		).Build(),
+		Scheme: scheme,
+	}
+	ctx := context.Background()
+	keyPairFrom := func(source v1alpha1.KeyPairValueSource) *v1alpha1.KeyPair {
+		return &v1alpha1.KeyPair{
+			ObjectMeta: metav1.ObjectMeta{Name: "kp", Namespace: "app"},
+			Spec:       v1alpha1.KeyPairSpec{ValueFrom: &source},
+		}
+	}
+
+	t.Run("secret", func(t *testing.T) {
+		publicKey, err := r.KeyPairPublicKeyOf(ctx, keyPairFrom(v1alpha1.KeyPairValueSource{
+			SecretKeyRef: &v1alpha1.LocalKeySelector{Name: "keys", Key: "id.pub"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, authorizedKey+" user@example.com", publicKey.PublicKey)
+		assert.Equal(t, ssh.FingerprintSHA256(sshKey), publicKey.Fingerprint)
+	})
+
+	t.Run("configmap", func(t *testing.T) {
+		publicKey, err := r.KeyPairPublicKeyOf(ctx, keyPairFrom(v1alpha1.KeyPairValueSource{
+			ConfigMapKeyRef: &v1alpha1.LocalKeySelector{Name: "keys", Key: "id.pub"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, ssh.FingerprintSHA256(sshKey), publicKey.Fingerprint)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := r.KeyPairPublicKeyOf(ctx, keyPairFrom(v1alpha1.KeyPairValueSource{
+			SecretKeyRef: &v1alpha1.LocalKeySelector{Name: "keys", Key: "invalid"},
+		}))
+		assert.ErrorContains(t, err, "not a valid SSH public key")
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := r.KeyPairPublicKeyOf(ctx, keyPairFrom(v1alpha1.KeyPairValueSource{
+			ConfigMapKeyRef: &v1alpha1.LocalKeySelector{Name: "keys", Key: "missing"},
+		}))
+		assert.ErrorContains(t, err, "has no key missing")
+	})
+}
+
+func TestKeyMaterialChanged(t *testing.T) {
+	applied := &v1alpha1.KeyPair{Status: v1alpha1.KeyPairStatus{PublicKey: "ssh-ed25519 AAAA old", Fingerprint: "SHA256:old"}}
+
+	assert.False(t, KeyMaterialChanged(applied, &KeyPairPublicKey{PublicKey: "ssh-ed25519 AAAA renamed", Fingerprint: "SHA256:old"}), "a comment change keeps the key")
+	assert.True(t, KeyMaterialChanged(applied, &KeyPairPublicKey{PublicKey: "ssh-ed25519 BBBB", Fingerprint: "SHA256:new"}))
+	assert.True(t, KeyMaterialChanged(&v1alpha1.KeyPair{Status: v1alpha1.KeyPairStatus{PublicKey: "invalid"}}, &KeyPairPublicKey{PublicKey: "changed"}))
+	assert.False(t, KeyMaterialChanged(&v1alpha1.KeyPair{}, &KeyPairPublicKey{PublicKey: "ssh-ed25519 BBBB", Fingerprint: "SHA256:new"}), "an unknown key is never rotated")
+}
+
+func TestCloudServersUsingKeyPair(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1alpha1.CloudServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "b"},
+			Status:     v1alpha1.CloudServerStatus{KeyPairID: "kp-1"},
+		},
+		&v1alpha1.CloudServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "a"},
+			Spec:       v1alpha1.CloudServerSpec{KeyPairReference: v1alpha1.ResourceReference{ID: "kp-1"}},
+		},
+		&v1alpha1.CloudServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "a"},
+			Status:     v1alpha1.CloudServerStatus{KeyPairID: "kp-2"},
+		},
+	).Build()
+
+	users, err := CloudServersUsingKeyPair(context.Background(), k8sClient, "kp-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a/db", "b/web"}, users)
+
+	users, err = CloudServersUsingKeyPair(context.Background(), k8sClient, "")
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+// cloudServerHTTPClient answers the cloud servers using the keypair kp-1, refuses the updates of
+// the refused ones and records the keypair sent with the other updates
+type cloudServerHTTPClient struct {
+	refused []string
+	updated map[string]string
+}
+
+func (c *cloudServerHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	id := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+	if id == "cs-gone" {
+		return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(`{"title":"Not Found","status":404}`))}, nil
+	}
+	if req.Method == http.MethodPut {
+		if slices.Contains(c.refused, id) {
+			return &http.Response{StatusCode: http.StatusUnprocessableEntity, Status: "422 Unprocessable Entity",
+				Body: io.NopCloser(strings.NewReader(`{"title":"The key pair can't be changed","status":422}`))}, nil
+		}
+		var body arubaClient.CloudServerRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		c.updated[id] = body.Properties.KeyPair.URI
+	}
+	body := `{"metadata":{"id":"` + id + `","name":"` + id + `"},"properties":{"flavorName":"small","keyPair":{"uri":"/projects/p-1/providers/Aruba.Compute/keyPairs/kp-1"}}}`
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestMoveCloudServersToKeyPair(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	cloudServer := func(name, resourceID, keyPairID string) *v1alpha1.CloudServer {
+		return &v1alpha1.CloudServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+			Status: v1alpha1.CloudServerStatus{
+				ResourceStatus: v1alpha1.ResourceStatus{ResourceID: resourceID},
+				ProjectID:      "p-1",
+				KeyPairID:      keyPairID,
+			},
+		}
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			cloudServer("web", "cs-web", "kp-1"),
+			cloudServer("db", "cs-db", "kp-1"),
+			cloudServer("gone", "cs-gone", "kp-1"),
+			cloudServer("other", "cs-other", "kp-3"),
+		).
+		WithStatusSubresource(&v1alpha1.CloudServer{}).
+		Build()
+	httpClient := &cloudServerHTTPClient{refused: []string{"cs-db"}, updated: map[string]string{}}
+	r := &Reconciler{
+		Client:       k8sClient,
+		Scheme:       scheme,
+		HelperClient: arubaClient.NewHelperClient(k8sClient, httpClient, "https://api.example.com"),
+	}
+
+	refused, err := r.MoveCloudServersToKeyPair(context.Background(), "p-1", "kp-1", "kp-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app/db (The key pair can't be changed)"}, refused)
+	assert.Equal(t, map[string]string{"cs-web": "/projects/p-1/providers/Aruba.Compute/keyPairs/kp-2"}, httpClient.updated)
+
+	keyPairIDs := map[string]string{}
+	for _, name := range []string{"web", "db", "gone", "other"} {
+		got := &v1alpha1.CloudServer{}
+		require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "app"}, got))
+		keyPairIDs[name] = got.Status.KeyPairID
+	}
+	assert.Equal(t, map[string]string{"web": "kp-2", "db": "kp-1", "gone": "kp-2", "other": "kp-3"}, keyPairIDs,
+		"the refused cloud server keeps the previous keypair")
+}
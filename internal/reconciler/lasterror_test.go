@@ -0,0 +1,32 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecFieldPath(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "", want: ""},
+		{field: "properties", want: "spec"},
+		{field: "properties.billingPlan.billingPeriod", want: "spec.billingPlan.billingPeriod"},
+		{field: "Properties.SizeGb", want: "spec.sizeGb"},
+		{field: "sizeGb", want: "spec.sizeGb"},
+		{field: "metadata", want: "spec"},
+		{field: "metadata.tags", want: "spec.tags"},
+		{field: "metadata.tags[0]", want: "spec.tags[0]"},
+		{field: "metadata.location", want: "spec.location"},
+		{field: "Metadata.Location.Value", want: "spec.location.value"},
+		{field: "metadata.name", want: "metadata.name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.want, specFieldPath(tt.field))
+		})
+	}
+}
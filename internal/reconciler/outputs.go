@@ -0,0 +1,19 @@
+package reconciler
+
+import (
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// RemoteOutputsOf returns the status outputs of a remote resource from its info
+func RemoteOutputsOf(info arubaClient.RemoteInfo) *v1alpha1.RemoteOutputs {
+	return &v1alpha1.RemoteOutputs{
+		URI:          info.URI,
+		State:        info.State,
+		CreationDate: info.CreationDate,
+		CreatedBy:    info.CreatedBy,
+		UpdateDate:   info.UpdateDate,
+		UpdatedBy:    info.UpdatedBy,
+		Version:      info.Version,
+	}
+}
@@ -0,0 +1,91 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+func TestHandleRemoteMissing_ReportsOnce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	vpc := &v1alpha1.Vpc{
+		ObjectMeta: metav1.ObjectMeta{Name: "vpc", Namespace: "app", Generation: 1},
+		Status:     v1alpha1.VpcStatus{ResourceStatus: v1alpha1.ResourceStatus{ResourceID: "vpc-123", Phase: v1alpha1.ResourcePhaseCreated, ObservedGeneration: 1}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vpc).WithStatusSubresource(vpc).Build()
+	r := &Reconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+
+	get := func(context.Context) error {
+		return &arubaClient.ApiError{Status: 404, Title: "Not Found"}
+	}
+
+	missing, result, err := r.HandleRemoteMissing(ctx, vpc, &vpc.Status.ResourceStatus, v1alpha1.RemoteDeletionPolicyReport, get)
+	require.NoError(t, err)
+	assert.True(t, missing)
+	assert.Equal(t, remoteCheckInterval, result.RequeueAfter)
+
+	reported := &v1alpha1.Vpc{}
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: "vpc", Namespace: "app"}, reported))
+	assert.True(t, meta.IsStatusConditionTrue(reported.Status.Conditions, v1alpha1.ConditionTypeRemoteMissing))
+
+	// A spec change made while the resource is missing is not marked as observed
+	reported.Generation = 2
+	missing, result, err = r.HandleRemoteMissing(ctx, reported, &reported.Status.ResourceStatus, v1alpha1.RemoteDeletionPolicyReport, get)
+	require.NoError(t, err)
+	assert.True(t, missing)
+	assert.Equal(t, remoteCheckInterval, result.RequeueAfter)
+
+	unchanged := &v1alpha1.Vpc{}
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: "vpc", Namespace: "app"}, unchanged))
+	assert.Equal(t, reported.ResourceVersion, unchanged.ResourceVersion)
+	assert.Equal(t, int64(1), unchanged.Status.ObservedGeneration)
+}
+
+func TestHandleRemoteMissing_RefreshesOutputs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	vpc := &v1alpha1.Vpc{
+		ObjectMeta: metav1.ObjectMeta{Name: "vpc", Namespace: "app"},
+		Status:     v1alpha1.VpcStatus{ResourceStatus: v1alpha1.ResourceStatus{ResourceID: "vpc-123", Phase: v1alpha1.ResourcePhaseCreated}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vpc).WithStatusSubresource(vpc).Build()
+	r := &Reconciler{Client: k8sClient, Scheme: scheme}
+	ctx := context.Background()
+
+	info := arubaClient.RemoteInfo{URI: "/projects/p/providers/Aruba.Network/vpcs/vpc-123", State: "Active", Version: "1"}
+	get := func(context.Context) error {
+		vpc.Status.Remote = RemoteOutputsOf(info)
+		return nil
+	}
+
+	missing, _, err := r.HandleRemoteMissing(ctx, vpc, &vpc.Status.ResourceStatus, v1alpha1.RemoteDeletionPolicyReport, get)
+	require.NoError(t, err)
+	assert.False(t, missing)
+
+	stored := &v1alpha1.Vpc{}
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: "vpc", Namespace: "app"}, stored))
+	require.NotNil(t, stored.Status.Remote)
+	assert.Equal(t, "Active", stored.Status.Remote.State)
+	assert.Equal(t, info.URI, stored.Status.Remote.URI)
+
+	// Unchanged outputs are not written again
+	_, _, err = r.HandleRemoteMissing(ctx, vpc, &vpc.Status.ResourceStatus, v1alpha1.RemoteDeletionPolicyReport, get)
+	require.NoError(t, err)
+	unchanged := &v1alpha1.Vpc{}
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: "vpc", Namespace: "app"}, unchanged))
+	assert.Equal(t, stored.ResourceVersion, unchanged.ResourceVersion)
+}
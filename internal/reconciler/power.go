@@ -0,0 +1,90 @@
+package reconciler
+
+import (
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// PowerStateOf returns the power state of a cloud server in the remote state, or an empty
+// state while the cloud server is transitioning
+func PowerStateOf(remoteState string) v1alpha1.CloudServerPowerState {
+	switch remoteState {
+	case arubaClient.CloudServerStateActive:
+		return v1alpha1.CloudServerPowerOn
+	case arubaClient.CloudServerStateStopped:
+		return v1alpha1.CloudServerPowerOff
+	default:
+		return ""
+	}
+}
+
+// PowerPlan is the next step bringing a cloud server to its desired power state
+type PowerPlan struct {
+	// Run is the power action to run now, if any
+	Run v1alpha1.CloudServerPowerAction
+	// Action is the power action in progress after this step
+	Action v1alpha1.CloudServerPowerAction
+	// HandledRestart is the restart request handled by this step, if any
+	HandledRestart string
+}
+
+// PlanPower returns the next step bringing cloudServer from its observed power state to the
+// desired one, running a requested restart first. Nothing is run while the cloud server is
+// transitioning or a previous action is still in progress.
+func PlanPower(cloudServer *v1alpha1.CloudServer) PowerPlan {
+	desired := cloudServer.Spec.PowerState
+	if desired == "" {
+		desired = v1alpha1.CloudServerPowerOn
+	}
+	observed := cloudServer.Status.PowerState
+	plan := PowerPlan{Action: cloudServer.Status.PowerAction}
+	if observed == "" {
+		return plan
+	}
+
+	switch plan.Action {
+	case v1alpha1.CloudServerPowerActionRestart:
+		// A restart powers the cloud server off, then back on
+		if observed == v1alpha1.CloudServerPowerOn {
+			return plan
+		}
+		plan.Action = ""
+		if desired == v1alpha1.CloudServerPowerOn {
+			plan.Run = v1alpha1.CloudServerPowerActionOn
+			plan.Action = v1alpha1.CloudServerPowerActionOn
+		}
+		return plan
+	case v1alpha1.CloudServerPowerActionOn, v1alpha1.CloudServerPowerActionOff:
+		if observed != powerStateAfter(plan.Action) {
+			return plan
+		}
+		plan.Action = ""
+	}
+
+	if restart := cloudServer.Annotations[v1alpha1.RestartAnnotation]; restart != "" && restart != cloudServer.Status.LastRestartRequest {
+		// Only a running cloud server that stays on is restarted
+		plan.HandledRestart = restart
+		if observed == v1alpha1.CloudServerPowerOn && desired == v1alpha1.CloudServerPowerOn {
+			plan.Run = v1alpha1.CloudServerPowerActionOff
+			plan.Action = v1alpha1.CloudServerPowerActionRestart
+			return plan
+		}
+	}
+
+	if observed != desired {
+		plan.Action = v1alpha1.CloudServerPowerActionOn
+		if desired == v1alpha1.CloudServerPowerOff {
+			plan.Action = v1alpha1.CloudServerPowerActionOff
+		}
+		plan.Run = plan.Action
+	}
+	return plan
+}
+
+// powerStateAfter returns the power state reached by action
+func powerStateAfter(action v1alpha1.CloudServerPowerAction) v1alpha1.CloudServerPowerState {
+	if action == v1alpha1.CloudServerPowerActionOff {
+		return v1alpha1.CloudServerPowerOff
+	}
+	return v1alpha1.CloudServerPowerOn
+}
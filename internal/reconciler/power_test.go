@@ -0,0 +1,65 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+func TestPlanPower(t *testing.T) {
+	const (
+		on       = v1alpha1.CloudServerPowerOn
+		off      = v1alpha1.CloudServerPowerOff
+		powerOn  = v1alpha1.CloudServerPowerActionOn
+		powerOff = v1alpha1.CloudServerPowerActionOff
+		restart  = v1alpha1.CloudServerPowerActionRestart
+	)
+	tests := []struct {
+		name        string
+		desired     v1alpha1.CloudServerPowerState
+		observed    v1alpha1.CloudServerPowerState
+		action      v1alpha1.CloudServerPowerAction
+		annotation  string
+		lastRestart string
+		want        PowerPlan
+	}{
+		{name: "running as desired", observed: on, want: PowerPlan{}},
+		{name: "power off", desired: off, observed: on, want: PowerPlan{Run: powerOff, Action: powerOff}},
+		{name: "power on", desired: on, observed: off, want: PowerPlan{Run: powerOn, Action: powerOn}},
+		{name: "transitioning", desired: off, observed: "", want: PowerPlan{}},
+		{name: "power off in progress", desired: off, observed: on, action: powerOff, want: PowerPlan{Action: powerOff}},
+		{name: "power off done", desired: off, observed: off, action: powerOff, want: PowerPlan{}},
+		{name: "restart requested", observed: on, annotation: "t1", want: PowerPlan{Run: powerOff, Action: restart, HandledRestart: "t1"}},
+		{name: "restart handled", observed: on, annotation: "t1", lastRestart: "t1", want: PowerPlan{}},
+		{name: "restart stopping", observed: on, action: restart, annotation: "t1", lastRestart: "t1", want: PowerPlan{Action: restart}},
+		{name: "restart stopped", observed: off, action: restart, annotation: "t1", lastRestart: "t1", want: PowerPlan{Run: powerOn, Action: powerOn}},
+		{name: "restart of a server to stop", desired: off, observed: off, action: restart, want: PowerPlan{}},
+		{name: "restart of a stopped server", desired: off, observed: off, annotation: "t1", want: PowerPlan{HandledRestart: "t1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cloudServer := &v1alpha1.CloudServer{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Spec:       v1alpha1.CloudServerSpec{PowerState: tt.desired},
+				Status: v1alpha1.CloudServerStatus{
+					PowerState:         tt.observed,
+					PowerAction:        tt.action,
+					LastRestartRequest: tt.lastRestart,
+				},
+			}
+			if tt.annotation != "" {
+				cloudServer.Annotations[v1alpha1.RestartAnnotation] = tt.annotation
+			}
+			assert.Equal(t, tt.want, PlanPower(cloudServer))
+		})
+	}
+}
+
+func TestPowerStateOf(t *testing.T) {
+	assert.Equal(t, v1alpha1.CloudServerPowerOn, PowerStateOf("Active"))
+	assert.Equal(t, v1alpha1.CloudServerPowerOff, PowerStateOf("Stopped"))
+	assert.Empty(t, PowerStateOf("Updating"))
+}
@@ -0,0 +1,258 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=providerconfigs,verbs=get;list;watch
+
+// ProviderConfigRefOf returns the ProviderConfig referenced by obj, or nil when it uses the operator configuration
+func ProviderConfigRefOf(obj client.Object) *v1alpha1.ProviderConfigReference {
+	switch o := obj.(type) {
+	case *v1alpha1.Project:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.ElasticIp:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.BlockStorage:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.BlockStorageSnapshot:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.KeyPair:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.Vpc:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.Subnet:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.SecurityGroup:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.SecurityRule:
+		return o.Spec.ProviderConfigRef
+	case *v1alpha1.CloudServer:
+		return o.Spec.ProviderConfigRef
+	}
+	return nil
+}
+
+// providerClients holds the clients built for a generation of a ProviderConfig
+type providerClients struct {
+	// mu serializes the authentications, since the token manager holds a single set of credentials
+	mu         sync.Mutex
+	generation int64
+	tokens     arubaClient.ITokenManager
+	vault      *arubaClient.AppRoleClient
+}
+
+// ProviderRegistry authenticates with the ProviderConfigs, caching their clients
+// until the ProviderConfig changes
+type ProviderRegistry struct {
+	client.Client
+	// NewTokenManager builds the token manager of a ProviderConfig
+	NewTokenManager func(keycloakURL, realm string) arubaClient.ITokenManager
+	// NewVaultClient builds and logs in the Vault client of a ProviderConfig
+	NewVaultClient func(vault *v1alpha1.VaultCredentials, roleID, secretID string) (*arubaClient.AppRoleClient, error)
+
+	mu      sync.Mutex
+	clients map[string]*providerClients
+}
+
+// NewProviderRegistry creates a ProviderRegistry reading the ProviderConfigs and their Secrets with c
+func NewProviderRegistry(c client.Client) *ProviderRegistry {
+	return &ProviderRegistry{
+		Client: c,
+		NewTokenManager: func(keycloakURL, realm string) arubaClient.ITokenManager {
+			return arubaClient.NewTokenManager(keycloakURL, realm, "", "", nil)
+		},
+		NewVaultClient: func(vault *v1alpha1.VaultCredentials, roleID, secretID string) (*arubaClient.AppRoleClient, error) {
+			cli, err := arubaClient.NewVaultClient(vault.Address)
+			if err != nil {
+				return nil, err
+			}
+			return arubaClient.NewAppRoleClient(vault.Namespace, vault.RolePath, roleID, secretID, vault.KVMount, cli)
+		},
+		clients: map[string]*providerClients{},
+	}
+}
+
+// Connect authenticates with the named ProviderConfig for tenant and returns a copy of ctx
+// whose API requests are sent to its gateway
+func (p *ProviderRegistry) Connect(ctx context.Context, name, tenant string) (context.Context, error) {
+	pc := &v1alpha1.ProviderConfig{}
+	if err := p.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		return ctx, fmt.Errorf("failed to get ProviderConfig %s: %w", name, err)
+	}
+
+	token, err := p.token(ctx, pc, tenant, true)
+	if err != nil {
+		return ctx, err
+	}
+	return arubaClient.WithConnection(ctx, arubaClient.Connection{APIGateway: pc.Spec.APIGateway, Token: token}), nil
+}
+
+// Check authenticates with pc without using the cached tokens. Vault credentials are
+// per tenant, so only the Vault login is checked for them.
+func (p *ProviderRegistry) Check(ctx context.Context, pc *v1alpha1.ProviderConfig) error {
+	if pc.Spec.Credentials.Source == v1alpha1.CredentialSourceVault {
+		_, err := p.clientsOf(ctx, pc)
+		return err
+	}
+	_, err := p.token(ctx, pc, "", false)
+	return err
+}
+
+// Forget closes and drops the clients of the named ProviderConfig
+func (p *ProviderRegistry) Forget(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if clients, ok := p.clients[name]; ok {
+		if clients.vault != nil {
+			clients.vault.Close()
+		}
+		delete(p.clients, name)
+	}
+}
+
+// token returns an access token of pc for tenant, reading the credentials again
+// when no valid token is cached
+func (p *ProviderRegistry) token(ctx context.Context, pc *v1alpha1.ProviderConfig, tenant string, checkCache bool) (string, error) {
+	clients, err := p.clientsOf(ctx, pc)
+	if err != nil {
+		return "", err
+	}
+
+	clients.mu.Lock()
+	defer clients.mu.Unlock()
+
+	if checkCache {
+		if token := clients.tokens.GetActiveToken(tenant); token != "" {
+			return token, nil
+		}
+	}
+
+	clientID, clientSecret, err := p.credentials(ctx, pc, clients, tenant)
+	if err != nil {
+		return "", err
+	}
+	clients.tokens.SetClientIdAndSecret(clientID, clientSecret)
+
+	token, err := clients.tokens.GetAccessToken(false, tenant)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate with ProviderConfig %s: %w", pc.Name, err)
+	}
+	return token, nil
+}
+
+// credentials returns the client ID and secret of pc for tenant
+func (p *ProviderRegistry) credentials(ctx context.Context, pc *v1alpha1.ProviderConfig, clients *providerClients, tenant string) (string, string, error) {
+	creds := pc.Spec.Credentials
+	switch creds.Source {
+	case v1alpha1.CredentialSourceStatic:
+		if creds.Static == nil {
+			return "", "", fmt.Errorf("ProviderConfig %s has no static credentials", pc.Name)
+		}
+		return creds.Static.ClientID, creds.Static.ClientSecret, nil
+	case v1alpha1.CredentialSourceSecret:
+		ref := creds.SecretRef
+		if ref == nil {
+			return "", "", fmt.Errorf("ProviderConfig %s has no secretRef", pc.Name)
+		}
+		idKey, secretKey := ref.ClientIDKey, ref.ClientSecretKey
+		if idKey == "" {
+			idKey = "client-id"
+		}
+		if secretKey == "" {
+			secretKey = "client-secret"
+		}
+		data, err := p.secretData(ctx, ref.Name, ref.Namespace)
+		if err != nil {
+			return "", "", err
+		}
+		return string(data[idKey]), string(data[secretKey]), nil
+	case v1alpha1.CredentialSourceVault:
+		if clients.vault == nil {
+			return "", "", fmt.Errorf("ProviderConfig %s has no vault configuration", pc.Name)
+		}
+		if tenant == "" {
+			return "", "", fmt.Errorf("tenant is required to read the credentials of ProviderConfig %s from Vault", pc.Name)
+		}
+		apiKeyData, err := clients.vault.GetSecret(ctx, tenant)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get API key of tenant %s from Vault: %w", tenant, err)
+		}
+		clientID, _ := apiKeyData["client-id"].(string)
+		clientSecret, _ := apiKeyData["client-secret"].(string)
+		return clientID, clientSecret, nil
+	}
+	return "", "", fmt.Errorf("ProviderConfig %s has an unknown credential source %q", pc.Name, creds.Source)
+}
+
+// clientsOf returns the clients of pc, building them when pc is new or has changed
+func (p *ProviderRegistry) clientsOf(ctx context.Context, pc *v1alpha1.ProviderConfig) (*providerClients, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if clients, ok := p.clients[pc.Name]; ok {
+		if clients.generation == pc.Generation {
+			return clients, nil
+		}
+		if clients.vault != nil {
+			clients.vault.Close()
+		}
+		delete(p.clients, pc.Name)
+	}
+
+	clients := &providerClients{
+		generation: pc.Generation,
+		tokens:     p.NewTokenManager(pc.Spec.KeycloakURL, pc.Spec.Realm),
+	}
+	if vault := pc.Spec.Credentials.Vault; pc.Spec.Credentials.Source == v1alpha1.CredentialSourceVault && vault != nil {
+		roleID, err := p.secretKey(ctx, vault.RoleIDRef)
+		if err != nil {
+			return nil, err
+		}
+		secretID, err := p.secretKey(ctx, vault.SecretIDRef)
+		if err != nil {
+			return nil, err
+		}
+		clients.vault, err = p.NewVaultClient(vault, roleID, secretID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to log in to Vault for ProviderConfig %s: %w", pc.Name, err)
+		}
+	}
+	ctrl.Log.V(1).Info("Initialized ProviderConfig clients", "ProviderConfig", pc.Name, "Generation", pc.Generation)
+
+	p.clients[pc.Name] = clients
+	return clients, nil
+}
+
+// secretKey returns the value selected by sel
+func (p *ProviderRegistry) secretKey(ctx context.Context, sel v1alpha1.SecretKeySelector) (string, error) {
+	data, err := p.secretData(ctx, sel.Name, sel.Namespace)
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[sel.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in Secret %s/%s", sel.Key, sel.Namespace, sel.Name)
+	}
+	return string(value), nil
+}
+
+// secretData returns the data of the named Secret
+func (p *ProviderRegistry) secretData(ctx context.Context, name, namespace string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	if err := p.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s: %w", namespace, name, err)
+	}
+	return secret.Data, nil
+}
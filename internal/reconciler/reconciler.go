@@ -7,11 +7,16 @@ import (
 	"net/http"
 	"os"
 	"slices"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -20,6 +25,7 @@ import (
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
@@ -27,6 +33,10 @@ const (
 	requeueAfter = 20 * time.Second
 	// maxPhaseTimeout defines the maximum time a resource can remain in a non-final phase
 	maxPhaseTimeout = 5 * time.Minute
+	// remoteCheckInterval defines how often a created resource is checked against the remote system
+	remoteCheckInterval = 5 * time.Minute
+	// eventRecorderName is the component name used when recording events
+	eventRecorderName = "arubacloud-resource-operator"
 )
 
 // ResourceReconciler is an interface that must be implemented by all resource reconcilers
@@ -39,6 +49,8 @@ type ResourceReconciler interface {
 	Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error)
 }
 
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
 // Reconciler provides base functionality for all resource controllers
 type Reconciler struct {
 	client.Client
@@ -46,7 +58,16 @@ type Reconciler struct {
 	*arubaClient.HelperClient
 	*arubaClient.AppRoleClient
 	TokenManager   arubaClient.ITokenManager
+	Recorder       record.EventRecorder
 	VaultIsEnabled bool
+	// PropagatedLabels are the label keys rendered as "key=value" tags on the remote resources
+	PropagatedLabels []string
+	// ClusterID is added as an operator-managed tag to the remote resources when set
+	ClusterID string
+	// Providers authenticates the resources referencing a ProviderConfig
+	Providers *ProviderRegistry
+	// Tenants restricts the tenants each namespace may use
+	Tenants *tenancy.Policy
 }
 
 // ReconcilerConfig holds configuration for setting up Reconciler
@@ -64,6 +85,12 @@ type ReconcilerConfig struct {
 	RoleSecret     string
 	KVMount        string
 	HTTPClient     *http.Client
+	// PropagatedLabels are the label keys rendered as tags on the remote resources
+	PropagatedLabels []string
+	// ClusterID identifies this cluster in the operator-managed tags
+	ClusterID string
+	// TenantIsolation restricts the tenants of each namespace to the ones bound by TenantBindings
+	TenantIsolation bool
 }
 
 // NewReconciler creates a new base reconciler
@@ -91,13 +118,44 @@ func NewReconciler(mgr ctrl.Manager, cfg ReconcilerConfig) *Reconciler {
 	}
 
 	return &Reconciler{
-		Client:         mgr.GetClient(),
-		Scheme:         mgr.GetScheme(),
-		HelperClient:   helperClientInstance,
-		AppRoleClient:  vaultAuth,
-		TokenManager:   oauthClient,
-		VaultIsEnabled: cfg.VaultIsEnabled,
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		HelperClient:     helperClientInstance,
+		AppRoleClient:    vaultAuth,
+		TokenManager:     oauthClient,
+		Recorder:         mgr.GetEventRecorderFor(eventRecorderName),
+		VaultIsEnabled:   cfg.VaultIsEnabled,
+		PropagatedLabels: cfg.PropagatedLabels,
+		ClusterID:        cfg.ClusterID,
+		Providers:        NewProviderRegistry(mgr.GetClient()),
+		Tenants:          tenancy.NewPolicy(mgr, eventRecorderName, cfg.TenantIsolation),
+	}
+}
+
+// resolveTenant returns the tenant of the resource, falling back to the tenant
+// of the referenced Project for resources that don't specify one (v1beta1 only
+// sets the tenant on Projects). A Project of another namespace must grant the reference.
+func (r *Reconciler) resolveTenant(ctx context.Context, obj client.Object, tenant *string) string {
+	if tenant != nil && *tenant != "" {
+		return *tenant
+	}
+	for _, ref := range ReferencesOf(obj) {
+		if ref.Kind != "Project" {
+			continue
+		}
+		if err := r.checkReferenceGrant(ctx, obj, ref.Kind, ref.Name, ref.Namespace); err != nil {
+			ctrl.Log.V(1).Info("Unable to resolve tenant from the referenced Project", "error", err.Error())
+			return ""
+		}
+		project := &v1alpha1.Project{}
+		key := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+		if err := r.Get(ctx, key, project); err != nil {
+			ctrl.Log.V(1).Info("Unable to resolve tenant from the referenced Project", "Project", key, "error", err.Error())
+			return ""
+		}
+		return project.Spec.Tenant
 	}
+	return ""
 }
 
 // Reconcile handles the common reconciliation logic for all resources
@@ -116,20 +174,28 @@ func (r *Reconciler) Reconcile(
 		}
 		return ctrl.Result{}, err
 	}
-
-	if tenant == nil || *tenant == "" {
-		if r.VaultIsEnabled {
-			errMsg := "Tenant ID is not specified in the resource spec"
-			ctrl.Log.Error(fmt.Errorf("%s", errMsg), "Cannot proceed without Tenant ID when Vault integration is enabled", "Resource", req.NamespacedName)
-			return ctrl.Result{}, fmt.Errorf("%s", errMsg)
-		} else {
-			ctrl.Log.V(1).Info("Vault integration is disabled; proceeding without Tenant ID")
+	ctx = withReferrer(ctx, obj)
+	ctx = withValidatedIDs(ctx, status)
+
+	tenantID := r.resolveTenant(ctx, obj, tenant)
+	if err := r.Tenants.Authorize(ctx, obj, tenantID); err != nil {
+		var notBound *tenancy.NotBoundError
+		if errors.As(err, &notBound) {
+			return r.deny(ctx, obj, status, tenancy.ReasonTenantNotBound, notBound)
 		}
+		return ctrl.Result{}, err
 	}
-
-	ctrl.Log.V(1).Info("Setting tenant in Aruba client", "TenantID", tenant)
-	if err := r.Authenticate(ctx, *tenant); err != nil {
-		ctrl.Log.Error(err, "Failed to authenticate Aruba client", "tenantID", tenant)
+	if ref := ProviderConfigRefOf(obj); ref != nil {
+		ctx, err = r.connectProvider(ctx, obj, ref.Name, tenantID)
+		if err != nil {
+			var notAllowed *tenancy.ProviderConfigNotAllowedError
+			if errors.As(err, &notAllowed) {
+				return r.deny(ctx, obj, status, tenancy.ReasonProviderConfigNotAllowed, notAllowed)
+			}
+			ctrl.Log.Error(err, "Failed to authenticate with ProviderConfig", "ProviderConfig", ref.Name, "tenantID", tenantID)
+			return ctrl.Result{}, err
+		}
+	} else if err := r.authenticateDefault(ctx, req, tenantID); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -164,12 +230,83 @@ func (r *Reconciler) Reconcile(
 		reconcileResult, reconcileError = ctrl.Result{}, nil
 	case v1alpha1.ResourcePhaseFailed:
 		// Resource is in failed state, nothing to do unless spec changes
-		reconcileResult, reconcileError = ctrl.Result{}, nil
+		reconcileResult, reconcileError = r.HandleFailed(ctx, obj, status)
 	}
 
 	return reconcileResult, reconcileError
 }
 
+// deny reports on obj that its namespace may not use its tenant or ProviderConfig, without
+// authenticating with them. The namespace is checked again periodically.
+func (r *Reconciler) deny(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, reason string, err error) (ctrl.Result, error) {
+	message := err.Error()
+	status.Message = message
+	status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeSynchronized, metav1.ConditionFalse, reason, message)
+	if err := r.Client.Status().Update(ctx, obj); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: remoteCheckInterval}, nil
+}
+
+// connectProvider authenticates with the named ProviderConfig and returns a copy of ctx
+// whose API requests use its gateway and token. It returns a *tenancy.ProviderConfigNotAllowedError
+// when the ProviderConfig doesn't allow the namespace of obj.
+func (r *Reconciler) connectProvider(ctx context.Context, obj client.Object, name, tenantID string) (context.Context, error) {
+	if r.Providers == nil {
+		return ctx, fmt.Errorf("ProviderConfigs are not enabled")
+	}
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		r.RecordEvent(obj, corev1.EventTypeWarning, "ProviderConfigError", err.Error())
+		return ctx, fmt.Errorf("failed to get ProviderConfig %s: %w", name, err)
+	}
+	if err := tenancy.AuthorizeProviderConfig(ctx, r.Client, pc, obj.GetNamespace()); err != nil {
+		var notAllowed *tenancy.ProviderConfigNotAllowedError
+		if errors.As(err, &notAllowed) {
+			r.RecordEvent(obj, corev1.EventTypeWarning, tenancy.ReasonProviderConfigNotAllowed, err.Error())
+		}
+		return ctx, err
+	}
+	providerCtx, err := r.Providers.Connect(ctx, name, tenantID)
+	if err != nil {
+		r.RecordEvent(obj, corev1.EventTypeWarning, "ProviderConfigError", err.Error())
+		return ctx, err
+	}
+	return providerCtx, nil
+}
+
+// authenticateDefault authenticates with the API gateway and credentials of the operator configuration
+func (r *Reconciler) authenticateDefault(ctx context.Context, req ctrl.Request, tenantID string) error {
+	if tenantID == "" {
+		if r.VaultIsEnabled {
+			errMsg := "Tenant ID is not specified in the resource spec"
+			ctrl.Log.Error(fmt.Errorf("%s", errMsg), "Cannot proceed without Tenant ID when Vault integration is enabled", "Resource", req.NamespacedName)
+			return fmt.Errorf("%s", errMsg)
+		} else {
+			ctrl.Log.V(1).Info("Vault integration is disabled; proceeding without Tenant ID")
+		}
+	}
+
+	ctrl.Log.V(1).Info("Setting tenant in Aruba client", "TenantID", tenantID)
+	if err := r.Authenticate(ctx, tenantID); err != nil {
+		ctrl.Log.Error(err, "Failed to authenticate Aruba client", "tenantID", tenantID)
+		return err
+	}
+
+	return nil
+}
+
+// waitingReasons are the reasons of the Synchronized condition of resources waiting on a change
+// outside of the operator, which never time out
+var waitingReasons = []string{
+	// The API refused to move cloud servers to the new keypair of a rotation
+	"KeyRotationBlocked",
+	// The deletion of a block storage waits for the deletion of its snapshots
+	"DependentSnapshots",
+	// A reference to another namespace waits for a ReferenceGrant allowing it
+	"ReferenceNotGranted",
+}
+
 // HandlePhaseTimeout transitions the resource to failed state due to timeout
 func (r *Reconciler) HandlePhaseTimeout(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (bool, ctrl.Result, error) {
 	isTimeout := false
@@ -189,6 +326,11 @@ func (r *Reconciler) HandlePhaseTimeout(ctx context.Context, obj client.Object,
 		return isTimeout, ctrl.Result{}, nil
 	}
 
+	// Waiting on a change outside of the operator can take any time
+	if cond := meta.FindStatusCondition(status.Conditions, v1alpha1.ConditionTypeSynchronized); cond != nil && slices.Contains(waitingReasons, cond.Reason) {
+		return isTimeout, ctrl.Result{}, nil
+	}
+
 	elapsed := time.Since(status.PhaseStartTime.Time)
 	isTimeout = elapsed > maxPhaseTimeout
 
@@ -214,6 +356,29 @@ func (r *Reconciler) HandlePhaseTimeout(ctx context.Context, obj client.Object,
 	return isTimeout, nextCtrlResult, err
 }
 
+// HandleFailed retries a failed resource once its spec has been changed
+func (r *Reconciler) HandleFailed(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	if status.ObservedGeneration == obj.GetGeneration() {
+		return ctrl.Result{}, nil
+	}
+
+	nextPhase := v1alpha1.ResourcePhaseUpdating
+	if status.ResourceID == "" {
+		nextPhase = v1alpha1.ResourcePhaseCreating
+	}
+
+	return r.Next(
+		ctx,
+		obj,
+		status,
+		nextPhase,
+		metav1.ConditionFalse,
+		"SpecChanged",
+		"Spec changed after failure, retrying",
+		true,
+	)
+}
+
 // HandleToDelete checks if resource should transition to deleting phase
 func (r *Reconciler) HandleToDelete(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (bool, ctrl.Result, error) {
 	shouldBeDeleted := status.Phase != v1alpha1.ResourcePhaseDeleting &&
@@ -282,6 +447,9 @@ func (r *Reconciler) Next(
 	resStatus.Phase = nextPhase
 	resStatus.Message = message
 	resStatus.ObservedGeneration = obj.GetGeneration()
+	if condStatus == metav1.ConditionTrue {
+		resStatus.LastError = nil
+	}
 	resStatus.Conditions = util.UpdateConditions(resStatus.Conditions, v1alpha1.ConditionTypeSynchronized, condStatus, reason, message)
 
 	if err := r.Client.Status().Update(ctx, obj); err != nil {
@@ -293,12 +461,41 @@ func (r *Reconciler) Next(
 	return ctrl.Result{Requeue: requeue, RequeueAfter: requeueAfter}, nil
 }
 
+// InvalidSpecError is returned when the spec of a resource can't be applied until it is fixed
+type InvalidSpecError struct {
+	Field   string
+	Message string
+}
+
+func (e *InvalidSpecError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
 // NextToFailedOnApiError handles API errors with proper 4xx/5xx logic and condition management
 func (r *Reconciler) NextToFailedOnApiError(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, err error) (ctrl.Result, error) {
 	var apiErr *arubaClient.ApiError
 	if errors.As(err, &apiErr) {
 		statusCode := apiErr.Status
 		message := apiErr.Error()
+		status.LastError = newLastError(apiErr)
+
+		// Handle validation errors - the request can't succeed until the spec is fixed
+		if apiErr.IsValidationError() {
+			message = apiErr.Title
+			if fieldErrors := apiErr.FieldErrors(); fieldErrors != "" {
+				message = fmt.Sprintf("%s: %s", message, fieldErrors)
+			}
+			return r.Next(
+				ctx,
+				obj,
+				status,
+				v1alpha1.ResourcePhaseFailed,
+				metav1.ConditionFalse,
+				"ValidationError",
+				fmt.Sprintf("Invalid request (HTTP %d): %s", statusCode, message),
+				false,
+			)
+		}
 
 		// Handle notReady/invalidStatus errors during transitioning phases - should retry
 		if apiErr.IsInvalidStatus() {
@@ -343,10 +540,90 @@ func (r *Reconciler) NextToFailedOnApiError(ctx context.Context, obj client.Obje
 		}
 	}
 
+	// The request can't succeed until the spec is fixed
+	var invalidSpec *InvalidSpecError
+	if errors.As(err, &invalidSpec) {
+		return r.Next(
+			ctx,
+			obj,
+			status,
+			v1alpha1.ResourcePhaseFailed,
+			metav1.ConditionFalse,
+			"InvalidSpec",
+			err.Error(),
+			false,
+		)
+	}
+
+	// A missing ReferenceGrant can be added later, keep retrying
+	var notGranted *ReferenceNotGrantedError
+	if errors.As(err, &notGranted) {
+		r.RecordEvent(obj, corev1.EventTypeWarning, "ReferenceNotGranted", err.Error())
+		return r.Next(
+			ctx,
+			obj,
+			status,
+			status.Phase,
+			metav1.ConditionFalse,
+			"ReferenceNotGranted",
+			err.Error(),
+			true,
+		)
+	}
+
 	// Unknown error, treat as retriable
 	return r.NextToFailedOnReconcileError(ctx, obj, status, err)
 }
 
+// newLastError converts an API error into its status representation
+func newLastError(apiErr *arubaClient.ApiError) *v1alpha1.LastError {
+	lastError := &v1alpha1.LastError{
+		HTTPStatus:   apiErr.Status,
+		Type:         apiErr.Type,
+		Title:        apiErr.Title,
+		TraceID:      apiErr.TraceId,
+		ParentID:     apiErr.ParentId,
+		ObservedTime: metav1.Now(),
+	}
+	for _, detail := range apiErr.Errors {
+		lastError.FieldErrors = append(lastError.FieldErrors, v1alpha1.FieldError{
+			Field:   specFieldPath(detail.Field),
+			Message: detail.Message,
+		})
+	}
+	return lastError
+}
+
+// specFieldPath maps a field path of the API request body to the matching path of the resource spec.
+// The properties and the metadata tags and location of the request are spec fields of the resource,
+// while its metadata name is the name of the resource.
+func specFieldPath(field string) string {
+	if field == "" {
+		return ""
+	}
+	section, rest, _ := strings.Cut(field, ".")
+	switch {
+	case !strings.EqualFold(section, "properties") && !strings.EqualFold(section, "metadata"):
+		rest = field
+	case rest == "":
+		return "spec"
+	case strings.EqualFold(section, "metadata") && strings.EqualFold(rest, "name"):
+		return "metadata.name"
+	}
+	return "spec." + lowerCamelPath(rest)
+}
+
+// lowerCamelPath lowercases the first letter of every segment of a field path
+func lowerCamelPath(field string) string {
+	segments := strings.Split(field, ".")
+	for i, segment := range segments {
+		if segment != "" {
+			segments[i] = strings.ToLower(segment[:1]) + segment[1:]
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
 // NextToFailedOnReconcileError handles generic reconcile errors
 func (r *Reconciler) NextToFailedOnReconcileError(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, err error) (ctrl.Result, error) {
 	return r.Next(
@@ -372,6 +649,11 @@ func (r *Reconciler) InitializeResource(ctx context.Context, obj client.Object,
 		}
 	}
 
+	// The defaulting webhook can't write the status, it lists the defaulted fields in an annotation
+	if defaulted := obj.GetAnnotations()[v1alpha1.DefaultedFieldsAnnotation]; defaulted != "" {
+		status.DefaultedFields = strings.Split(defaulted, ",")
+	}
+
 	return r.Next(ctx, obj, status, v1alpha1.ResourcePhaseCreating, metav1.ConditionFalse, "Initialized", "Resource initialized successfully", true)
 }
 
@@ -403,6 +685,7 @@ func (r *Reconciler) HandleCreating(ctx context.Context, obj client.Object, stat
 
 	// Update status with resource ID
 	status.ResourceID = resourceID
+	status.AppliedTags = r.ResourceTags(obj)
 
 	if state == "InCreation" || state == "Provisioning" {
 		return r.Next(
@@ -435,6 +718,7 @@ func (r *Reconciler) HandleUpdating(ctx context.Context, obj client.Object, stat
 	if err != nil {
 		return r.NextToFailedOnApiError(ctx, obj, status, err)
 	}
+	status.AppliedTags = r.ResourceTags(obj)
 
 	return r.Next(
 		ctx,
@@ -515,8 +799,118 @@ func (r *Reconciler) CheckForUpdates(ctx context.Context, obj client.Object, sta
 		)
 	}
 
+	// Label and operator configuration changes don't bump the generation
+	if r.tagsChanged(obj, status) {
+		phaseLogger.Info("resource needs update - tags changed",
+			"appliedTags", status.AppliedTags,
+			"tags", r.ResourceTags(obj))
+
+		return r.Next(
+			ctx,
+			obj,
+			status,
+			v1alpha1.ResourcePhaseUpdating,
+			metav1.ConditionFalse,
+			"TagsChanged",
+			"Resource tags update initiated",
+			true,
+		)
+	}
+
 	phaseLogger.Info("resource is up to date")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: remoteCheckInterval}, nil
+}
+
+// HandleRemoteMissing checks that a created resource still exists remotely and applies
+// the remote deletion policy when it was deleted outside of the operator
+func (r *Reconciler) HandleRemoteMissing(
+	ctx context.Context,
+	obj client.Object,
+	status *v1alpha1.ResourceStatus,
+	policy v1alpha1.RemoteDeletionPolicy,
+	getFunc func(context.Context) error,
+) (bool, ctrl.Result, error) {
+	if status.ResourceID == "" {
+		return false, ctrl.Result{}, nil
+	}
+
+	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+
+	// getFunc refreshes the remote outputs in the status of obj when the resource is found
+	before := obj.DeepCopyObject()
+	err := getFunc(ctx)
+	var apiErr *arubaClient.ApiError
+	if err != nil && (!errors.As(err, &apiErr) || !apiErr.IsNotFound()) {
+		// The remote check is best effort, other failures must not block the steady state
+		phaseLogger.Error(err, "failed to check remote resource")
+		return false, ctrl.Result{}, nil
+	}
+
+	if err == nil {
+		if meta.IsStatusConditionTrue(status.Conditions, v1alpha1.ConditionTypeRemoteMissing) {
+			status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeRemoteMissing, metav1.ConditionFalse, "RemoteFound", "Remote resource exists")
+		}
+		if equality.Semantic.DeepEqual(before, obj) {
+			return false, ctrl.Result{}, nil
+		}
+		if err := r.Client.Status().Update(ctx, obj); err != nil {
+			phaseLogger.Error(err, "failed to update status")
+			return true, ctrl.Result{}, err
+		}
+		return false, ctrl.Result{}, nil
+	}
+
+	alreadyReported := meta.IsStatusConditionTrue(status.Conditions, v1alpha1.ConditionTypeRemoteMissing)
+	message := fmt.Sprintf("Remote resource %s was deleted outside of the operator", status.ResourceID)
+	status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeRemoteMissing, metav1.ConditionTrue, "RemoteNotFound", message)
+
+	if !alreadyReported {
+		r.RecordEvent(obj, corev1.EventTypeWarning, "RemoteMissing", message)
+		if err := r.NotifyDependents(ctx, obj, message); err != nil {
+			phaseLogger.Error(err, "failed to notify dependent resources")
+		}
+	}
+
+	if policy == v1alpha1.RemoteDeletionPolicyRecreate {
+		status.ResourceID = ""
+		nextCtrlResult, err := r.Next(
+			ctx,
+			obj,
+			status,
+			v1alpha1.ResourcePhaseCreating,
+			metav1.ConditionFalse,
+			"RemoteMissing",
+			fmt.Sprintf("%s, recreating it", message),
+			true,
+		)
+		return true, nextCtrlResult, err
+	}
+
+	// The status is only written when the resource goes missing, so that spec changes made
+	// meanwhile are not marked as observed without being applied
+	if !alreadyReported {
+		if _, err := r.Next(
+			ctx,
+			obj,
+			status,
+			v1alpha1.ResourcePhaseCreated,
+			metav1.ConditionFalse,
+			"RemoteMissing",
+			message,
+			false,
+		); err != nil {
+			return true, ctrl.Result{}, err
+		}
+	}
+	return true, ctrl.Result{RequeueAfter: remoteCheckInterval}, nil
+}
+
+// RecordEvent records a Kubernetes event on obj when an event recorder is configured
+func (r *Reconciler) RecordEvent(obj runtime.Object, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(obj, eventType, reason, message)
 }
 
 // Authenticate authenticates the client with the given tenant
@@ -557,8 +951,13 @@ func (r *Reconciler) Authenticate(ctx context.Context, tenantId string) error {
 	return nil
 }
 
-// Helper methods for getting resource references
+// Helper methods for getting resource references. A reference to another namespace
+// must be allowed by a ReferenceGrant of that namespace.
 func (r *Reconciler) GetProjectID(ctx context.Context, name string, namespace string) (string, error) {
+	if err := r.checkReferenceGrant(ctx, referrerOf(ctx), "Project", name, namespace); err != nil {
+		return "", err
+	}
+
 	project := &v1alpha1.Project{}
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      name,
@@ -578,6 +977,10 @@ func (r *Reconciler) GetProjectID(ctx context.Context, name string, namespace st
 }
 
 func (r *Reconciler) GetElasticIpID(ctx context.Context, name string, namespace string) (string, error) {
+	if err := r.checkReferenceGrant(ctx, referrerOf(ctx), "ElasticIp", name, namespace); err != nil {
+		return "", err
+	}
+
 	elasticIp := &v1alpha1.ElasticIp{}
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      name,
@@ -597,6 +1000,10 @@ func (r *Reconciler) GetElasticIpID(ctx context.Context, name string, namespace
 }
 
 func (r *Reconciler) GetSubnetID(ctx context.Context, name string, namespace string) (string, error) {
+	if err := r.checkReferenceGrant(ctx, referrerOf(ctx), "Subnet", name, namespace); err != nil {
+		return "", err
+	}
+
 	subnet := &v1alpha1.Subnet{}
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      name,
@@ -616,6 +1023,10 @@ func (r *Reconciler) GetSubnetID(ctx context.Context, name string, namespace str
 }
 
 func (r *Reconciler) GetSecurityGroupID(ctx context.Context, name string, namespace string) (string, error) {
+	if err := r.checkReferenceGrant(ctx, referrerOf(ctx), "SecurityGroup", name, namespace); err != nil {
+		return "", err
+	}
+
 	securityGroup := &v1alpha1.SecurityGroup{}
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      name,
@@ -635,6 +1046,10 @@ func (r *Reconciler) GetSecurityGroupID(ctx context.Context, name string, namesp
 }
 
 func (r *Reconciler) GetBlockStorageID(ctx context.Context, name string, namespace string) (string, error) {
+	if err := r.checkReferenceGrant(ctx, referrerOf(ctx), "BlockStorage", name, namespace); err != nil {
+		return "", err
+	}
+
 	blockStorage := &v1alpha1.BlockStorage{}
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      name,
@@ -653,7 +1068,34 @@ func (r *Reconciler) GetBlockStorageID(ctx context.Context, name string, namespa
 	return blockStorage.Status.ResourceID, nil
 }
 
+func (r *Reconciler) GetBlockStorageSnapshotID(ctx context.Context, name string, namespace string) (string, error) {
+	if err := r.checkReferenceGrant(ctx, referrerOf(ctx), "BlockStorageSnapshot", name, namespace); err != nil {
+		return "", err
+	}
+
+	snapshot := &v1alpha1.BlockStorageSnapshot{}
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}, snapshot)
+	if err != nil {
+		return "", fmt.Errorf("failed to get referenced BlockStorageSnapshot %s/%s: %w",
+			namespace, name, err)
+	}
+
+	if snapshot.Status.ResourceID == "" {
+		return "", fmt.Errorf("referenced BlockStorageSnapshot %s/%s does not have a snapshot ID yet",
+			namespace, name)
+	}
+
+	return snapshot.Status.ResourceID, nil
+}
+
 func (r *Reconciler) GetVpcID(ctx context.Context, name string, namespace string) (string, error) {
+	if err := r.checkReferenceGrant(ctx, referrerOf(ctx), "Vpc", name, namespace); err != nil {
+		return "", err
+	}
+
 	vpc := &v1alpha1.Vpc{}
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      name,
@@ -673,6 +1115,10 @@ func (r *Reconciler) GetVpcID(ctx context.Context, name string, namespace string
 }
 
 func (r *Reconciler) GetKeyPairID(ctx context.Context, name string, namespace string) (string, error) {
+	if err := r.checkReferenceGrant(ctx, referrerOf(ctx), "KeyPair", name, namespace); err != nil {
+		return "", err
+	}
+
 	keyPair := &v1alpha1.KeyPair{}
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      name,
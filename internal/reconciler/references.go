@@ -0,0 +1,140 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// validatedIDsKey is the context key of the status caching the validated remote IDs
+type validatedIDsKey struct{}
+
+// withValidatedIDs returns a copy of ctx recording status as the cache of the validated remote IDs
+func withValidatedIDs(ctx context.Context, status *v1alpha1.ResourceStatus) context.Context {
+	return context.WithValue(ctx, validatedIDsKey{}, status)
+}
+
+// validatedIDsOf returns the status recorded in ctx by withValidatedIDs, or nil
+func validatedIDsOf(ctx context.Context) *v1alpha1.ResourceStatus {
+	status, _ := ctx.Value(validatedIDsKey{}).(*v1alpha1.ResourceStatus)
+	return status
+}
+
+// validateRemoteID checks with get that the remote resource of kind with id exists. Validated
+// IDs are recorded in the status of the resource being reconciled, so they are checked once.
+func (r *Reconciler) validateRemoteID(ctx context.Context, kind, id string, get func(ctx context.Context) error) error {
+	key := kind + "/" + id
+	status := validatedIDsOf(ctx)
+	if status != nil && slices.Contains(status.ValidatedReferenceIDs, key) {
+		return nil
+	}
+
+	if err := get(ctx); err != nil {
+		return fmt.Errorf("failed to validate the referenced %s ID %s: %w", kind, id, err)
+	}
+
+	if status != nil {
+		status.ValidatedReferenceIDs = append(status.ValidatedReferenceIDs, key)
+	}
+	return nil
+}
+
+// referenceNamespace returns the namespace of ref, defaulted to the namespace of the resource being reconciled
+func referenceNamespace(ctx context.Context, ref v1alpha1.ResourceReference) string {
+	if ref.Namespace == "" {
+		if referrer := referrerOf(ctx); referrer != nil {
+			return referrer.GetNamespace()
+		}
+	}
+	return ref.Namespace
+}
+
+// ProjectIDOf returns the Aruba ID of the Project referenced by ref
+func (r *Reconciler) ProjectIDOf(ctx context.Context, ref v1alpha1.ResourceReference) (string, error) {
+	if ref.ID == "" {
+		return r.GetProjectID(ctx, ref.Name, referenceNamespace(ctx, ref))
+	}
+	return ref.ID, r.validateRemoteID(ctx, "Project", ref.ID, func(ctx context.Context) error {
+		_, err := r.GetProject(ctx, ref.ID)
+		return err
+	})
+}
+
+// VpcIDOf returns the Aruba ID of the Vpc referenced by ref in the project with projectID
+func (r *Reconciler) VpcIDOf(ctx context.Context, ref v1alpha1.ResourceReference, projectID string) (string, error) {
+	if ref.ID == "" {
+		return r.GetVpcID(ctx, ref.Name, referenceNamespace(ctx, ref))
+	}
+	return ref.ID, r.validateRemoteID(ctx, "Vpc", ref.ID, func(ctx context.Context) error {
+		_, err := r.GetVpc(ctx, projectID, ref.ID)
+		return err
+	})
+}
+
+// SubnetIDOf returns the Aruba ID of the Subnet referenced by ref in the vpc with vpcID
+func (r *Reconciler) SubnetIDOf(ctx context.Context, ref v1alpha1.ResourceReference, projectID, vpcID string) (string, error) {
+	if ref.ID == "" {
+		return r.GetSubnetID(ctx, ref.Name, referenceNamespace(ctx, ref))
+	}
+	return ref.ID, r.validateRemoteID(ctx, "Subnet", ref.ID, func(ctx context.Context) error {
+		_, err := r.GetSubnet(ctx, projectID, vpcID, ref.ID)
+		return err
+	})
+}
+
+// SecurityGroupIDOf returns the Aruba ID of the SecurityGroup referenced by ref in the vpc with vpcID
+func (r *Reconciler) SecurityGroupIDOf(ctx context.Context, ref v1alpha1.ResourceReference, projectID, vpcID string) (string, error) {
+	if ref.ID == "" {
+		return r.GetSecurityGroupID(ctx, ref.Name, referenceNamespace(ctx, ref))
+	}
+	return ref.ID, r.validateRemoteID(ctx, "SecurityGroup", ref.ID, func(ctx context.Context) error {
+		_, err := r.GetSecurityGroup(ctx, projectID, vpcID, ref.ID)
+		return err
+	})
+}
+
+// BlockStorageIDOf returns the Aruba ID of the BlockStorage referenced by ref in the project with projectID
+func (r *Reconciler) BlockStorageIDOf(ctx context.Context, ref v1alpha1.ResourceReference, projectID string) (string, error) {
+	if ref.ID == "" {
+		return r.GetBlockStorageID(ctx, ref.Name, referenceNamespace(ctx, ref))
+	}
+	return ref.ID, r.validateRemoteID(ctx, "BlockStorage", ref.ID, func(ctx context.Context) error {
+		_, err := r.GetBlockStorage(ctx, projectID, ref.ID)
+		return err
+	})
+}
+
+// SnapshotIDOf returns the Aruba ID of the BlockStorageSnapshot referenced by ref in the project with projectID
+func (r *Reconciler) SnapshotIDOf(ctx context.Context, ref v1alpha1.ResourceReference, projectID string) (string, error) {
+	if ref.ID == "" {
+		return r.GetBlockStorageSnapshotID(ctx, ref.Name, referenceNamespace(ctx, ref))
+	}
+	return ref.ID, r.validateRemoteID(ctx, "BlockStorageSnapshot", ref.ID, func(ctx context.Context) error {
+		_, err := r.GetSnapshot(ctx, projectID, ref.ID)
+		return err
+	})
+}
+
+// ElasticIpIDOf returns the Aruba ID of the ElasticIp referenced by ref in the project with projectID
+func (r *Reconciler) ElasticIpIDOf(ctx context.Context, ref v1alpha1.ResourceReference, projectID string) (string, error) {
+	if ref.ID == "" {
+		return r.GetElasticIpID(ctx, ref.Name, referenceNamespace(ctx, ref))
+	}
+	return ref.ID, r.validateRemoteID(ctx, "ElasticIp", ref.ID, func(ctx context.Context) error {
+		_, err := r.GetElasticIp(ctx, projectID, ref.ID)
+		return err
+	})
+}
+
+// KeyPairIDOf returns the Aruba ID of the KeyPair referenced by ref in the project with projectID
+func (r *Reconciler) KeyPairIDOf(ctx context.Context, ref v1alpha1.ResourceReference, projectID string) (string, error) {
+	if ref.ID == "" {
+		return r.GetKeyPairID(ctx, ref.Name, referenceNamespace(ctx, ref))
+	}
+	return ref.ID, r.validateRemoteID(ctx, "KeyPair", ref.ID, func(ctx context.Context) error {
+		_, err := r.GetKeyPair(ctx, projectID, ref.ID)
+		return err
+	})
+}
@@ -0,0 +1,79 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// remoteHTTPClient answers 200 for the paths ending with one of the found IDs and 404 otherwise
+type remoteHTTPClient struct {
+	found    []string
+	requests int
+}
+
+func (c *remoteHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests++
+	for _, id := range c.found {
+		if strings.HasSuffix(req.URL.Path, "/"+id) {
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+		}
+	}
+	return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(`{"title":"Not Found","status":404}`))}, nil
+}
+
+func TestVpcIDOf(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	vpc := &v1alpha1.Vpc{
+		ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "app"},
+		Status:     v1alpha1.VpcStatus{ResourceStatus: v1alpha1.ResourceStatus{ResourceID: "vpc-local"}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vpc).Build()
+	httpClient := &remoteHTTPClient{found: []string{"vpc-shared"}}
+	r := &Reconciler{
+		Client:       k8sClient,
+		Scheme:       scheme,
+		HelperClient: arubaClient.NewHelperClient(k8sClient, httpClient, "https://api.example.com"),
+	}
+	subnet := &v1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet", Namespace: "app"}}
+	ctx := withValidatedIDs(withReferrer(context.Background(), subnet), &subnet.Status.ResourceStatus)
+
+	t.Run("by name", func(t *testing.T) {
+		id, err := r.VpcIDOf(ctx, v1alpha1.ResourceReference{Name: "local"}, "project-1")
+		require.NoError(t, err)
+		assert.Equal(t, "vpc-local", id)
+		assert.Zero(t, httpClient.requests)
+	})
+
+	t.Run("by ID", func(t *testing.T) {
+		for range 2 {
+			id, err := r.VpcIDOf(ctx, v1alpha1.ResourceReference{ID: "vpc-shared"}, "project-1")
+			require.NoError(t, err)
+			assert.Equal(t, "vpc-shared", id)
+		}
+		assert.Equal(t, 1, httpClient.requests, "a validated ID is checked once")
+		assert.Equal(t, []string{"Vpc/vpc-shared"}, subnet.Status.ValidatedReferenceIDs)
+	})
+
+	t.Run("by missing ID", func(t *testing.T) {
+		_, err := r.VpcIDOf(ctx, v1alpha1.ResourceReference{ID: "vpc-missing"}, "project-1")
+		var apiErr *arubaClient.ApiError
+		require.True(t, errors.As(err, &apiErr), "expected ApiError, got %v", err)
+		assert.True(t, apiErr.IsNotFound())
+		assert.Equal(t, []string{"Vpc/vpc-shared"}, subnet.Status.ValidatedReferenceIDs)
+	})
+}
@@ -0,0 +1,107 @@
+package reconciler
+
+import (
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// ResizeStep is a step resizing a cloud server
+type ResizeStep string
+
+const (
+	// ResizeStepPowerOff powers the cloud server off, Aruba Cloud only resizes stopped cloud servers
+	ResizeStepPowerOff ResizeStep = "PowerOff"
+	// ResizeStepResize requests the new flavor
+	ResizeStepResize ResizeStep = "Resize"
+)
+
+// ResizePlan is the next step bringing a cloud server to its desired flavor
+type ResizePlan struct {
+	// Run is the step to run now, if any
+	Run ResizeStep
+	// Phase is the phase of the resize after this step, empty when no resize was ever run
+	Phase v1alpha1.CloudServerResizePhase
+	// Start reports whether this step starts a new resize
+	Start bool
+}
+
+// EffectiveFlavor returns the name of the flavor the cloud server runs with, empty while unknown
+func EffectiveFlavor(cloudServer *v1alpha1.CloudServer) string {
+	if cloudServer.Status.Flavor == nil {
+		return ""
+	}
+	return cloudServer.Status.Flavor.Name
+}
+
+// ObservedFlavor returns the flavor of a cloud server from the properties returned by the API.
+// The flavor details aren't always returned, the flavorName is used then, and applied, the flavor
+// last applied, when neither is.
+func ObservedFlavor(properties arubaClient.CloudServerProperties, applied *v1alpha1.CloudServerFlavor) *v1alpha1.CloudServerFlavor {
+	if flavor := properties.Flavor; flavor != nil && flavor.Name != "" {
+		return &v1alpha1.CloudServerFlavor{
+			Name:     flavor.Name,
+			Category: flavor.Category,
+			CPU:      flavor.CPU,
+			RAMGb:    flavor.RAM,
+			DiskGb:   flavor.HD,
+		}
+	}
+	if properties.FlavorName == "" {
+		return applied
+	}
+	if applied != nil && applied.Name == properties.FlavorName {
+		// The details known of the same flavor are kept
+		return applied
+	}
+	return &v1alpha1.CloudServerFlavor{Name: properties.FlavorName}
+}
+
+// ResizeInProgress reports whether phase is a resize in progress
+func ResizeInProgress(phase v1alpha1.CloudServerResizePhase) bool {
+	return phase == v1alpha1.CloudServerResizeStopping || phase == v1alpha1.CloudServerResizeResizing
+}
+
+// PlanResize returns the next step resizing cloudServer from its effective flavor to spec.flavorName.
+// A running cloud server is powered off first; bringing it back to its desired power state is left
+// to PlanPower once the resize is completed. No resize starts while the cloud server is transitioning
+// or running a power action, nor again to a flavor that was refused.
+func PlanResize(cloudServer *v1alpha1.CloudServer) ResizePlan {
+	resize := cloudServer.Status.Resize
+	effective := EffectiveFlavor(cloudServer)
+	observed := cloudServer.Status.PowerState
+
+	plan := ResizePlan{}
+	if resize != nil {
+		plan.Phase = resize.Phase
+		switch resize.Phase {
+		case v1alpha1.CloudServerResizeStopping:
+			if observed == v1alpha1.CloudServerPowerOff {
+				plan.Run = ResizeStepResize
+				plan.Phase = v1alpha1.CloudServerResizeResizing
+			}
+			return plan
+		case v1alpha1.CloudServerResizeResizing:
+			if effective == resize.To && observed != "" {
+				plan.Phase = v1alpha1.CloudServerResizeCompleted
+			}
+			return plan
+		}
+	}
+
+	desired := cloudServer.Spec.FlavorName
+	if effective == "" || desired == effective || observed == "" || cloudServer.Status.PowerAction != "" {
+		return plan
+	}
+	if resize != nil && resize.Phase == v1alpha1.CloudServerResizeRefused && resize.To == desired {
+		return plan
+	}
+
+	plan.Start = true
+	plan.Run = ResizeStepResize
+	plan.Phase = v1alpha1.CloudServerResizeResizing
+	if observed == v1alpha1.CloudServerPowerOn {
+		plan.Run = ResizeStepPowerOff
+		plan.Phase = v1alpha1.CloudServerResizeStopping
+	}
+	return plan
+}
@@ -0,0 +1,106 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+func TestObservedFlavor(t *testing.T) {
+	applied := &v1alpha1.CloudServerFlavor{Name: "CSO2A4", CPU: 2, RAMGb: 4}
+	tests := []struct {
+		name       string
+		properties arubaClient.CloudServerProperties
+		applied    *v1alpha1.CloudServerFlavor
+		want       *v1alpha1.CloudServerFlavor
+	}{
+		{name: "flavor details",
+			properties: arubaClient.CloudServerProperties{FlavorName: "CSO4A8", Flavor: &arubaClient.CloudServerFlavor{Name: "CSO4A8", CPU: 4, RAM: 8, HD: 80}},
+			applied:    applied,
+			want:       &v1alpha1.CloudServerFlavor{Name: "CSO4A8", CPU: 4, RAMGb: 8, DiskGb: 80}},
+		{name: "flavor name only", properties: arubaClient.CloudServerProperties{FlavorName: "CSO4A8"}, applied: applied,
+			want: &v1alpha1.CloudServerFlavor{Name: "CSO4A8"}},
+		{name: "flavor name of the applied flavor", properties: arubaClient.CloudServerProperties{FlavorName: "CSO2A4"}, applied: applied,
+			want: applied},
+		{name: "flavor absent", properties: arubaClient.CloudServerProperties{}, applied: applied, want: applied},
+		{name: "flavor absent and never applied", properties: arubaClient.CloudServerProperties{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ObservedFlavor(tt.properties, tt.applied))
+		})
+	}
+}
+
+func TestPlanResize(t *testing.T) {
+	const (
+		on        = v1alpha1.CloudServerPowerOn
+		off       = v1alpha1.CloudServerPowerOff
+		stopping  = v1alpha1.CloudServerResizeStopping
+		resizing  = v1alpha1.CloudServerResizeResizing
+		completed = v1alpha1.CloudServerResizeCompleted
+		refused   = v1alpha1.CloudServerResizeRefused
+	)
+	tests := []struct {
+		name      string
+		desired   string
+		effective string
+		observed  v1alpha1.CloudServerPowerState
+		action    v1alpha1.CloudServerPowerAction
+		resize    *v1alpha1.CloudServerResize
+		want      ResizePlan
+	}{
+		{name: "flavor applied", desired: "CSO2A4", effective: "CSO2A4", observed: on, want: ResizePlan{}},
+		{name: "flavor unknown", desired: "CSO4A8", observed: on, want: ResizePlan{}},
+		{name: "running", desired: "CSO4A8", effective: "CSO2A4", observed: on,
+			want: ResizePlan{Run: ResizeStepPowerOff, Phase: stopping, Start: true}},
+		{name: "stopped", desired: "CSO4A8", effective: "CSO2A4", observed: off,
+			want: ResizePlan{Run: ResizeStepResize, Phase: resizing, Start: true}},
+		{name: "transitioning", desired: "CSO4A8", effective: "CSO2A4", want: ResizePlan{}},
+		{name: "power action in progress", desired: "CSO4A8", effective: "CSO2A4", observed: on, action: v1alpha1.CloudServerPowerActionRestart,
+			want: ResizePlan{}},
+		{name: "stopping", desired: "CSO4A8", effective: "CSO2A4", observed: on,
+			resize: &v1alpha1.CloudServerResize{Phase: stopping, From: "CSO2A4", To: "CSO4A8"},
+			want:   ResizePlan{Phase: stopping}},
+		{name: "stopped for the resize", desired: "CSO4A8", effective: "CSO2A4", observed: off,
+			resize: &v1alpha1.CloudServerResize{Phase: stopping, From: "CSO2A4", To: "CSO4A8"},
+			want:   ResizePlan{Run: ResizeStepResize, Phase: resizing}},
+		{name: "resizing", desired: "CSO4A8", effective: "CSO2A4", observed: off,
+			resize: &v1alpha1.CloudServerResize{Phase: resizing, From: "CSO2A4", To: "CSO4A8"},
+			want:   ResizePlan{Phase: resizing}},
+		{name: "resized", desired: "CSO4A8", effective: "CSO4A8", observed: off,
+			resize: &v1alpha1.CloudServerResize{Phase: resizing, From: "CSO2A4", To: "CSO4A8"},
+			want:   ResizePlan{Phase: completed}},
+		{name: "resized while transitioning", desired: "CSO4A8", effective: "CSO4A8",
+			resize: &v1alpha1.CloudServerResize{Phase: resizing, From: "CSO2A4", To: "CSO4A8"},
+			want:   ResizePlan{Phase: resizing}},
+		{name: "refused", desired: "CSO4A8", effective: "CSO2A4", observed: on,
+			resize: &v1alpha1.CloudServerResize{Phase: refused, From: "CSO2A4", To: "CSO4A8"},
+			want:   ResizePlan{Phase: refused}},
+		{name: "another flavor after a refusal", desired: "CSO8A16", effective: "CSO2A4", observed: off,
+			resize: &v1alpha1.CloudServerResize{Phase: refused, From: "CSO2A4", To: "CSO4A8"},
+			want:   ResizePlan{Run: ResizeStepResize, Phase: resizing, Start: true}},
+		{name: "another resize", desired: "CSO8A16", effective: "CSO4A8", observed: on,
+			resize: &v1alpha1.CloudServerResize{Phase: completed, From: "CSO2A4", To: "CSO4A8"},
+			want:   ResizePlan{Run: ResizeStepPowerOff, Phase: stopping, Start: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cloudServer := &v1alpha1.CloudServer{
+				Spec: v1alpha1.CloudServerSpec{FlavorName: tt.desired},
+				Status: v1alpha1.CloudServerStatus{
+					PowerState:  tt.observed,
+					PowerAction: tt.action,
+					Resize:      tt.resize,
+				},
+			}
+			if tt.effective != "" {
+				cloudServer.Status.Flavor = &v1alpha1.CloudServerFlavor{Name: tt.effective}
+			}
+			assert.Equal(t, tt.want, PlanResize(cloudServer))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// SnapshotsOf returns the namespace/name of the block storage snapshots taken of the remote block
+// storage with blockStorageID. Snapshots not created remotely yet, failed or deleted outside of the
+// operator are left out.
+func SnapshotsOf(ctx context.Context, c client.Reader, blockStorageID string) ([]string, error) {
+	if blockStorageID == "" {
+		return nil, nil
+	}
+	snapshots := &v1alpha1.BlockStorageSnapshotList{}
+	if err := c.List(ctx, snapshots); err != nil {
+		return nil, fmt.Errorf("failed to list block storage snapshots: %w", err)
+	}
+
+	var names []string
+	for _, snapshot := range snapshots.Items {
+		if snapshot.Status.BlockStorageID != blockStorageID || snapshot.Status.ResourceID == "" ||
+			snapshot.Status.Phase == v1alpha1.ResourcePhaseFailed ||
+			meta.IsStatusConditionTrue(snapshot.Status.Conditions, v1alpha1.ConditionTypeRemoteMissing) {
+			continue
+		}
+		names = append(names, snapshot.Namespace+"/"+snapshot.Name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
@@ -0,0 +1,53 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+func TestSnapshotsOf(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	snapshot := func(namespace, name, blockStorageID, resourceID string) *v1alpha1.BlockStorageSnapshot {
+		return &v1alpha1.BlockStorageSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status: v1alpha1.BlockStorageSnapshotStatus{
+				ResourceStatus: v1alpha1.ResourceStatus{ResourceID: resourceID},
+				BlockStorageID: blockStorageID,
+			},
+		}
+	}
+	failed := snapshot("app", "data-failed", "bs-data", "snap-4")
+	failed.Status.Phase = v1alpha1.ResourcePhaseFailed
+	missing := snapshot("app", "data-missing", "bs-data", "snap-5")
+	missing.Status.Conditions = []metav1.Condition{{Type: v1alpha1.ConditionTypeRemoteMissing, Status: metav1.ConditionTrue, Reason: "RemoteNotFound"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		snapshot("app", "data-nightly", "bs-data", "snap-1"),
+		snapshot("backup", "data-weekly", "bs-data", "snap-2"),
+		snapshot("app", "data-pending", "bs-data", ""),
+		snapshot("app", "boot-nightly", "bs-boot", "snap-3"),
+		failed,
+		missing,
+	).Build()
+	ctx := context.Background()
+
+	snapshots, err := SnapshotsOf(ctx, k8sClient, "bs-data")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app/data-nightly", "backup/data-weekly"}, snapshots)
+
+	snapshots, err = SnapshotsOf(ctx, k8sClient, "bs-other")
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	snapshots, err = SnapshotsOf(ctx, k8sClient, "")
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
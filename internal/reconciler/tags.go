@@ -0,0 +1,91 @@
+package reconciler
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+const (
+	// NamespaceTagKey is the operator-managed tag holding the namespace of the resource
+	NamespaceTagKey = "k8s-namespace"
+	// NameTagKey is the operator-managed tag holding the name of the resource
+	NameTagKey = "k8s-name"
+	// ClusterTagKey is the operator-managed tag holding the configured cluster ID
+	ClusterTagKey = "k8s-cluster"
+)
+
+// SpecTagsOf returns the tags set in the spec of obj
+func SpecTagsOf(obj client.Object) []string {
+	switch o := obj.(type) {
+	case *v1alpha1.Project:
+		return o.Spec.Tags
+	case *v1alpha1.ElasticIp:
+		return o.Spec.Tags
+	case *v1alpha1.BlockStorage:
+		return o.Spec.Tags
+	case *v1alpha1.BlockStorageSnapshot:
+		return o.Spec.Tags
+	case *v1alpha1.KeyPair:
+		return o.Spec.Tags
+	case *v1alpha1.Vpc:
+		return o.Spec.Tags
+	case *v1alpha1.Subnet:
+		return o.Spec.Tags
+	case *v1alpha1.SecurityGroup:
+		return o.Spec.Tags
+	case *v1alpha1.SecurityRule:
+		return o.Spec.Tags
+	case *v1alpha1.CloudServer:
+		return o.Spec.Tags
+	}
+	return nil
+}
+
+// ResourceTags returns the tags to send to the remote API for obj: the spec tags,
+// followed by the propagated labels rendered as "key=value" and the operator-managed
+// tags. A propagated label is skipped when the spec already sets a tag with its key.
+func (r *Reconciler) ResourceTags(obj client.Object) []string {
+	var tags []string
+	keys := map[string]bool{}
+	add := func(tag string) {
+		if slices.Contains(tags, tag) {
+			return
+		}
+		key, _, _ := strings.Cut(tag, "=")
+		keys[key] = true
+		tags = append(tags, tag)
+	}
+
+	for _, tag := range SpecTagsOf(obj) {
+		add(tag)
+	}
+
+	labels := obj.GetLabels()
+	propagated := slices.Clone(r.PropagatedLabels)
+	sort.Strings(propagated)
+	for _, key := range propagated {
+		value, ok := labels[key]
+		if !ok || keys[key] {
+			continue
+		}
+		add(key + "=" + value)
+	}
+
+	add(NamespaceTagKey + "=" + obj.GetNamespace())
+	add(NameTagKey + "=" + obj.GetName())
+	if r.ClusterID != "" {
+		add(ClusterTagKey + "=" + r.ClusterID)
+	}
+
+	return tags
+}
+
+// tagsChanged reports whether the tags of obj differ from the ones last sent to the remote API
+func (r *Reconciler) tagsChanged(obj client.Object, status *v1alpha1.ResourceStatus) bool {
+	return !slices.Equal(status.AppliedTags, r.ResourceTags(obj))
+}
@@ -0,0 +1,163 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearch bounds the search of the next activation of an expression that never matches, e.g. "0 0 30 2 *"
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Expression is a parsed cron expression: "minute hour day-of-month month day-of-week"
+type Expression struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// When both the day of month and the day of week are restricted, a day matching either one matches
+	restrictedDays bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12,
+		names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	// 7 is Sunday too
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7,
+		names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// Parse parses a cron expression of five fields, each a "*" or a comma-separated list of
+// values and ranges, optionally with a "/step". Months and days of week accept their
+// three-letter English names.
+func Parse(spec string) (*Expression, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, got %d", spec, len(fields))
+	}
+
+	e := &Expression{}
+	var err error
+	for i, f := range []struct {
+		field
+		bits *uint64
+	}{
+		{minuteField, &e.minute},
+		{hourField, &e.hour},
+		{dayOfMonthField, &e.dayOfMonth},
+		{monthField, &e.month},
+		{dayOfWeekField, &e.dayOfWeek},
+	} {
+		if *f.bits, err = f.parse(fields[i]); err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", spec, err)
+		}
+	}
+	if e.dayOfWeek&(1<<7) != 0 {
+		e.dayOfWeek |= 1
+	}
+	e.restrictedDays = !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*")
+	return e, nil
+}
+
+// parse returns the values of the field in value as a bit set
+func (f field) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rng = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %s %q", f.name, part)
+			}
+			step = n
+		}
+
+		var low, high int
+		switch {
+		case rng == "*":
+			low, high = f.min, f.max
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range in %s %q", f.name, part)
+			}
+		default:
+			var err error
+			if low, err = f.value(rng); err != nil {
+				return 0, err
+			}
+			high = low
+			if step > 1 {
+				// "a/n" runs from a to the end of the field
+				high = f.max
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of the field, by number or name
+func (f field) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return i + f.min, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q, must be between %d and %d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t matching the expression, in the location of t, or the
+// zero time when the expression never matches
+func (e *Expression) Next(t time.Time) time.Time {
+	// Start from the next whole minute
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	limit := t.Add(maxSearch)
+	for t.Before(limit) {
+		switch {
+		case e.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !e.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case e.hour&(1<<t.Hour()) == 0:
+			// Move by elapsed time rather than wall clock, so daylight saving changes can't loop
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case e.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (e *Expression) matchesDay(t time.Time) bool {
+	dayOfMonth := e.dayOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := e.dayOfWeek&(1<<int(t.Weekday())) != 0
+	if e.restrictedDays {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
@@ -0,0 +1,63 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"0 20 * *",
+		"60 20 * * *",
+		"0 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"0 0 * * 8",
+		"0 20-7 * * *",
+		"*/0 * * * *",
+		"0 0 * foo *",
+	} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestExpressionNext(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+	// Friday
+	from := time.Date(2025, 3, 7, 12, 30, 15, 0, rome)
+
+	tests := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{spec: "0 20 * * *", from: from, want: time.Date(2025, 3, 7, 20, 0, 0, 0, rome)},
+		{spec: "30 12 * * *", from: from, want: time.Date(2025, 3, 8, 12, 30, 0, 0, rome)},
+		{spec: "*/15 * * * *", from: from, want: time.Date(2025, 3, 7, 12, 45, 0, 0, rome)},
+		{spec: "0 7 * * mon-fri", from: from, want: time.Date(2025, 3, 10, 7, 0, 0, 0, rome)},
+		{spec: "0 0 * * 7", from: from, want: time.Date(2025, 3, 9, 0, 0, 0, 0, rome)},
+		{spec: "0 8,18 * * SAT", from: from, want: time.Date(2025, 3, 8, 8, 0, 0, 0, rome)},
+		{spec: "0 0 1 jan *", from: from, want: time.Date(2026, 1, 1, 0, 0, 0, 0, rome)},
+		{spec: "0 0 29 2 *", from: from, want: time.Date(2028, 2, 29, 0, 0, 0, 0, rome)},
+		// Either the day of month or the day of week matches when both are restricted
+		{spec: "0 0 15 * sun", from: from, want: time.Date(2025, 3, 9, 0, 0, 0, 0, rome)},
+		{spec: "0 0 */10 * *", from: from, want: time.Date(2025, 3, 11, 0, 0, 0, 0, rome)},
+		// 02:30 doesn't exist when daylight saving time starts
+		{spec: "30 3 * * *", from: time.Date(2025, 3, 30, 1, 0, 0, 0, rome), want: time.Date(2025, 3, 30, 3, 30, 0, 0, rome)},
+		{spec: "0 0 30 2 *", from: from, want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			e, err := Parse(tt.spec)
+			require.NoError(t, err)
+			got := e.Next(tt.from)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
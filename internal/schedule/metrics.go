@@ -0,0 +1,44 @@
+package schedule
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	snapshotPolicyLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "arubacloud_snapshot_policy_last_success_timestamp_seconds",
+		Help: "Time of the last run of a SnapshotPolicy whose snapshots were all taken.",
+	}, []string{"namespace", "policy"})
+	snapshotPolicyLastFailure = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "arubacloud_snapshot_policy_last_failure_timestamp_seconds",
+		Help: "Time of the last run of a SnapshotPolicy a snapshot couldn't be taken for.",
+	}, []string{"namespace", "policy"})
+	snapshotPolicySnapshots = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "arubacloud_snapshot_policy_snapshots",
+		Help: "Number of snapshots taken by a SnapshotPolicy that are kept.",
+	}, []string{"namespace", "policy"})
+	snapshotPolicyCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "arubacloud_snapshot_policy_snapshots_created_total",
+		Help: "Number of snapshots created by a SnapshotPolicy.",
+	}, []string{"namespace", "policy"})
+	snapshotPolicyPruned = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "arubacloud_snapshot_policy_snapshots_pruned_total",
+		Help: "Number of snapshots deleted by a SnapshotPolicy once out of retention.",
+	}, []string{"namespace", "policy"})
+	snapshotPolicyFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "arubacloud_snapshot_policy_failures_total",
+		Help: "Number of runs of a SnapshotPolicy a snapshot couldn't be taken for.",
+	}, []string{"namespace", "policy"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		snapshotPolicyLastSuccess,
+		snapshotPolicyLastFailure,
+		snapshotPolicySnapshots,
+		snapshotPolicyCreated,
+		snapshotPolicyPruned,
+		snapshotPolicyFailures,
+	)
+}
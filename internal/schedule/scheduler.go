@@ -0,0 +1,296 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=serverschedules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=serverschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=cloudservers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+const (
+	// DefaultInterval is the interval the schedules are evaluated at
+	DefaultInterval = 30 * time.Second
+	// maxCatchUp bounds how far back a schedule looks for a missed change, e.g. when first
+	// created or after the manager was down
+	maxCatchUp = 7 * 24 * time.Hour
+
+	ReasonScheduledPowerOff = "ScheduledPowerOff"
+	ReasonScheduledPowerOn  = "ScheduledPowerOn"
+	ReasonInvalidSchedule   = "InvalidSchedule"
+	ReasonScheduleError     = "ScheduleError"
+)
+
+// Scheduler sets the power state of the CloudServers selected by the ServerSchedules when their
+// windows start and end. It only runs on the leader; the progress of each schedule is kept in its
+// status, so a new leader applies the changes missed in between.
+type Scheduler struct {
+	client.Client
+	Recorder record.EventRecorder
+	Interval time.Duration
+
+	now func() time.Time
+}
+
+// NewScheduler creates a Scheduler using the manager client
+func NewScheduler(mgr ctrl.Manager) *Scheduler {
+	return &Scheduler{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor("serverschedule-scheduler"),
+		Interval: DefaultInterval,
+	}
+}
+
+// NeedLeaderElection makes the Scheduler run on the leader only
+func (s *Scheduler) NeedLeaderElection() bool {
+	return true
+}
+
+// Start evaluates the ServerSchedules every Interval until ctx is done
+func (s *Scheduler) Start(ctx context.Context) error {
+	return runEvery(ctx, s.Interval, s.Run)
+}
+
+// runEvery calls run every interval, DefaultInterval when not set, until ctx is done
+func runEvery(ctx context.Context, interval time.Duration, run func(context.Context)) error {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run evaluates every ServerSchedule once
+func (s *Scheduler) Run(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx).WithName("scheduler")
+
+	schedules := &v1alpha1.ServerScheduleList{}
+	if err := s.List(ctx, schedules); err != nil {
+		log.Error(err, "failed to list ServerSchedules")
+		return
+	}
+	for i := range schedules.Items {
+		schedule := &schedules.Items[i]
+		if err := s.Evaluate(ctx, schedule); err != nil {
+			log.Error(err, "failed to evaluate ServerSchedule", "Namespace", schedule.Namespace, "Name", schedule.Name)
+			s.Recorder.Event(schedule, corev1.EventTypeWarning, ReasonScheduleError, err.Error())
+		}
+	}
+}
+
+// event is a power state change of a schedule
+type event struct {
+	powerState v1alpha1.CloudServerPowerState
+	time       time.Time
+}
+
+// change is the start or end of a ServerScheduleWindow
+type change struct {
+	expr       *Expression
+	powerState v1alpha1.CloudServerPowerState
+}
+
+// Evaluate applies the latest change of schedule due since it was last applied, and updates its
+// status. The status is only written when it changes.
+func (s *Scheduler) Evaluate(ctx context.Context, schedule *v1alpha1.ServerSchedule) error {
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+	original := schedule.DeepCopy()
+
+	loc, changes, err := parseSchedule(schedule.Spec)
+	if err != nil {
+		if apimeta.SetStatusCondition(&schedule.Status.Conditions, metav1.Condition{
+			Type:               v1alpha1.ConditionTypeScheduleValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             ReasonInvalidSchedule,
+			Message:            err.Error(),
+			ObservedGeneration: schedule.Generation,
+		}) {
+			s.Recorder.Event(schedule, corev1.EventTypeWarning, ReasonInvalidSchedule, err.Error())
+		}
+		schedule.Status.NextAction = nil
+		return s.updateStatus(ctx, original, schedule)
+	}
+	apimeta.SetStatusCondition(&schedule.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionTypeScheduleValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Valid",
+		ObservedGeneration: schedule.Generation,
+	})
+
+	now = now.In(loc)
+	since := now.Add(-maxCatchUp)
+	if last := schedule.Status.LastScheduleTime; last != nil && last.After(since) {
+		since = last.In(loc)
+	}
+
+	if due, ok := latestEvent(changes, since, now); ok {
+		// A suspended schedule skips its changes, they aren't applied once resumed
+		if !schedule.Spec.Suspend {
+			changed, err := s.apply(ctx, schedule, due.powerState)
+			if err != nil {
+				return err
+			}
+			schedule.Status.LastAction = &v1alpha1.ScheduledPowerAction{
+				PowerState:   due.powerState,
+				Time:         metav1.NewTime(due.time),
+				CloudServers: changed,
+			}
+		}
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: now}
+	}
+
+	schedule.Status.NextAction = nil
+	if next, ok := nextEvent(changes, now); ok {
+		schedule.Status.NextAction = &v1alpha1.ScheduledPowerAction{
+			PowerState: next.powerState,
+			Time:       metav1.NewTime(next.time),
+		}
+	}
+	return s.updateStatus(ctx, original, schedule)
+}
+
+// apply sets the power state of the CloudServers selected by schedule, except the ones opting out,
+// and returns the sorted names of the CloudServers changed
+func (s *Scheduler) apply(ctx context.Context, schedule *v1alpha1.ServerSchedule, powerState v1alpha1.CloudServerPowerState) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&schedule.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+	cloudServers := &v1alpha1.CloudServerList{}
+	if err := s.List(ctx, cloudServers, client.InNamespace(schedule.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list CloudServers: %w", err)
+	}
+
+	reason := ReasonScheduledPowerOn
+	if powerState == v1alpha1.CloudServerPowerOff {
+		reason = ReasonScheduledPowerOff
+	}
+	var changed []string
+	for i := range cloudServers.Items {
+		cloudServer := &cloudServers.Items[i]
+		if cloudServer.Annotations[v1alpha1.ScheduleOptOutAnnotation] == "true" || !cloudServer.DeletionTimestamp.IsZero() {
+			continue
+		}
+		current := cloudServer.Spec.PowerState
+		if current == "" {
+			current = v1alpha1.CloudServerPowerOn
+		}
+		if current == powerState {
+			continue
+		}
+
+		patch := client.MergeFrom(cloudServer.DeepCopy())
+		cloudServer.Spec.PowerState = powerState
+		if err := s.Patch(ctx, cloudServer, patch); err != nil {
+			return nil, fmt.Errorf("failed to set the power state of CloudServer %s: %w", cloudServer.Name, err)
+		}
+		s.Recorder.Eventf(cloudServer, corev1.EventTypeNormal, reason, "Power state set to %s by ServerSchedule %s", powerState, schedule.Name)
+		changed = append(changed, cloudServer.Name)
+	}
+	sort.Strings(changed)
+
+	if len(changed) > 0 {
+		s.Recorder.Eventf(schedule, corev1.EventTypeNormal, reason, "Power state of %d CloudServers set to %s: %s", len(changed), powerState, strings.Join(changed, ", "))
+	}
+	return changed, nil
+}
+
+func (s *Scheduler) updateStatus(ctx context.Context, original, schedule *v1alpha1.ServerSchedule) error {
+	if equality.Semantic.DeepEqual(original.Status, schedule.Status) {
+		return nil
+	}
+	return s.Status().Patch(ctx, schedule, client.MergeFrom(original))
+}
+
+// parseSchedule returns the time zone of spec and the starts and ends of its windows
+func parseSchedule(spec v1alpha1.ServerScheduleSpec) (*time.Location, []change, error) {
+	timeZone := spec.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid time zone %q: %w", spec.TimeZone, err)
+	}
+
+	changes := make([]change, 0, 2*len(spec.Windows))
+	for i, w := range spec.Windows {
+		powerOff, err := Parse(w.PowerOff)
+		if err != nil {
+			return nil, nil, fmt.Errorf("windows[%d].powerOff: %w", i, err)
+		}
+		powerOn, err := Parse(w.PowerOn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("windows[%d].powerOn: %w", i, err)
+		}
+		changes = append(changes,
+			change{expr: powerOff, powerState: v1alpha1.CloudServerPowerOff},
+			change{expr: powerOn, powerState: v1alpha1.CloudServerPowerOn})
+	}
+	return loc, changes, nil
+}
+
+// latestEvent returns the latest of changes in (since, until]. A window ending when another
+// one starts keeps the cloud servers on.
+func latestEvent(changes []change, since, until time.Time) (event, bool) {
+	var latest event
+	found := false
+	for _, c := range changes {
+		var last time.Time
+		for t := c.expr.Next(since); !t.IsZero() && !t.After(until); t = c.expr.Next(t) {
+			last = t
+		}
+		if last.IsZero() {
+			continue
+		}
+		if !found || last.After(latest.time) || (last.Equal(latest.time) && c.powerState == v1alpha1.CloudServerPowerOn) {
+			latest = event{powerState: c.powerState, time: last}
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// nextEvent returns the first of changes after t
+func nextEvent(changes []change, t time.Time) (event, bool) {
+	var next event
+	found := false
+	for _, c := range changes {
+		at := c.expr.Next(t)
+		if at.IsZero() {
+			continue
+		}
+		if !found || at.Before(next.time) || (at.Equal(next.time) && c.powerState == v1alpha1.CloudServerPowerOn) {
+			next = event{powerState: c.powerState, time: at}
+			found = true
+		}
+	}
+	return next, found
+}
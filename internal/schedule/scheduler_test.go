@@ -0,0 +1,144 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+func newCloudServer(name string, labels, annotations map[string]string) *v1alpha1.CloudServer {
+	return &v1alpha1.CloudServer{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "dev", Labels: labels, Annotations: annotations},
+	}
+}
+
+func newTestScheduler(t *testing.T, objs ...client.Object) *Scheduler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	return &Scheduler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(&v1alpha1.ServerSchedule{}).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+}
+
+func powerStateOf(t *testing.T, s *Scheduler, name string) v1alpha1.CloudServerPowerState {
+	cloudServer := &v1alpha1.CloudServer{}
+	require.NoError(t, s.Get(context.Background(), types.NamespacedName{Namespace: "dev", Name: name}, cloudServer))
+	return cloudServer.Spec.PowerState
+}
+
+func TestSchedulerEvaluate(t *testing.T) {
+	ctx := context.Background()
+	schedule := &v1alpha1.ServerSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "nights", Namespace: "dev"},
+		Spec: v1alpha1.ServerScheduleSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			TimeZone: "Europe/Rome",
+			Windows:  []v1alpha1.ServerScheduleWindow{{PowerOff: "0 20 * * mon-fri", PowerOn: "0 7 * * mon-fri"}},
+		},
+	}
+	dev := map[string]string{"env": "dev"}
+	s := newTestScheduler(t, schedule,
+		newCloudServer("dev-1", dev, nil),
+		newCloudServer("dev-2", dev, map[string]string{v1alpha1.ScheduleOptOutAnnotation: "true"}),
+		newCloudServer("prod-1", map[string]string{"env": "prod"}, nil),
+	)
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+	evaluateAt := func(now time.Time) *v1alpha1.ServerSchedule {
+		s.now = func() time.Time { return now }
+		stored := &v1alpha1.ServerSchedule{}
+		require.NoError(t, s.Get(ctx, client.ObjectKeyFromObject(schedule), stored))
+		require.NoError(t, s.Evaluate(ctx, stored))
+		require.NoError(t, s.Get(ctx, client.ObjectKeyFromObject(schedule), stored))
+		return stored
+	}
+
+	// Created on Wednesday evening, the servers are powered off right away
+	stored := evaluateAt(time.Date(2025, 3, 5, 21, 0, 0, 0, rome))
+	assert.Equal(t, v1alpha1.CloudServerPowerOff, powerStateOf(t, s, "dev-1"))
+	assert.Empty(t, powerStateOf(t, s, "dev-2"))
+	assert.Empty(t, powerStateOf(t, s, "prod-1"))
+	require.NotNil(t, stored.Status.LastAction)
+	assert.Equal(t, v1alpha1.CloudServerPowerOff, stored.Status.LastAction.PowerState)
+	assert.Equal(t, []string{"dev-1"}, stored.Status.LastAction.CloudServers)
+	require.NotNil(t, stored.Status.NextAction)
+	assert.Equal(t, v1alpha1.CloudServerPowerOn, stored.Status.NextAction.PowerState)
+	assert.True(t, time.Date(2025, 3, 6, 7, 0, 0, 0, rome).Equal(stored.Status.NextAction.Time.Time))
+	assert.True(t, apimeta.IsStatusConditionTrue(stored.Status.Conditions, v1alpha1.ConditionTypeScheduleValid))
+
+	// A manual change isn't reverted until the next change of the schedule
+	cloudServer := &v1alpha1.CloudServer{}
+	require.NoError(t, s.Get(ctx, types.NamespacedName{Namespace: "dev", Name: "dev-1"}, cloudServer))
+	cloudServer.Spec.PowerState = v1alpha1.CloudServerPowerOn
+	require.NoError(t, s.Update(ctx, cloudServer))
+	evaluateAt(time.Date(2025, 3, 5, 23, 0, 0, 0, rome))
+	assert.Equal(t, v1alpha1.CloudServerPowerOn, powerStateOf(t, s, "dev-1"))
+
+	// Changes missed while no scheduler was running are applied, the latest one only
+	stored = evaluateAt(time.Date(2025, 3, 6, 21, 0, 0, 0, rome))
+	assert.Equal(t, v1alpha1.CloudServerPowerOff, powerStateOf(t, s, "dev-1"))
+	assert.True(t, time.Date(2025, 3, 6, 20, 0, 0, 0, rome).Equal(stored.Status.LastAction.Time.Time))
+
+	// The servers stay off over the weekend
+	stored = evaluateAt(time.Date(2025, 3, 8, 9, 0, 0, 0, rome))
+	assert.Equal(t, v1alpha1.CloudServerPowerOff, powerStateOf(t, s, "dev-1"))
+	assert.True(t, time.Date(2025, 3, 10, 7, 0, 0, 0, rome).Equal(stored.Status.NextAction.Time.Time))
+
+	stored = evaluateAt(time.Date(2025, 3, 10, 7, 0, 30, 0, rome))
+	assert.Equal(t, v1alpha1.CloudServerPowerOn, powerStateOf(t, s, "dev-1"))
+	assert.Equal(t, v1alpha1.CloudServerPowerOn, stored.Status.LastAction.PowerState)
+}
+
+func TestSchedulerEvaluate_Suspended(t *testing.T) {
+	schedule := &v1alpha1.ServerSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "nights", Namespace: "dev"},
+		Spec: v1alpha1.ServerScheduleSpec{
+			Windows: []v1alpha1.ServerScheduleWindow{{PowerOff: "0 20 * * *", PowerOn: "0 7 * * *"}},
+			Suspend: true,
+		},
+	}
+	s := newTestScheduler(t, schedule, newCloudServer("dev-1", nil, nil))
+	s.now = func() time.Time { return time.Date(2025, 3, 5, 21, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, s.Evaluate(context.Background(), schedule))
+	assert.Empty(t, powerStateOf(t, s, "dev-1"))
+	assert.Nil(t, schedule.Status.LastAction)
+	assert.NotNil(t, schedule.Status.LastScheduleTime)
+	assert.NotNil(t, schedule.Status.NextAction)
+}
+
+func TestSchedulerEvaluate_Invalid(t *testing.T) {
+	schedule := &v1alpha1.ServerSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "nights", Namespace: "dev"},
+		Spec: v1alpha1.ServerScheduleSpec{
+			TimeZone: "Mars/Olympus_Mons",
+			Windows:  []v1alpha1.ServerScheduleWindow{{PowerOff: "0 20 * * *", PowerOn: "0 7 * * *"}},
+		},
+	}
+	s := newTestScheduler(t, schedule)
+
+	require.NoError(t, s.Evaluate(context.Background(), schedule))
+	condition := apimeta.FindStatusCondition(schedule.Status.Conditions, v1alpha1.ConditionTypeScheduleValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, "invalid time zone")
+
+	schedule.Spec.TimeZone = ""
+	schedule.Spec.Windows[0].PowerOn = "0 7 * *"
+	require.NoError(t, s.Evaluate(context.Background(), schedule))
+	condition = apimeta.FindStatusCondition(schedule.Status.Conditions, v1alpha1.ConditionTypeScheduleValid)
+	assert.Contains(t, condition.Message, "windows[0].powerOn")
+}
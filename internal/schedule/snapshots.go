@@ -0,0 +1,453 @@
+package schedule
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=snapshotpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=snapshotpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=blockstoragesnapshots,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=arubacloud.com,resources=blockstorages,verbs=get;list;watch
+
+const (
+	ReasonSnapshotsCreated = "SnapshotsCreated"
+	ReasonSnapshotsPruned  = "SnapshotsPruned"
+	ReasonSnapshotFailed   = "SnapshotFailed"
+
+	// snapshotTimeFormat is the format of the run time in the name of the snapshots taken by a policy
+	snapshotTimeFormat = "200601021504"
+)
+
+// SnapshotScheduler snapshots the BlockStorages selected by the SnapshotPolicies on their schedule
+// and deletes the snapshots out of retention. Like the Scheduler, it only runs on the leader and
+// keeps the progress of each policy in its status.
+type SnapshotScheduler struct {
+	client.Client
+	Recorder record.EventRecorder
+	Interval time.Duration
+
+	now func() time.Time
+}
+
+// NewSnapshotScheduler creates a SnapshotScheduler using the manager client
+func NewSnapshotScheduler(mgr ctrl.Manager) *SnapshotScheduler {
+	return &SnapshotScheduler{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor("snapshotpolicy-scheduler"),
+		Interval: DefaultInterval,
+	}
+}
+
+// NeedLeaderElection makes the SnapshotScheduler run on the leader only
+func (s *SnapshotScheduler) NeedLeaderElection() bool {
+	return true
+}
+
+// Start evaluates the SnapshotPolicies every Interval until ctx is done
+func (s *SnapshotScheduler) Start(ctx context.Context) error {
+	return runEvery(ctx, s.Interval, s.Run)
+}
+
+// Run evaluates every SnapshotPolicy once
+func (s *SnapshotScheduler) Run(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx).WithName("snapshot-scheduler")
+
+	policies := &v1alpha1.SnapshotPolicyList{}
+	if err := s.List(ctx, policies); err != nil {
+		log.Error(err, "failed to list SnapshotPolicies")
+		return
+	}
+	// The gauges only report the policies that still exist
+	snapshotPolicyLastSuccess.Reset()
+	snapshotPolicyLastFailure.Reset()
+	snapshotPolicySnapshots.Reset()
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if err := s.Evaluate(ctx, policy); err != nil {
+			log.Error(err, "failed to evaluate SnapshotPolicy", "Namespace", policy.Namespace, "Name", policy.Name)
+			s.Recorder.Event(policy, corev1.EventTypeWarning, ReasonScheduleError, err.Error())
+		}
+	}
+}
+
+// Evaluate takes the snapshots of the latest run of policy due since it was last handled, records
+// the outcome of the runs, deletes the snapshots out of retention and updates its status. The
+// status is only written when it changes.
+func (s *SnapshotScheduler) Evaluate(ctx context.Context, policy *v1alpha1.SnapshotPolicy) error {
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+	original := policy.DeepCopy()
+
+	loc, expr, err := parsePolicySchedule(policy.Spec)
+	if err != nil {
+		if apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:               v1alpha1.ConditionTypeScheduleValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             ReasonInvalidSchedule,
+			Message:            err.Error(),
+			ObservedGeneration: policy.Generation,
+		}) {
+			s.Recorder.Event(policy, corev1.EventTypeWarning, ReasonInvalidSchedule, err.Error())
+		}
+		policy.Status.NextScheduleTime = nil
+	} else {
+		apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:               v1alpha1.ConditionTypeScheduleValid,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Valid",
+			ObservedGeneration: policy.Generation,
+		})
+
+		now = now.In(loc)
+		// A new policy waits for its first run, a missed run is taken when found
+		since := policy.CreationTimestamp.Time
+		if last := policy.Status.LastScheduleTime; last != nil {
+			since = last.Time
+		}
+		if oldest := now.Add(-maxCatchUp); since.Before(oldest) {
+			since = oldest
+		}
+		if due, ok := latestRun(expr, since.In(loc), now); ok {
+			// A suspended policy skips its runs, they aren't taken once resumed
+			if !policy.Spec.Suspend {
+				if err := s.takeSnapshots(ctx, policy, due); err != nil {
+					s.recordFailure(policy, due, err.Error())
+				}
+			}
+			policy.Status.LastScheduleTime = &metav1.Time{Time: now}
+		}
+
+		policy.Status.NextScheduleTime = nil
+		if next := expr.Next(now); !next.IsZero() {
+			policy.Status.NextScheduleTime = &metav1.Time{Time: next}
+		}
+	}
+
+	snapshots := &v1alpha1.BlockStorageSnapshotList{}
+	if err := s.List(ctx, snapshots, client.InNamespace(policy.Namespace), client.MatchingLabels{v1alpha1.SnapshotPolicyLabel: policy.Name}); err != nil {
+		return fmt.Errorf("failed to list BlockStorageSnapshots: %w", err)
+	}
+	s.observeRuns(policy, snapshots.Items)
+
+	kept, err := s.prune(ctx, policy, snapshots.Items, now)
+	if err != nil {
+		return err
+	}
+	policy.Status.Snapshots = kept
+
+	s.reportMetrics(policy)
+	return s.updateStatus(ctx, original, policy)
+}
+
+// takeSnapshots creates a BlockStorageSnapshot of every BlockStorage selected by policy for the
+// run at due. The snapshots are named after the run, so a run taken twice, e.g. by a new leader,
+// doesn't create them again.
+func (s *SnapshotScheduler) takeSnapshots(ctx context.Context, policy *v1alpha1.SnapshotPolicy, due time.Time) error {
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector: %w", err)
+	}
+	blockStorages := &v1alpha1.BlockStorageList{}
+	if err := s.List(ctx, blockStorages, client.InNamespace(policy.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list BlockStorages: %w", err)
+	}
+
+	var created, failures []string
+	for i := range blockStorages.Items {
+		blockStorage := &blockStorages.Items[i]
+		if !blockStorage.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if blockStorage.Status.ResourceID == "" {
+			failures = append(failures, fmt.Sprintf("BlockStorage %s isn't created yet", blockStorage.Name))
+			continue
+		}
+
+		snapshot := snapshotFor(policy, blockStorage, due)
+		if err := s.Create(ctx, snapshot); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				err = s.checkTaken(ctx, snapshot)
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("failed to create the snapshot of BlockStorage %s: %v", blockStorage.Name, err))
+			}
+			continue
+		}
+		created = append(created, snapshot.Name)
+	}
+	sort.Strings(created)
+
+	if len(created) > 0 {
+		snapshotPolicyCreated.WithLabelValues(policy.Namespace, policy.Name).Add(float64(len(created)))
+		s.Recorder.Eventf(policy, corev1.EventTypeNormal, ReasonSnapshotsCreated, "Created %d snapshots: %s", len(created), strings.Join(created, ", "))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// checkTaken checks that the existing BlockStorageSnapshot named like snapshot was taken by the
+// same policy of the same block storage, i.e. that the run was already taken
+func (s *SnapshotScheduler) checkTaken(ctx context.Context, snapshot *v1alpha1.BlockStorageSnapshot) error {
+	existing := &v1alpha1.BlockStorageSnapshot{}
+	if err := s.Get(ctx, client.ObjectKeyFromObject(snapshot), existing); err != nil {
+		return err
+	}
+	ref := existing.Spec.BlockStorageReference
+	if ref.Namespace == "" {
+		ref.Namespace = existing.Namespace
+	}
+	if existing.Labels[v1alpha1.SnapshotPolicyLabel] != snapshot.Labels[v1alpha1.SnapshotPolicyLabel] || ref != snapshot.Spec.BlockStorageReference {
+		return fmt.Errorf("BlockStorageSnapshot %s already exists and wasn't taken by the policy", snapshot.Name)
+	}
+	return nil
+}
+
+// snapshotFor returns the BlockStorageSnapshot of blockStorage taken by policy for the run at due
+func snapshotFor(policy *v1alpha1.SnapshotPolicy, blockStorage *v1alpha1.BlockStorage, due time.Time) *v1alpha1.BlockStorageSnapshot {
+	return &v1alpha1.BlockStorageSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        snapshotName(policy.Namespace, blockStorage.Name, policy.Name, due),
+			Namespace:   policy.Namespace,
+			Labels:      map[string]string{v1alpha1.SnapshotPolicyLabel: policy.Name},
+			Annotations: map[string]string{v1alpha1.SnapshotScheduledAtAnnotation: due.UTC().Format(time.RFC3339)},
+		},
+		Spec: v1alpha1.BlockStorageSnapshotSpec{
+			Tenant:                blockStorage.Spec.Tenant,
+			Tags:                  policy.Spec.Tags,
+			Location:              blockStorage.Spec.Location,
+			BlockStorageReference: v1alpha1.ResourceReference{Name: blockStorage.Name, Namespace: blockStorage.Namespace},
+			ProjectReference:      blockStorage.Spec.ProjectReference,
+			ProviderConfigRef:     blockStorage.Spec.ProviderConfigRef.DeepCopy(),
+		},
+	}
+}
+
+// snapshotName returns "<block storage>-<policy>-<hash>-<run time>", shortened to a valid object
+// name. The hash of the namespace, block storage and policy tells apart the names that would be
+// the same otherwise, e.g. of the policy "prod-daily" of "db" and the policy "daily" of "db-prod".
+func snapshotName(namespace, blockStorage, policy string, due time.Time) string {
+	sum := sha256.Sum256([]byte(namespace + "/" + blockStorage + "/" + policy))
+	suffix := "-" + hex.EncodeToString(sum[:])[:8] + "-" + due.UTC().Format(snapshotTimeFormat)
+	prefix := blockStorage + "-" + policy
+	if limit := 253 - len(suffix); len(prefix) > limit {
+		prefix = strings.TrimRight(prefix[:limit], "-.")
+	}
+	return prefix + suffix
+}
+
+// scheduledAt returns the time of the run snapshot was taken for, its creation time when unknown
+func scheduledAt(snapshot *v1alpha1.BlockStorageSnapshot) time.Time {
+	if t, err := time.Parse(time.RFC3339, snapshot.Annotations[v1alpha1.SnapshotScheduledAtAnnotation]); err == nil {
+		return t
+	}
+	return snapshot.CreationTimestamp.Time
+}
+
+// observeRuns records in the status of policy the latest run whose snapshots were all taken and
+// the latest run a snapshot failed for
+func (s *SnapshotScheduler) observeRuns(policy *v1alpha1.SnapshotPolicy, snapshots []v1alpha1.BlockStorageSnapshot) {
+	type run struct {
+		at       time.Time
+		pending  bool
+		failures []string
+	}
+	runs := map[int64]*run{}
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		at := scheduledAt(snapshot)
+		r := runs[at.Unix()]
+		if r == nil {
+			r = &run{at: at}
+			runs[at.Unix()] = r
+		}
+		switch snapshot.Status.Phase {
+		case v1alpha1.ResourcePhaseCreated:
+		case v1alpha1.ResourcePhaseFailed:
+			r.failures = append(r.failures, fmt.Sprintf("snapshot %s failed: %s", snapshot.Name, snapshot.Status.Message))
+		default:
+			r.pending = true
+		}
+	}
+
+	for _, r := range runs {
+		switch {
+		case len(r.failures) > 0:
+			sort.Strings(r.failures)
+			s.recordFailure(policy, r.at, strings.Join(r.failures, "; "))
+		case !r.pending:
+			// A run that failed to create some of its snapshots isn't successful
+			if failure := policy.Status.LastFailure; failure != nil && failure.Time.Time.Equal(r.at) {
+				continue
+			}
+			if last := policy.Status.LastSuccessfulTime; last == nil || r.at.After(last.Time) {
+				policy.Status.LastSuccessfulTime = &metav1.Time{Time: r.at}
+			}
+		}
+	}
+}
+
+// recordFailure reports the failure of the run of policy at at, unless a later or the same run
+// was already reported
+func (s *SnapshotScheduler) recordFailure(policy *v1alpha1.SnapshotPolicy, at time.Time, message string) {
+	if last := policy.Status.LastFailure; last != nil && !at.After(last.Time.Time) {
+		return
+	}
+	policy.Status.LastFailure = &v1alpha1.SnapshotPolicyFailure{Time: metav1.Time{Time: at}, Message: message}
+	snapshotPolicyFailures.WithLabelValues(policy.Namespace, policy.Name).Inc()
+	s.Recorder.Event(policy, corev1.EventTypeWarning, ReasonSnapshotFailed, message)
+}
+
+// prune deletes the snapshots taken by policy that are out of retention, and returns the number
+// of snapshots kept
+func (s *SnapshotScheduler) prune(ctx context.Context, policy *v1alpha1.SnapshotPolicy, snapshots []v1alpha1.BlockStorageSnapshot, now time.Time) (int32, error) {
+	blockStorages := &v1alpha1.BlockStorageList{}
+	if err := s.List(ctx, blockStorages, client.InNamespace(policy.Namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list BlockStorages: %w", err)
+	}
+	live := map[string]bool{}
+	for _, blockStorage := range blockStorages.Items {
+		if blockStorage.DeletionTimestamp.IsZero() {
+			live[blockStorageKey(v1alpha1.ResourceReference{Name: blockStorage.Name, Namespace: blockStorage.Namespace})] = true
+		}
+	}
+
+	expired := planRetention(snapshots, policy.Spec.Retention, live, now)
+	var pruned []string
+	for _, snapshot := range expired {
+		if err := s.Delete(ctx, snapshot); client.IgnoreNotFound(err) != nil {
+			return 0, fmt.Errorf("failed to delete BlockStorageSnapshot %s: %w", snapshot.Name, err)
+		}
+		pruned = append(pruned, snapshot.Name)
+	}
+
+	kept := int32(0)
+	for i := range snapshots {
+		if snapshots[i].DeletionTimestamp.IsZero() {
+			kept++
+		}
+	}
+	kept -= int32(len(pruned))
+
+	if len(pruned) > 0 {
+		snapshotPolicyPruned.WithLabelValues(policy.Namespace, policy.Name).Add(float64(len(pruned)))
+		s.Recorder.Eventf(policy, corev1.EventTypeNormal, ReasonSnapshotsPruned, "Deleted %d snapshots out of retention: %s", len(pruned), strings.Join(pruned, ", "))
+	}
+	return kept, nil
+}
+
+// planRetention returns the snapshots out of retention. The snapshots of each block storage are
+// ranked from the latest run: a taken snapshot is kept while among the retention.keepLast latest
+// taken ones or newer than retention.keepDays, and the latest taken snapshot is always kept. A
+// failed snapshot is deleted once a later one was taken, and the snapshots in progress are kept.
+// The retention only applies while the block storage is live, i.e. its key is in live: the taken
+// and failed snapshots of a block storage being deleted or gone are all out of retention, since no
+// later run rotates them and they would otherwise hold its deletion forever.
+func planRetention(snapshots []v1alpha1.BlockStorageSnapshot, retention v1alpha1.SnapshotRetention, live map[string]bool, now time.Time) []*v1alpha1.BlockStorageSnapshot {
+	byBlockStorage := map[string][]*v1alpha1.BlockStorageSnapshot{}
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		if !snapshot.DeletionTimestamp.IsZero() {
+			continue
+		}
+		key := blockStorageKey(snapshot.Spec.BlockStorageReference)
+		byBlockStorage[key] = append(byBlockStorage[key], snapshot)
+	}
+
+	maxAge := time.Duration(retention.KeepDays) * 24 * time.Hour
+	var expired []*v1alpha1.BlockStorageSnapshot
+	for key, group := range byBlockStorage {
+		sort.SliceStable(group, func(i, j int) bool {
+			return scheduledAt(group[i]).After(scheduledAt(group[j]))
+		})
+
+		taken := int32(0)
+		for _, snapshot := range group {
+			switch snapshot.Status.Phase {
+			case v1alpha1.ResourcePhaseCreated:
+				keep := live[key] && (taken == 0 ||
+					retention.KeepLast > 0 && taken < retention.KeepLast ||
+					retention.KeepDays > 0 && now.Sub(scheduledAt(snapshot)) <= maxAge)
+				taken++
+				if !keep {
+					expired = append(expired, snapshot)
+				}
+			case v1alpha1.ResourcePhaseFailed:
+				if taken > 0 || !live[key] {
+					expired = append(expired, snapshot)
+				}
+			}
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].Name < expired[j].Name })
+	return expired
+}
+
+// blockStorageKey returns the key of the block storage referenced by ref
+func blockStorageKey(ref v1alpha1.ResourceReference) string {
+	return ref.Namespace + "/" + ref.Name + "/" + ref.ID
+}
+
+// reportMetrics sets the gauges of policy from its status
+func (s *SnapshotScheduler) reportMetrics(policy *v1alpha1.SnapshotPolicy) {
+	if last := policy.Status.LastSuccessfulTime; last != nil {
+		snapshotPolicyLastSuccess.WithLabelValues(policy.Namespace, policy.Name).Set(float64(last.Unix()))
+	}
+	if last := policy.Status.LastFailure; last != nil {
+		snapshotPolicyLastFailure.WithLabelValues(policy.Namespace, policy.Name).Set(float64(last.Time.Unix()))
+	}
+	snapshotPolicySnapshots.WithLabelValues(policy.Namespace, policy.Name).Set(float64(policy.Status.Snapshots))
+}
+
+func (s *SnapshotScheduler) updateStatus(ctx context.Context, original, policy *v1alpha1.SnapshotPolicy) error {
+	if equality.Semantic.DeepEqual(original.Status, policy.Status) {
+		return nil
+	}
+	return s.Status().Patch(ctx, policy, client.MergeFrom(original))
+}
+
+// parsePolicySchedule returns the time zone and the cron expression of spec
+func parsePolicySchedule(spec v1alpha1.SnapshotPolicySpec) (*time.Location, *Expression, error) {
+	timeZone := spec.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid time zone %q: %w", spec.TimeZone, err)
+	}
+	expr, err := Parse(spec.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("schedule: %w", err)
+	}
+	return loc, expr, nil
+}
+
+// latestRun returns the latest time of expr in (since, until]
+func latestRun(expr *Expression, since, until time.Time) (time.Time, bool) {
+	var last time.Time
+	for t := expr.Next(since); !t.IsZero() && !t.After(until); t = expr.Next(t) {
+		last = t
+	}
+	return last, !last.IsZero()
+}
@@ -0,0 +1,259 @@
+package schedule
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+func newBlockStorage(name, resourceID string, labels map[string]string) *v1alpha1.BlockStorage {
+	return &v1alpha1.BlockStorage{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod", Labels: labels},
+		Spec: v1alpha1.BlockStorageSpec{
+			Tenant:           "acme",
+			Location:         v1alpha1.Location{Value: "ITBG-Bergamo"},
+			ProjectReference: v1alpha1.ResourceReference{Name: "main"},
+		},
+		Status: v1alpha1.BlockStorageStatus{ResourceStatus: v1alpha1.ResourceStatus{ResourceID: resourceID}},
+	}
+}
+
+func newTestSnapshotScheduler(t *testing.T, objs ...client.Object) *SnapshotScheduler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	return &SnapshotScheduler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(&v1alpha1.SnapshotPolicy{}, &v1alpha1.BlockStorageSnapshot{}).Build(),
+		Recorder: record.NewFakeRecorder(20),
+	}
+}
+
+func snapshotsOf(t *testing.T, s *SnapshotScheduler) []v1alpha1.BlockStorageSnapshot {
+	snapshots := &v1alpha1.BlockStorageSnapshotList{}
+	require.NoError(t, s.List(context.Background(), snapshots, client.InNamespace("prod")))
+	return snapshots.Items
+}
+
+func setSnapshotPhase(t *testing.T, s *SnapshotScheduler, phase v1alpha1.ResourcePhase, message string) {
+	for _, snapshot := range snapshotsOf(t, s) {
+		if snapshot.Status.Phase != "" {
+			continue
+		}
+		snapshot.Status.Phase = phase
+		snapshot.Status.Message = message
+		require.NoError(t, s.Status().Update(context.Background(), &snapshot))
+	}
+}
+
+func TestSnapshotSchedulerEvaluate(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2025, 3, 5, 15, 0, 0, 0, time.UTC)
+	policy := &v1alpha1.SnapshotPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "prod", CreationTimestamp: metav1.NewTime(created)},
+		Spec: v1alpha1.SnapshotPolicySpec{
+			Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"backup": "daily"}},
+			Schedule:  "0 2 * * *",
+			Retention: v1alpha1.SnapshotRetention{KeepLast: 2},
+			Tags:      []string{"compliance"},
+		},
+	}
+	daily := map[string]string{"backup": "daily"}
+	s := newTestSnapshotScheduler(t, policy,
+		newBlockStorage("data", "bs-data", daily),
+		newBlockStorage("scratch", "bs-scratch", nil),
+	)
+	evaluateAt := func(now time.Time) *v1alpha1.SnapshotPolicy {
+		s.now = func() time.Time { return now }
+		stored := &v1alpha1.SnapshotPolicy{}
+		require.NoError(t, s.Get(ctx, client.ObjectKeyFromObject(policy), stored))
+		require.NoError(t, s.Evaluate(ctx, stored))
+		require.NoError(t, s.Get(ctx, client.ObjectKeyFromObject(policy), stored))
+		return stored
+	}
+
+	// A new policy waits for its first run
+	stored := evaluateAt(created.Add(time.Hour))
+	assert.Empty(t, snapshotsOf(t, s))
+	assert.True(t, time.Date(2025, 3, 6, 2, 0, 0, 0, time.UTC).Equal(stored.Status.NextScheduleTime.Time))
+	assert.True(t, apimeta.IsStatusConditionTrue(stored.Status.Conditions, v1alpha1.ConditionTypeScheduleValid))
+
+	// The selected block storages are snapshotted once per run
+	evaluateAt(time.Date(2025, 3, 6, 2, 0, 30, 0, time.UTC))
+	stored = evaluateAt(time.Date(2025, 3, 6, 2, 1, 0, 0, time.UTC))
+	snapshots := snapshotsOf(t, s)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "data-daily-b64e877e-202503060200", snapshots[0].Name)
+	assert.Equal(t, "daily", snapshots[0].Labels[v1alpha1.SnapshotPolicyLabel])
+	assert.Equal(t, v1alpha1.ResourceReference{Name: "data", Namespace: "prod"}, snapshots[0].Spec.BlockStorageReference)
+	assert.Equal(t, "acme", snapshots[0].Spec.Tenant)
+	assert.Equal(t, []string{"compliance"}, snapshots[0].Spec.Tags)
+	assert.Nil(t, stored.Status.LastSuccessfulTime)
+	assert.Equal(t, int32(1), stored.Status.Snapshots)
+
+	// The run succeeds once its snapshots are taken
+	setSnapshotPhase(t, s, v1alpha1.ResourcePhaseCreated, "")
+	stored = evaluateAt(time.Date(2025, 3, 6, 2, 2, 0, 0, time.UTC))
+	require.NotNil(t, stored.Status.LastSuccessfulTime)
+	assert.True(t, time.Date(2025, 3, 6, 2, 0, 0, 0, time.UTC).Equal(stored.Status.LastSuccessfulTime.Time))
+	assert.Nil(t, stored.Status.LastFailure)
+
+	// A failed snapshot fails its run
+	evaluateAt(time.Date(2025, 3, 7, 2, 0, 30, 0, time.UTC))
+	setSnapshotPhase(t, s, v1alpha1.ResourcePhaseFailed, "quota exceeded")
+	stored = evaluateAt(time.Date(2025, 3, 7, 2, 1, 0, 0, time.UTC))
+	require.NotNil(t, stored.Status.LastFailure)
+	assert.True(t, time.Date(2025, 3, 7, 2, 0, 0, 0, time.UTC).Equal(stored.Status.LastFailure.Time.Time))
+	assert.Contains(t, stored.Status.LastFailure.Message, "quota exceeded")
+	assert.True(t, time.Date(2025, 3, 6, 2, 0, 0, 0, time.UTC).Equal(stored.Status.LastSuccessfulTime.Time))
+
+	// Only the last 2 taken snapshots are kept, the failed one is deleted once a later one is taken
+	for day := 8; day <= 9; day++ {
+		evaluateAt(time.Date(2025, 3, day, 2, 0, 30, 0, time.UTC))
+		setSnapshotPhase(t, s, v1alpha1.ResourcePhaseCreated, "")
+	}
+	stored = evaluateAt(time.Date(2025, 3, 9, 2, 1, 0, 0, time.UTC))
+	var names []string
+	for _, snapshot := range snapshotsOf(t, s) {
+		names = append(names, snapshot.Name)
+	}
+	assert.ElementsMatch(t, []string{"data-daily-b64e877e-202503080200", "data-daily-b64e877e-202503090200"}, names)
+	assert.Equal(t, int32(2), stored.Status.Snapshots)
+	assert.True(t, time.Date(2025, 3, 9, 2, 0, 0, 0, time.UTC).Equal(stored.Status.LastSuccessfulTime.Time))
+}
+
+func TestSnapshotSchedulerEvaluate_NotCreated(t *testing.T) {
+	policy := &v1alpha1.SnapshotPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "prod"},
+		Spec: v1alpha1.SnapshotPolicySpec{
+			Schedule:  "0 2 * * *",
+			Retention: v1alpha1.SnapshotRetention{KeepDays: 7},
+		},
+	}
+	s := newTestSnapshotScheduler(t, policy, newBlockStorage("data", "", nil))
+	s.now = func() time.Time { return time.Date(2025, 3, 6, 2, 0, 30, 0, time.UTC) }
+
+	require.NoError(t, s.Evaluate(context.Background(), policy))
+	assert.Empty(t, snapshotsOf(t, s))
+	require.NotNil(t, policy.Status.LastFailure)
+	assert.Contains(t, policy.Status.LastFailure.Message, "BlockStorage data isn't created yet")
+}
+
+func TestSnapshotSchedulerEvaluate_NameTaken(t *testing.T) {
+	due := time.Date(2025, 3, 6, 2, 0, 0, 0, time.UTC)
+	policy := &v1alpha1.SnapshotPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "prod"},
+		Spec: v1alpha1.SnapshotPolicySpec{
+			Schedule:  "0 2 * * *",
+			Retention: v1alpha1.SnapshotRetention{KeepLast: 7},
+		},
+	}
+	taken := &v1alpha1.BlockStorageSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: snapshotName("prod", "data", "daily", due), Namespace: "prod"},
+		Spec:       v1alpha1.BlockStorageSnapshotSpec{BlockStorageReference: v1alpha1.ResourceReference{Name: "other"}},
+	}
+	s := newTestSnapshotScheduler(t, policy, newBlockStorage("data", "bs-data", nil), taken)
+	s.now = func() time.Time { return due.Add(30 * time.Second) }
+
+	require.NoError(t, s.Evaluate(context.Background(), policy))
+	require.NotNil(t, policy.Status.LastFailure)
+	assert.Contains(t, policy.Status.LastFailure.Message, "already exists and wasn't taken by the policy")
+}
+
+func TestSnapshotSchedulerEvaluate_Invalid(t *testing.T) {
+	policy := &v1alpha1.SnapshotPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "prod"},
+		Spec: v1alpha1.SnapshotPolicySpec{
+			Schedule:  "0 2 * *",
+			Retention: v1alpha1.SnapshotRetention{KeepLast: 7},
+		},
+	}
+	s := newTestSnapshotScheduler(t, policy)
+
+	require.NoError(t, s.Evaluate(context.Background(), policy))
+	condition := apimeta.FindStatusCondition(policy.Status.Conditions, v1alpha1.ConditionTypeScheduleValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, "schedule")
+	assert.Nil(t, policy.Status.NextScheduleTime)
+}
+
+func TestPlanRetention(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	snapshot := func(name, volume string, daysAgo int, phase v1alpha1.ResourcePhase) v1alpha1.BlockStorageSnapshot {
+		return v1alpha1.BlockStorageSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{
+				v1alpha1.SnapshotScheduledAtAnnotation: now.AddDate(0, 0, -daysAgo).Format(time.RFC3339),
+			}},
+			Spec:   v1alpha1.BlockStorageSnapshotSpec{BlockStorageReference: v1alpha1.ResourceReference{Name: volume, Namespace: "prod"}},
+			Status: v1alpha1.BlockStorageSnapshotStatus{ResourceStatus: v1alpha1.ResourceStatus{Phase: phase}},
+		}
+	}
+	const (
+		created = v1alpha1.ResourcePhaseCreated
+		failed  = v1alpha1.ResourcePhaseFailed
+	)
+	snapshots := []v1alpha1.BlockStorageSnapshot{
+		snapshot("data-0", "data", 0, v1alpha1.ResourcePhaseCreating),
+		snapshot("data-1", "data", 1, created),
+		snapshot("data-2", "data", 2, failed),
+		snapshot("data-3", "data", 3, created),
+		snapshot("data-5", "data", 5, created),
+		snapshot("data-9", "data", 9, created),
+		snapshot("logs-20", "logs", 20, created),
+		snapshot("logs-30", "logs", 30, failed),
+	}
+
+	tests := []struct {
+		name      string
+		retention v1alpha1.SnapshotRetention
+		deleted   bool
+		want      []string
+	}{
+		{name: "keep last", retention: v1alpha1.SnapshotRetention{KeepLast: 2},
+			want: []string{"data-2", "data-5", "data-9", "logs-30"}},
+		{name: "keep days", retention: v1alpha1.SnapshotRetention{KeepDays: 4},
+			want: []string{"data-2", "data-5", "data-9", "logs-30"}},
+		{name: "either rule keeps", retention: v1alpha1.SnapshotRetention{KeepLast: 1, KeepDays: 6},
+			want: []string{"data-2", "data-9", "logs-30"}},
+		{name: "latest taken always kept", retention: v1alpha1.SnapshotRetention{KeepDays: 1},
+			want: []string{"data-2", "data-3", "data-5", "data-9", "logs-30"}},
+		{name: "latest taken of a deleted block storage not kept", retention: v1alpha1.SnapshotRetention{KeepDays: 1}, deleted: true,
+			want: []string{"data-2", "data-3", "data-5", "data-9", "logs-20", "logs-30"}},
+		{name: "keep last of a deleted block storage not applied", retention: v1alpha1.SnapshotRetention{KeepLast: 5}, deleted: true,
+			want: []string{"data-2", "logs-20", "logs-30"}},
+		{name: "keep days of a deleted block storage not applied", retention: v1alpha1.SnapshotRetention{KeepDays: 60}, deleted: true,
+			want: []string{"data-2", "logs-20", "logs-30"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := map[string]bool{"prod/data/": true, "prod/logs/": !tt.deleted}
+			var names []string
+			for _, expired := range planRetention(snapshots, tt.retention, live, now) {
+				names = append(names, expired.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestSnapshotName(t *testing.T) {
+	due := time.Date(2025, 3, 6, 2, 0, 0, 0, time.UTC)
+	assert.Equal(t, "data-daily-b64e877e-202503060200", snapshotName("prod", "data", "daily", due))
+	assert.NotEqual(t, snapshotName("prod", "db", "prod-daily", due), snapshotName("prod", "db-prod", "daily", due))
+	assert.NotEqual(t, snapshotName("prod", "data", "daily", due), snapshotName("staging", "data", "daily", due))
+
+	name := snapshotName("prod", strings.Repeat("a", 250), "daily", due)
+	assert.Len(t, name, 253)
+	assert.True(t, strings.HasSuffix(name, "a-82b0ce1b-202503060200"), name)
+}
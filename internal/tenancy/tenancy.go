@@ -0,0 +1,156 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=tenantbindings,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// ReasonTenantNotBound is the reason of the events recording a denied tenant
+const ReasonTenantNotBound = "TenantNotBound"
+
+// NotBoundError is returned when a namespace uses a tenant no TenantBinding binds it to
+type NotBoundError struct {
+	Namespace string
+	Tenant    string
+}
+
+func (e *NotBoundError) Error() string {
+	return fmt.Sprintf("tenant %q is not bound to namespace %q by any TenantBinding", e.Tenant, e.Namespace)
+}
+
+// ReasonProviderConfigNotAllowed is the reason of the events recording a denied ProviderConfig
+const ReasonProviderConfigNotAllowed = "ProviderConfigNotAllowed"
+
+// ProviderConfigNotAllowedError is returned when a namespace references a ProviderConfig that doesn't allow it
+type ProviderConfigNotAllowedError struct {
+	Namespace      string
+	ProviderConfig string
+}
+
+func (e *ProviderConfigNotAllowedError) Error() string {
+	return fmt.Sprintf("ProviderConfig %q does not allow namespace %q in spec.allowedNamespaces", e.ProviderConfig, e.Namespace)
+}
+
+// Policy restricts the tenants each namespace may use to the ones bound by the TenantBindings
+type Policy struct {
+	client.Reader
+	Recorder record.EventRecorder
+	// Enabled enforces the TenantBindings, every tenant is allowed otherwise
+	Enabled bool
+}
+
+// NewPolicy creates a Policy reading the TenantBindings and Namespaces from the manager cache
+func NewPolicy(mgr ctrl.Manager, recorderName string, enabled bool) *Policy {
+	return &Policy{
+		Reader:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor(recorderName),
+		Enabled:  enabled,
+	}
+}
+
+// Authorize returns a *NotBoundError when the namespace of obj may not use tenant, recording
+// the attempt as a Warning event on obj. An empty tenant is always allowed, as well as any
+// tenant when p is nil or disabled.
+func (p *Policy) Authorize(ctx context.Context, obj client.Object, tenant string) error {
+	if p == nil || !p.Enabled || tenant == "" {
+		return nil
+	}
+
+	bound, err := p.IsBound(ctx, obj.GetNamespace(), tenant)
+	if err != nil {
+		return err
+	}
+	if bound {
+		return nil
+	}
+
+	notBound := &NotBoundError{Namespace: obj.GetNamespace(), Tenant: tenant}
+	message := notBound.Error()
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		message = fmt.Sprintf("%s, requested by %s", message, req.UserInfo.Username)
+	}
+	ctrl.Log.Info("Denied tenant", "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Namespace", obj.GetNamespace(), "Name", obj.GetName(), "message", message)
+	if p.Recorder != nil {
+		p.Recorder.Event(obj, corev1.EventTypeWarning, ReasonTenantNotBound, message)
+	}
+	return notBound
+}
+
+// IsBound reports whether a TenantBinding binds tenant to namespace
+func (p *Policy) IsBound(ctx context.Context, namespace, tenant string) (bool, error) {
+	bindings := &v1alpha1.TenantBindingList{}
+	if err := p.List(ctx, bindings); err != nil {
+		return false, fmt.Errorf("failed to list TenantBindings: %w", err)
+	}
+
+	var namespaceLabels labels.Set
+	namespaceLoaded := false
+	for _, binding := range bindings.Items {
+		if !slices.Contains(binding.Spec.Tenants, tenant) {
+			continue
+		}
+		if slices.Contains(binding.Spec.Namespaces, namespace) {
+			return true, nil
+		}
+		if binding.Spec.NamespaceSelector == nil {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(binding.Spec.NamespaceSelector)
+		if err != nil {
+			ctrl.Log.Error(err, "Invalid namespaceSelector", "TenantBinding", binding.Name)
+			continue
+		}
+		if !namespaceLoaded {
+			ns := &corev1.Namespace{}
+			if err := p.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+				return false, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+			}
+			namespaceLabels = labels.Set(ns.Labels)
+			namespaceLoaded = true
+		}
+		if selector.Matches(namespaceLabels) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AuthorizeProviderConfig returns a *ProviderConfigNotAllowedError when pc doesn't allow the resources
+// of namespace to reference it, reading the labels of namespace with r
+func AuthorizeProviderConfig(ctx context.Context, r client.Reader, pc *v1alpha1.ProviderConfig, namespace string) error {
+	allowed := pc.Spec.AllowedNamespaces
+	if slices.Contains(allowed.Namespaces, namespace) {
+		return nil
+	}
+	if allowed.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(allowed.NamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("invalid allowedNamespaces.namespaceSelector of ProviderConfig %s: %w", pc.Name, err)
+		}
+		ns := &corev1.Namespace{}
+		if err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+			return fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			return nil
+		}
+	}
+	return &ProviderConfigNotAllowedError{Namespace: namespace, ProviderConfig: pc.Name}
+}
@@ -31,3 +31,9 @@ func CalculateVolumeChanges(desiredVolumeIDs, currentVolumeIDs []string) (toAtta
 
 	return toAttach, toDetach
 }
+
+// SameIDs reports whether a and b hold the same IDs, regardless of their order
+func SameIDs(a, b []string) bool {
+	added, removed := CalculateVolumeChanges(a, b)
+	return len(added) == 0 && len(removed) == 0
+}
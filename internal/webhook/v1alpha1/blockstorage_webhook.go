@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var blockstoragelog = logf.Log.WithName("blockstorage-resource")
+
+// SetupBlockStorageWebhookWithManager registers the webhook for BlockStorage in the manager.
+func SetupBlockStorageWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.BlockStorage{}).
+		WithValidator(&BlockStorageCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&BlockStorageCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-blockstorage,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=blockstorages,verbs=create,versions=v1alpha1,name=mblockstorage-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BlockStorageCustomDefaulter fills the empty fields of the BlockStorage resource from the
+// ArubaDefaults of its namespace when it is created.
+type BlockStorageCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &BlockStorageCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type BlockStorage.
+func (d *BlockStorageCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	blockStorage, ok := obj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return fmt.Errorf("expected a BlockStorage object but got %T", obj)
+	}
+	blockstoragelog.Info("Defaulting for BlockStorage", "name", blockStorage.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, blockStorage))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &blockStorage.Spec.Tenant)
+	defaults.location(specPath.Child("location"), &blockStorage.Spec.Location)
+	defaults.dataCenter(specPath.Child("dataCenter"), &blockStorage.Spec.DataCenter)
+	defaults.projectReference(specPath.Child("projectReference"), &blockStorage.Spec.ProjectReference)
+	if blockStorage.Spec.DataSource != nil {
+		defaults.referenceNamespace(specPath.Child("dataSource"), &blockStorage.Spec.DataSource.ResourceReference)
+	}
+	defaults.record(blockStorage)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-blockstorage,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=blockstorages,verbs=create;update,versions=v1alpha1,name=vblockstorage-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BlockStorageCustomValidator validates the BlockStorage resource when it is created or updated.
+type BlockStorageCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &BlockStorageCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type BlockStorage.
+func (v *BlockStorageCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	blockStorage, ok := obj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorage object but got %T", obj)
+	}
+	blockstoragelog.Info("Validation for BlockStorage upon creation", "name", blockStorage.GetName())
+
+	return v.validateBlockStorage(ctx, blockStorage, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type BlockStorage.
+func (v *BlockStorageCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	blockStorage, ok := newObj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorage object for the newObj but got %T", newObj)
+	}
+	oldBlockStorage, ok := oldObj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorage object for the oldObj but got %T", oldObj)
+	}
+	blockstoragelog.Info("Validation for BlockStorage upon update", "name", blockStorage.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldBlockStorage.Spec, blockStorage.Spec) {
+		return nil, nil
+	}
+
+	return v.validateBlockStorage(ctx, blockStorage, oldBlockStorage)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type BlockStorage.
+func (v *BlockStorageCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateBlockStorage validates the block storage spec, comparing it with old on update
+func (v *BlockStorageCustomValidator) validateBlockStorage(ctx context.Context, blockStorage, old *arubacloudcomv1alpha1.BlockStorage) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, blockStorage.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("location"), old.Spec.Location, blockStorage.Spec.Location)...)
+		errs = append(errs, validateImmutable(specPath.Child("dataCenter"), old.Spec.DataCenter, blockStorage.Spec.DataCenter)...)
+		errs = append(errs, validateImmutable(specPath.Child("bootable"), old.Spec.Bootable, blockStorage.Spec.Bootable)...)
+		errs = append(errs, validateImmutable(specPath.Child("image"), old.Spec.Image, blockStorage.Spec.Image)...)
+		errs = append(errs, validateImmutable(specPath.Child("dataSource"), old.Spec.DataSource, blockStorage.Spec.DataSource)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, blockStorage.Spec.ProjectReference)...)
+		errs = append(errs, validateSizeGb(specPath.Child("sizeGb"), old, blockStorage)...)
+	}
+
+	if blockStorage.Spec.Bootable && blockStorage.Spec.Image == "" {
+		errs = append(errs, field.Required(specPath.Child("image"), "image is required for a bootable block storage"))
+	}
+	if blockStorage.Spec.DataSource != nil && blockStorage.Spec.Image != "" {
+		errs = append(errs, field.Forbidden(specPath.Child("dataSource"), "dataSource can't be set with image"))
+	}
+
+	refs := newReferenceValidator(v.Client, blockStorage.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", blockStorage.Spec.ProjectReference, project)
+	sourceSizeGb := validateDataSource(ctx, refs, specPath.Child("dataSource"), blockStorage.Spec.DataSource)
+	errs = append(errs, refs.errs...)
+	if old == nil && blockStorage.Spec.SizeGb < sourceSizeGb {
+		errs = append(errs, field.Invalid(specPath.Child("sizeGb"), blockStorage.Spec.SizeGb,
+			fmt.Sprintf("the size must be at least the %dGB of the dataSource", sourceSizeGb)))
+	}
+	errs = append(errs, validateTenant(ctx, v.Tenants, blockStorage, specPath.Child("tenant"), blockStorage.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, blockStorage, specPath.Child("providerConfigRef"), blockStorage.Spec.ProviderConfigRef)...)
+
+	warnings := refs.warnings
+	if old != nil && blockStorage.Spec.SizeGb > old.Spec.SizeGb {
+		warnings = append(warnings, v.expansionWarnings(ctx, old)...)
+	}
+
+	return warnings, invalidError("BlockStorage", blockStorage.Name, errs)
+}
+
+// validateDataSource validates the reference of ds and returns the size of the source, or 0 when
+// it isn't known yet. The controller checks the size again with the remote API.
+func validateDataSource(ctx context.Context, refs *referenceValidator, path *field.Path, ds *arubacloudcomv1alpha1.BlockStorageDataSource) int32 {
+	if ds == nil {
+		return 0
+	}
+	switch ds.Kind {
+	case arubacloudcomv1alpha1.BlockStorageDataSourceSnapshot:
+		snapshot := &arubacloudcomv1alpha1.BlockStorageSnapshot{}
+		refs.validate(ctx, path, string(ds.Kind), ds.ResourceReference, snapshot)
+		return snapshot.Status.SizeGb
+	case arubacloudcomv1alpha1.BlockStorageDataSourceBlockStorage:
+		source := &arubacloudcomv1alpha1.BlockStorage{}
+		refs.validate(ctx, path, string(ds.Kind), ds.ResourceReference, source)
+		return max(source.Spec.SizeGb, source.Status.CapacityGb)
+	default:
+		refs.errs = append(refs.errs, field.NotSupported(path.Child("kind"), ds.Kind,
+			[]arubacloudcomv1alpha1.BlockStorageDataSourceKind{arubacloudcomv1alpha1.BlockStorageDataSourceSnapshot, arubacloudcomv1alpha1.BlockStorageDataSourceBlockStorage}))
+		return 0
+	}
+}
+
+// validateSizeGb forbids shrinking the block storage below its previous size or the capacity reported by Aruba Cloud
+func validateSizeGb(path *field.Path, old, blockStorage *arubacloudcomv1alpha1.BlockStorage) field.ErrorList {
+	minimum := max(old.Spec.SizeGb, old.Status.CapacityGb)
+	if blockStorage.Spec.SizeGb >= minimum {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, blockStorage.Spec.SizeGb,
+		fmt.Sprintf("a block storage can't be shrunk, the size must be at least %dGB", minimum))}
+}
+
+// expansionWarnings warns about the action needed on the cloud servers attaching the block storage once expanded
+func (v *BlockStorageCustomValidator) expansionWarnings(ctx context.Context, blockStorage *arubacloudcomv1alpha1.BlockStorage) admission.Warnings {
+	booting, attaching, err := reconciler.CloudServersAttaching(ctx, v.Client, blockStorage.Status.ResourceID)
+	if err != nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	if len(booting) > 0 {
+		warnings = append(warnings, fmt.Sprintf("CloudServers %s boot from this block storage: reboot them once expanded to grow the root filesystem", strings.Join(booting, ", ")))
+	}
+	if len(attaching) > 0 {
+		warnings = append(warnings, fmt.Sprintf("CloudServers %s attach this block storage: grow its partition and filesystem once expanded", strings.Join(attaching, ", ")))
+	}
+	return warnings
+}
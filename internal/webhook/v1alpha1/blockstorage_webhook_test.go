@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("BlockStorage Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.BlockStorage
+		oldObj    *arubacloudcomv1alpha1.BlockStorage
+		validator BlockStorageCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.BlockStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-block-storage",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.BlockStorageSpec{
+				Tenant:           "test-tenant",
+				Location:         arubacloudcomv1alpha1.Location{Value: "ITBG-Bergamo"},
+				SizeGb:           20,
+				BillingPeriod:    "Hour",
+				DataCenter:       "ITBG-1",
+				Bootable:         true,
+				Image:            "LU22-001",
+				ProjectReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = BlockStorageCustomValidator{
+			Client: newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+			),
+		}
+	})
+
+	Context("When creating BlockStorage under Validating Webhook", func() {
+		It("Should admit a valid block storage", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny a bootable block storage without image", func() {
+			obj.Spec.Image = ""
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.image")))
+		})
+
+		It("Should deny a data source with an image", func() {
+			obj.Spec.DataSource = &arubacloudcomv1alpha1.BlockStorageDataSource{
+				Kind:              arubacloudcomv1alpha1.BlockStorageDataSourceSnapshot,
+				ResourceReference: arubacloudcomv1alpha1.ResourceReference{ID: "snap-1"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.dataSource")))
+		})
+	})
+
+	Context("When creating BlockStorage from a data source under Validating Webhook", func() {
+		BeforeEach(func() {
+			obj.Spec.Bootable = false
+			obj.Spec.Image = ""
+			validator.Client = newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+				&arubacloudcomv1alpha1.BlockStorageSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+					Status:     arubacloudcomv1alpha1.BlockStorageSnapshotStatus{SizeGb: 50},
+				},
+				&arubacloudcomv1alpha1.BlockStorage{
+					ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "default"},
+					Spec:       arubacloudcomv1alpha1.BlockStorageSpec{SizeGb: 40},
+					Status:     arubacloudcomv1alpha1.BlockStorageStatus{CapacityGb: 100},
+				},
+			)
+		})
+
+		DescribeTable("Should check the size against the source",
+			func(kind arubacloudcomv1alpha1.BlockStorageDataSourceKind, name string, sizeGb int32, allowed bool) {
+				obj.Spec.SizeGb = sizeGb
+				obj.Spec.DataSource = &arubacloudcomv1alpha1.BlockStorageDataSource{
+					Kind:              kind,
+					ResourceReference: arubacloudcomv1alpha1.ResourceReference{Name: name, Namespace: "default"},
+				}
+				_, err := validator.ValidateCreate(ctx, obj)
+				if allowed {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring("spec.sizeGb")))
+				}
+			},
+			Entry("snapshot of the same size", arubacloudcomv1alpha1.BlockStorageDataSourceSnapshot, "nightly", int32(50), true),
+			Entry("snapshot larger than the size", arubacloudcomv1alpha1.BlockStorageDataSourceSnapshot, "nightly", int32(20), false),
+			Entry("block storage larger than the size", arubacloudcomv1alpha1.BlockStorageDataSourceBlockStorage, "production", int32(60), false),
+			Entry("larger than the block storage", arubacloudcomv1alpha1.BlockStorageDataSourceBlockStorage, "production", int32(200), true),
+		)
+
+		It("Should warn about a source that doesn't exist yet", func() {
+			obj.Spec.DataSource = &arubacloudcomv1alpha1.BlockStorageDataSource{
+				Kind:              arubacloudcomv1alpha1.BlockStorageDataSourceSnapshot,
+				ResourceReference: arubacloudcomv1alpha1.ResourceReference{Name: "missing", Namespace: "default"},
+			}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("BlockStorageSnapshot default/missing does not exist yet")))
+		})
+
+		It("Should deny a change of the data source", func() {
+			obj.Spec.DataSource = &arubacloudcomv1alpha1.BlockStorageDataSource{
+				Kind:              arubacloudcomv1alpha1.BlockStorageDataSourceSnapshot,
+				ResourceReference: arubacloudcomv1alpha1.ResourceReference{Name: "nightly", Namespace: "default"},
+			}
+			oldObj = obj.DeepCopy()
+			obj.Spec.DataSource.Name = "weekly"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.dataSource")))
+		})
+	})
+
+	Context("When updating BlockStorage under Validating Webhook", func() {
+		DescribeTable("Should deny changes to fields the API can't update",
+			func(mutate func(*arubacloudcomv1alpha1.BlockStorage), path string) {
+				mutate(obj)
+				_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+				Expect(err).To(MatchError(ContainSubstring(path)))
+			},
+			Entry("location", func(b *arubacloudcomv1alpha1.BlockStorage) { b.Spec.Location.Value = "ITMI-Milano" }, "spec.location"),
+			Entry("data center", func(b *arubacloudcomv1alpha1.BlockStorage) { b.Spec.DataCenter = "ITBG-2" }, "spec.dataCenter"),
+			Entry("bootable", func(b *arubacloudcomv1alpha1.BlockStorage) { b.Spec.Bootable = false }, "spec.bootable"),
+			Entry("project", func(b *arubacloudcomv1alpha1.BlockStorage) { b.Spec.ProjectReference.Name = "other" }, "spec.projectReference"),
+		)
+
+		It("Should admit a size increase", func() {
+			obj.Spec.SizeGb = 40
+			warnings, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny a size decrease", func() {
+			obj.Spec.SizeGb = 10
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.sizeGb")))
+		})
+
+		It("Should deny a size below the capacity", func() {
+			oldObj.Status.CapacityGb = 50
+			obj.Spec.SizeGb = 40
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("at least 50GB")))
+		})
+
+		It("Should warn about the cloud servers attaching the block storage", func() {
+			oldObj.Status.ResourceID = "bs-123"
+			validator.Client = newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+				&arubacloudcomv1alpha1.CloudServer{
+					ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+					Status:     arubacloudcomv1alpha1.CloudServerStatus{BootVolumeID: "bs-123"},
+				},
+				&arubacloudcomv1alpha1.CloudServer{
+					ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "default"},
+					Status:     arubacloudcomv1alpha1.CloudServerStatus{DataVolumeIDs: []string{"bs-123"}},
+				},
+			)
+			obj.Spec.SizeGb = 40
+			warnings, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring("default/web-1 boot from this block storage"),
+				ContainSubstring("default/db-1 attach this block storage"),
+			))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var blockstoragesnapshotlog = logf.Log.WithName("blockstoragesnapshot-resource")
+
+// SetupBlockStorageSnapshotWebhookWithManager registers the webhook for BlockStorageSnapshot in the manager.
+func SetupBlockStorageSnapshotWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.BlockStorageSnapshot{}).
+		WithValidator(&BlockStorageSnapshotCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&BlockStorageSnapshotCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-blockstoragesnapshot,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=blockstoragesnapshots,verbs=create,versions=v1alpha1,name=mblockstoragesnapshot-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BlockStorageSnapshotCustomDefaulter fills the empty fields of the BlockStorageSnapshot resource from the
+// ArubaDefaults of its namespace when it is created.
+type BlockStorageSnapshotCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &BlockStorageSnapshotCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type BlockStorageSnapshot.
+func (d *BlockStorageSnapshotCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	snapshot, ok := obj.(*arubacloudcomv1alpha1.BlockStorageSnapshot)
+	if !ok {
+		return fmt.Errorf("expected a BlockStorageSnapshot object but got %T", obj)
+	}
+	blockstoragesnapshotlog.Info("Defaulting for BlockStorageSnapshot", "name", snapshot.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, snapshot))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &snapshot.Spec.Tenant)
+	defaults.location(specPath.Child("location"), &snapshot.Spec.Location)
+	defaults.projectReference(specPath.Child("projectReference"), &snapshot.Spec.ProjectReference)
+	defaults.referenceNamespace(specPath.Child("blockStorageReference"), &snapshot.Spec.BlockStorageReference)
+	defaults.record(snapshot)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-blockstoragesnapshot,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=blockstoragesnapshots,verbs=create;update,versions=v1alpha1,name=vblockstoragesnapshot-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BlockStorageSnapshotCustomValidator validates the BlockStorageSnapshot resource when it is created or updated.
+type BlockStorageSnapshotCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &BlockStorageSnapshotCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type BlockStorageSnapshot.
+func (v *BlockStorageSnapshotCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	snapshot, ok := obj.(*arubacloudcomv1alpha1.BlockStorageSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorageSnapshot object but got %T", obj)
+	}
+	blockstoragesnapshotlog.Info("Validation for BlockStorageSnapshot upon creation", "name", snapshot.GetName())
+
+	return v.validateBlockStorageSnapshot(ctx, snapshot, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type BlockStorageSnapshot.
+func (v *BlockStorageSnapshotCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	snapshot, ok := newObj.(*arubacloudcomv1alpha1.BlockStorageSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorageSnapshot object for the newObj but got %T", newObj)
+	}
+	oldBlockStorageSnapshot, ok := oldObj.(*arubacloudcomv1alpha1.BlockStorageSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorageSnapshot object for the oldObj but got %T", oldObj)
+	}
+	blockstoragesnapshotlog.Info("Validation for BlockStorageSnapshot upon update", "name", snapshot.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldBlockStorageSnapshot.Spec, snapshot.Spec) {
+		return nil, nil
+	}
+
+	return v.validateBlockStorageSnapshot(ctx, snapshot, oldBlockStorageSnapshot)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type BlockStorageSnapshot.
+func (v *BlockStorageSnapshotCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateBlockStorageSnapshot validates the snapshot spec, comparing it with old on update
+func (v *BlockStorageSnapshotCustomValidator) validateBlockStorageSnapshot(ctx context.Context, snapshot, old *arubacloudcomv1alpha1.BlockStorageSnapshot) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, snapshot.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("location"), old.Spec.Location, snapshot.Spec.Location)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, snapshot.Spec.ProjectReference)...)
+		errs = append(errs, validateImmutable(specPath.Child("blockStorageReference"), old.Spec.BlockStorageReference, snapshot.Spec.BlockStorageReference)...)
+	}
+
+	refs := newReferenceValidator(v.Client, snapshot.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", snapshot.Spec.ProjectReference, project)
+	refs.validate(ctx, specPath.Child("blockStorageReference"), "BlockStorage", snapshot.Spec.BlockStorageReference, &arubacloudcomv1alpha1.BlockStorage{})
+	errs = append(errs, refs.errs...)
+	errs = append(errs, validateTenant(ctx, v.Tenants, snapshot, specPath.Child("tenant"), snapshot.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, snapshot, specPath.Child("providerConfigRef"), snapshot.Spec.ProviderConfigRef)...)
+
+	return refs.warnings, invalidError("BlockStorageSnapshot", snapshot.Name, errs)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("BlockStorageSnapshot Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.BlockStorageSnapshot
+		oldObj    *arubacloudcomv1alpha1.BlockStorageSnapshot
+		validator BlockStorageSnapshotCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.BlockStorageSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-snapshot",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.BlockStorageSnapshotSpec{
+				Tenant:                "test-tenant",
+				Location:              arubacloudcomv1alpha1.Location{Value: "ITBG-Bergamo"},
+				BlockStorageReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-volume", Namespace: "default"},
+				ProjectReference:      arubacloudcomv1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = BlockStorageSnapshotCustomValidator{
+			Client: newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+				&arubacloudcomv1alpha1.BlockStorage{ObjectMeta: metav1.ObjectMeta{Name: "test-volume", Namespace: "default"}},
+			),
+		}
+	})
+
+	Context("When creating BlockStorageSnapshot under Validating Webhook", func() {
+		It("Should admit a valid snapshot", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should warn when the referenced block storage does not exist yet", func() {
+			obj.Spec.BlockStorageReference.Name = "missing-volume"
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("BlockStorage default/missing-volume does not exist yet")))
+		})
+	})
+
+	Context("When updating BlockStorageSnapshot under Validating Webhook", func() {
+		It("Should deny a block storage change", func() {
+			obj.Spec.BlockStorageReference.Name = "other-volume"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.blockStorageReference")))
+		})
+
+		It("Should admit a tags change", func() {
+			obj.Spec.Tags = []string{"nightly"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var cloudserverlog = logf.Log.WithName("cloudserver-resource")
+
+// SetupCloudServerWebhookWithManager registers the webhook for CloudServer in the manager.
+func SetupCloudServerWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.CloudServer{}).
+		WithValidator(&CloudServerCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&CloudServerCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-cloudserver,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=cloudservers,verbs=create,versions=v1alpha1,name=mcloudserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CloudServerCustomDefaulter fills the empty fields of the CloudServer resource from the
+// ArubaDefaults of its namespace when it is created.
+type CloudServerCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &CloudServerCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type CloudServer.
+func (d *CloudServerCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	cloudServer, ok := obj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return fmt.Errorf("expected a CloudServer object but got %T", obj)
+	}
+	cloudserverlog.Info("Defaulting for CloudServer", "name", cloudServer.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, cloudServer))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &cloudServer.Spec.Tenant)
+	defaults.location(specPath.Child("location"), &cloudServer.Spec.Location)
+	defaults.dataCenter(specPath.Child("dataCenter"), &cloudServer.Spec.DataCenter)
+	defaults.projectReference(specPath.Child("projectReference"), &cloudServer.Spec.ProjectReference)
+	defaults.referenceNamespace(specPath.Child("vpcReference"), &cloudServer.Spec.VpcReference)
+	defaults.referenceNamespace(specPath.Child("elasticIpReference"), cloudServer.Spec.ElasticIpReference)
+	defaults.referenceNamespace(specPath.Child("keyPairReference"), &cloudServer.Spec.KeyPairReference)
+	defaults.referenceNamespace(specPath.Child("bootVolumeReference"), &cloudServer.Spec.BootVolumeReference)
+	for i := range cloudServer.Spec.SubnetReferences {
+		defaults.referenceNamespace(specPath.Child("subnetReferences").Index(i), &cloudServer.Spec.SubnetReferences[i])
+	}
+	for i := range cloudServer.Spec.SecurityGroupReferences {
+		defaults.referenceNamespace(specPath.Child("securityGroupReferences").Index(i), &cloudServer.Spec.SecurityGroupReferences[i])
+	}
+	for i := range cloudServer.Spec.DataVolumeReferences {
+		defaults.referenceNamespace(specPath.Child("dataVolumeReferences").Index(i), &cloudServer.Spec.DataVolumeReferences[i])
+	}
+	defaults.record(cloudServer)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-cloudserver,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=cloudservers,verbs=create;update,versions=v1alpha1,name=vcloudserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CloudServerCustomValidator validates the CloudServer resource when it is created or updated.
+type CloudServerCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &CloudServerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type CloudServer.
+func (v *CloudServerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cloudServer, ok := obj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a CloudServer object but got %T", obj)
+	}
+	cloudserverlog.Info("Validation for CloudServer upon creation", "name", cloudServer.GetName())
+
+	return v.validateCloudServer(ctx, cloudServer, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type CloudServer.
+func (v *CloudServerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	cloudServer, ok := newObj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a CloudServer object for the newObj but got %T", newObj)
+	}
+	oldCloudServer, ok := oldObj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a CloudServer object for the oldObj but got %T", oldObj)
+	}
+	cloudserverlog.Info("Validation for CloudServer upon update", "name", cloudServer.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldCloudServer.Spec, cloudServer.Spec) {
+		return nil, nil
+	}
+
+	return v.validateCloudServer(ctx, cloudServer, oldCloudServer)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type CloudServer.
+func (v *CloudServerCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateCloudServer validates the cloud server spec, comparing it with old on update
+func (v *CloudServerCustomValidator) validateCloudServer(ctx context.Context, cloudServer, old *arubacloudcomv1alpha1.CloudServer) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, cloudServer.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("location"), old.Spec.Location, cloudServer.Spec.Location)...)
+		errs = append(errs, validateImmutable(specPath.Child("dataCenter"), old.Spec.DataCenter, cloudServer.Spec.DataCenter)...)
+		errs = append(errs, validateImmutable(specPath.Child("vpcReference"), old.Spec.VpcReference, cloudServer.Spec.VpcReference)...)
+		errs = append(errs, validateImmutable(specPath.Child("bootVolumeReference"), old.Spec.BootVolumeReference, cloudServer.Spec.BootVolumeReference)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, cloudServer.Spec.ProjectReference)...)
+	}
+
+	refs := newReferenceValidator(v.Client, cloudServer.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", cloudServer.Spec.ProjectReference, project)
+	refs.validate(ctx, specPath.Child("vpcReference"), "Vpc", cloudServer.Spec.VpcReference, &arubacloudcomv1alpha1.Vpc{})
+	refs.validate(ctx, specPath.Child("keyPairReference"), "KeyPair", cloudServer.Spec.KeyPairReference, &arubacloudcomv1alpha1.KeyPair{})
+	refs.validate(ctx, specPath.Child("bootVolumeReference"), "BlockStorage", cloudServer.Spec.BootVolumeReference, &arubacloudcomv1alpha1.BlockStorage{})
+	if cloudServer.Spec.ElasticIpReference != nil {
+		refs.validate(ctx, specPath.Child("elasticIpReference"), "ElasticIp", *cloudServer.Spec.ElasticIpReference, &arubacloudcomv1alpha1.ElasticIp{})
+	}
+	for i, ref := range cloudServer.Spec.SubnetReferences {
+		refs.validate(ctx, specPath.Child("subnetReferences").Index(i), "Subnet", ref, &arubacloudcomv1alpha1.Subnet{})
+	}
+	for i, ref := range cloudServer.Spec.SecurityGroupReferences {
+		refs.validate(ctx, specPath.Child("securityGroupReferences").Index(i), "SecurityGroup", ref, &arubacloudcomv1alpha1.SecurityGroup{})
+	}
+	for i, ref := range cloudServer.Spec.DataVolumeReferences {
+		if ref == cloudServer.Spec.BootVolumeReference {
+			errs = append(errs, field.Invalid(specPath.Child("dataVolumeReferences").Index(i), ref.String(), "the boot volume can't be attached as a data volume"))
+			continue
+		}
+		refs.validate(ctx, specPath.Child("dataVolumeReferences").Index(i), "BlockStorage", ref, &arubacloudcomv1alpha1.BlockStorage{})
+	}
+	if len(cloudServer.Spec.SecurityGroupReferences) == 0 && cloudServer.Spec.SecurityGroupSelector == nil {
+		errs = append(errs, field.Required(specPath.Child("securityGroupReferences"), "securityGroupReferences or securityGroupSelector must be set"))
+	}
+	selectorOptions := metav1validation.LabelSelectorValidationOptions{}
+	if cloudServer.Spec.SecurityGroupSelector != nil {
+		errs = append(errs, metav1validation.ValidateLabelSelector(cloudServer.Spec.SecurityGroupSelector, selectorOptions, specPath.Child("securityGroupSelector"))...)
+	}
+	if cloudServer.Spec.DataVolumeSelector != nil {
+		errs = append(errs, metav1validation.ValidateLabelSelector(cloudServer.Spec.DataVolumeSelector, selectorOptions, specPath.Child("dataVolumeSelector"))...)
+	}
+	errs = append(errs, refs.errs...)
+	errs = append(errs, validateTenant(ctx, v.Tenants, cloudServer, specPath.Child("tenant"), cloudServer.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, cloudServer, specPath.Child("providerConfigRef"), cloudServer.Spec.ProviderConfigRef)...)
+
+	return refs.warnings, invalidError("CloudServer", cloudServer.Name, errs)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("CloudServer Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.CloudServer
+		oldObj    *arubacloudcomv1alpha1.CloudServer
+		validator CloudServerCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.CloudServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-server",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.CloudServerSpec{
+				Tenant:                  "test-tenant",
+				Location:                arubacloudcomv1alpha1.Location{Value: "ITBG-Bergamo"},
+				DataCenter:              "ITBG-1",
+				FlavorName:              "CSO4A8",
+				VpcReference:            arubacloudcomv1alpha1.ResourceReference{Name: "test-vpc"},
+				KeyPairReference:        arubacloudcomv1alpha1.ResourceReference{Name: "test-keypair"},
+				SubnetReferences:        []arubacloudcomv1alpha1.ResourceReference{{Name: "test-subnet"}},
+				SecurityGroupReferences: []arubacloudcomv1alpha1.ResourceReference{{Name: "test-sg"}},
+				BootVolumeReference:     arubacloudcomv1alpha1.ResourceReference{Name: "test-boot"},
+				ProjectReference:        arubacloudcomv1alpha1.ResourceReference{Name: "test-project"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = CloudServerCustomValidator{
+			Client: newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+				&arubacloudcomv1alpha1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default"}},
+				&arubacloudcomv1alpha1.KeyPair{ObjectMeta: metav1.ObjectMeta{Name: "test-keypair", Namespace: "default"}},
+				&arubacloudcomv1alpha1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "test-subnet", Namespace: "default"}},
+				&arubacloudcomv1alpha1.SecurityGroup{ObjectMeta: metav1.ObjectMeta{Name: "test-sg", Namespace: "default"}},
+				&arubacloudcomv1alpha1.BlockStorage{ObjectMeta: metav1.ObjectMeta{Name: "test-boot", Namespace: "default"}},
+			),
+		}
+	})
+
+	Context("When creating CloudServer under Validating Webhook", func() {
+		It("Should admit a valid cloud server without warnings", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should warn for every referenced resource that does not exist yet", func() {
+			obj.Spec.SubnetReferences = append(obj.Spec.SubnetReferences, arubacloudcomv1alpha1.ResourceReference{Name: "missing-subnet"})
+			obj.Spec.DataVolumeReferences = []arubacloudcomv1alpha1.ResourceReference{{Name: "missing-volume"}}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring("spec.subnetReferences[1]: referenced Subnet default/missing-subnet"),
+				ContainSubstring("spec.dataVolumeReferences[0]: referenced BlockStorage default/missing-volume"),
+			))
+		})
+
+		It("Should deny the boot volume attached as a data volume", func() {
+			obj.Spec.DataVolumeReferences = []arubacloudcomv1alpha1.ResourceReference{obj.Spec.BootVolumeReference}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.dataVolumeReferences[0]")))
+		})
+
+		It("Should deny a reference with an invalid namespace", func() {
+			obj.Spec.KeyPairReference.Namespace = "Not_A_Namespace"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.keyPairReference.namespace")))
+		})
+
+		It("Should admit security groups and data volumes selected by labels", func() {
+			obj.Spec.SecurityGroupReferences = nil
+			obj.Spec.SecurityGroupSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}}
+			obj.Spec.DataVolumeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"attach-to": "web-1"}}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny a cloud server without security groups", func() {
+			obj.Spec.SecurityGroupReferences = nil
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.securityGroupReferences")))
+		})
+
+		It("Should deny an invalid label selector", func() {
+			obj.Spec.DataVolumeSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "attach-to", Operator: metav1.LabelSelectorOpIn},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.dataVolumeSelector.matchExpressions[0].values")))
+		})
+	})
+
+	Context("When updating CloudServer under Validating Webhook", func() {
+		It("Should deny a data center change", func() {
+			obj.Spec.DataCenter = "ITBG-2"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.dataCenter")))
+		})
+
+		It("Should deny a boot volume change", func() {
+			obj.Spec.BootVolumeReference.Name = "other-boot"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.bootVolumeReference")))
+		})
+
+		It("Should admit attaching a data volume", func() {
+			obj.Spec.DataVolumeReferences = []arubacloudcomv1alpha1.ResourceReference{{Name: "test-boot-2"}}
+			warnings, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+		})
+	})
+
+	Context("When creating CloudServer under Defaulting Webhook", func() {
+		It("Should fill the data center and the namespace of every reference", func() {
+			obj.Spec.DataCenter = ""
+			obj.Spec.ElasticIpReference = &arubacloudcomv1alpha1.ResourceReference{Name: "test-eip"}
+			obj.Spec.DataVolumeReferences = []arubacloudcomv1alpha1.ResourceReference{{Name: "test-data", Namespace: "storage"}}
+			defaulter := CloudServerCustomDefaulter{
+				Client: newFakeClient(&arubacloudcomv1alpha1.ArubaDefaults{
+					ObjectMeta: metav1.ObjectMeta{Name: arubacloudcomv1alpha1.ArubaDefaultsName, Namespace: "default"},
+					Spec:       arubacloudcomv1alpha1.ArubaDefaultsSpec{DataCenter: "ITBG-2"},
+				}),
+			}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(obj.Spec.DataCenter).To(Equal("ITBG-2"))
+			for _, ref := range []arubacloudcomv1alpha1.ResourceReference{
+				obj.Spec.ProjectReference,
+				obj.Spec.VpcReference,
+				*obj.Spec.ElasticIpReference,
+				obj.Spec.KeyPairReference,
+				obj.Spec.BootVolumeReference,
+				obj.Spec.SubnetReferences[0],
+				obj.Spec.SecurityGroupReferences[0],
+			} {
+				Expect(ref.Namespace).To(Equal("default"))
+			}
+			Expect(obj.Spec.DataVolumeReferences[0].Namespace).To(Equal("storage"))
+			Expect(obj.Annotations[arubacloudcomv1alpha1.DefaultedFieldsAnnotation]).To(And(
+				ContainSubstring("spec.dataCenter"),
+				ContainSubstring("spec.subnetReferences[0].namespace"),
+				Not(ContainSubstring("spec.dataVolumeReferences")),
+			))
+		})
+	})
+})
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=arubadefaults,verbs=get;list;watch
+
+// namespaceDefaults fills the empty fields of a resource from the ArubaDefaults of its namespace
+type namespaceDefaults struct {
+	namespace string
+	spec      arubacloudcomv1alpha1.ArubaDefaultsSpec
+
+	defaulted []string
+}
+
+// loadNamespaceDefaults reads the ArubaDefaults of namespace. A namespace without
+// ArubaDefaults, or a cluster without the kind, only gets reference namespaces defaulted.
+func loadNamespaceDefaults(ctx context.Context, c client.Client, namespace string) (*namespaceDefaults, error) {
+	d := &namespaceDefaults{namespace: namespace}
+	if c == nil {
+		return d, nil
+	}
+
+	defaults := &arubacloudcomv1alpha1.ArubaDefaults{}
+	err := c.Get(ctx, types.NamespacedName{Name: arubacloudcomv1alpha1.ArubaDefaultsName, Namespace: namespace}, defaults)
+	switch {
+	case err == nil:
+		d.spec = defaults.Spec
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+	default:
+		return nil, fmt.Errorf("failed to read the ArubaDefaults of namespace %s: %w", namespace, err)
+	}
+	return d, nil
+}
+
+// namespaceOf returns the namespace of obj, or the namespace of the admission request
+// when the manifest doesn't set one
+func namespaceOf(ctx context.Context, obj client.Object) string {
+	if obj.GetNamespace() != "" {
+		return obj.GetNamespace()
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		return req.Namespace
+	}
+	return ""
+}
+
+// tenant fills an empty tenant
+func (d *namespaceDefaults) tenant(path *field.Path, tenant *string) {
+	if *tenant == "" && d.spec.Tenant != "" {
+		*tenant = d.spec.Tenant
+		d.defaulted = append(d.defaulted, path.String())
+	}
+}
+
+// location fills an empty location
+func (d *namespaceDefaults) location(path *field.Path, location *arubacloudcomv1alpha1.Location) {
+	if location.Value == "" && d.spec.Location != nil && d.spec.Location.Value != "" {
+		*location = *d.spec.Location
+		d.defaulted = append(d.defaulted, path.String())
+	}
+}
+
+// dataCenter fills an empty data center
+func (d *namespaceDefaults) dataCenter(path *field.Path, dataCenter *string) {
+	if *dataCenter == "" && d.spec.DataCenter != "" {
+		*dataCenter = d.spec.DataCenter
+		d.defaulted = append(d.defaulted, path.String())
+	}
+}
+
+// projectReference fills an empty project reference, then its namespace
+func (d *namespaceDefaults) projectReference(path *field.Path, ref *arubacloudcomv1alpha1.ResourceReference) {
+	if ref.Name == "" && ref.ID == "" && d.spec.ProjectReference != nil && (d.spec.ProjectReference.Name != "" || d.spec.ProjectReference.ID != "") {
+		*ref = *d.spec.ProjectReference
+		d.defaulted = append(d.defaulted, path.String())
+	}
+	d.referenceNamespace(path, ref)
+}
+
+// referenceNamespace fills the empty namespace of a reference with the namespace of the resource
+func (d *namespaceDefaults) referenceNamespace(path *field.Path, ref *arubacloudcomv1alpha1.ResourceReference) {
+	if ref != nil && ref.Name != "" && ref.Namespace == "" {
+		ref.Namespace = d.namespace
+		d.defaulted = append(d.defaulted, path.Child("namespace").String())
+	}
+}
+
+// record lists the defaulted fields in the DefaultedFieldsAnnotation of obj, for the
+// controller to report them in the status
+func (d *namespaceDefaults) record(obj client.Object) {
+	if len(d.defaulted) == 0 {
+		return
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[arubacloudcomv1alpha1.DefaultedFieldsAnnotation] = strings.Join(d.defaulted, ",")
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var elasticiplog = logf.Log.WithName("elasticip-resource")
+
+// SetupElasticIpWebhookWithManager registers the webhook for ElasticIp in the manager.
+func SetupElasticIpWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.ElasticIp{}).
+		WithValidator(&ElasticIpCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&ElasticIpCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-elasticip,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=elasticips,verbs=create,versions=v1alpha1,name=melasticip-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ElasticIpCustomDefaulter fills the empty fields of the ElasticIp resource from the
+// ArubaDefaults of its namespace when it is created.
+type ElasticIpCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &ElasticIpCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type ElasticIp.
+func (d *ElasticIpCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	elasticIp, ok := obj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return fmt.Errorf("expected a ElasticIp object but got %T", obj)
+	}
+	elasticiplog.Info("Defaulting for ElasticIp", "name", elasticIp.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, elasticIp))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &elasticIp.Spec.Tenant)
+	defaults.location(specPath.Child("location"), &elasticIp.Spec.Location)
+	defaults.projectReference(specPath.Child("projectReference"), &elasticIp.Spec.ProjectReference)
+	defaults.record(elasticIp)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-elasticip,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=elasticips,verbs=create;update,versions=v1alpha1,name=velasticip-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ElasticIpCustomValidator validates the ElasticIp resource when it is created or updated.
+type ElasticIpCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &ElasticIpCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ElasticIp.
+func (v *ElasticIpCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	elasticIp, ok := obj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return nil, fmt.Errorf("expected a ElasticIp object but got %T", obj)
+	}
+	elasticiplog.Info("Validation for ElasticIp upon creation", "name", elasticIp.GetName())
+
+	return v.validateElasticIp(ctx, elasticIp, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ElasticIp.
+func (v *ElasticIpCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	elasticIp, ok := newObj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return nil, fmt.Errorf("expected a ElasticIp object for the newObj but got %T", newObj)
+	}
+	oldElasticIp, ok := oldObj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return nil, fmt.Errorf("expected a ElasticIp object for the oldObj but got %T", oldObj)
+	}
+	elasticiplog.Info("Validation for ElasticIp upon update", "name", elasticIp.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldElasticIp.Spec, elasticIp.Spec) {
+		return nil, nil
+	}
+
+	return v.validateElasticIp(ctx, elasticIp, oldElasticIp)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ElasticIp.
+func (v *ElasticIpCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateElasticIp validates the elastic IP spec, comparing it with old on update
+func (v *ElasticIpCustomValidator) validateElasticIp(ctx context.Context, elasticIp, old *arubacloudcomv1alpha1.ElasticIp) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, elasticIp.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("location"), old.Spec.Location, elasticIp.Spec.Location)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, elasticIp.Spec.ProjectReference)...)
+	}
+
+	refs := newReferenceValidator(v.Client, elasticIp.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", elasticIp.Spec.ProjectReference, project)
+	errs = append(errs, refs.errs...)
+	errs = append(errs, validateTenant(ctx, v.Tenants, elasticIp, specPath.Child("tenant"), elasticIp.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, elasticIp, specPath.Child("providerConfigRef"), elasticIp.Spec.ProviderConfigRef)...)
+
+	return refs.warnings, invalidError("ElasticIp", elasticIp.Name, errs)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("ElasticIp Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.ElasticIp
+		oldObj    *arubacloudcomv1alpha1.ElasticIp
+		validator ElasticIpCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.ElasticIp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-elasticip",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.ElasticIpSpec{
+				Tenant:           "test-tenant",
+				Location:         arubacloudcomv1alpha1.Location{Value: "ITBG-Bergamo"},
+				BillingPlan:      arubacloudcomv1alpha1.BillingPlan{BillingPeriod: "Hour"},
+				ProjectReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = ElasticIpCustomValidator{
+			Client: newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+			),
+		}
+	})
+
+	Context("When creating ElasticIp under Validating Webhook", func() {
+		It("Should admit a valid elastic IP", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should warn when the referenced project does not exist yet", func() {
+			obj.Spec.ProjectReference.Name = "missing-project"
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("Project default/missing-project does not exist yet")))
+		})
+	})
+
+	Context("When updating ElasticIp under Validating Webhook", func() {
+		It("Should deny a location change", func() {
+			obj.Spec.Location.Value = "ITMI-Milano"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.location")))
+		})
+
+		It("Should admit a billing period change", func() {
+			obj.Spec.BillingPlan.BillingPeriod = "Month"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
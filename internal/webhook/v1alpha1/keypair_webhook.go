@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var keypairlog = logf.Log.WithName("keypair-resource")
+
+// SetupKeyPairWebhookWithManager registers the webhook for KeyPair in the manager.
+func SetupKeyPairWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.KeyPair{}).
+		WithValidator(&KeyPairCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&KeyPairCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-keypair,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=keypairs,verbs=create,versions=v1alpha1,name=mkeypair-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KeyPairCustomDefaulter fills the empty fields of the KeyPair resource from the
+// ArubaDefaults of its namespace when it is created.
+type KeyPairCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &KeyPairCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type KeyPair.
+func (d *KeyPairCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	keyPair, ok := obj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return fmt.Errorf("expected a KeyPair object but got %T", obj)
+	}
+	keypairlog.Info("Defaulting for KeyPair", "name", keyPair.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, keyPair))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &keyPair.Spec.Tenant)
+	defaults.location(specPath.Child("location"), &keyPair.Spec.Location)
+	defaults.projectReference(specPath.Child("projectReference"), &keyPair.Spec.ProjectReference)
+	defaults.record(keyPair)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-keypair,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=keypairs,verbs=create;update,versions=v1alpha1,name=vkeypair-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KeyPairCustomValidator validates the KeyPair resource when it is created or updated.
+type KeyPairCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &KeyPairCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	keyPair, ok := obj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object but got %T", obj)
+	}
+	keypairlog.Info("Validation for KeyPair upon creation", "name", keyPair.GetName())
+
+	return v.validateKeyPair(ctx, keyPair, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	keyPair, ok := newObj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object for the newObj but got %T", newObj)
+	}
+	oldKeyPair, ok := oldObj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object for the oldObj but got %T", oldObj)
+	}
+	keypairlog.Info("Validation for KeyPair upon update", "name", keyPair.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldKeyPair.Spec, keyPair.Spec) {
+		return nil, nil
+	}
+
+	return v.validateKeyPair(ctx, keyPair, oldKeyPair)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateKeyPair validates the keypair spec, comparing it with old on update
+func (v *KeyPairCustomValidator) validateKeyPair(ctx context.Context, keyPair, old *arubacloudcomv1alpha1.KeyPair) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, keyPair.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("location"), old.Spec.Location, keyPair.Spec.Location)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, keyPair.Spec.ProjectReference)...)
+		errs = append(errs, validateImmutable(specPath.Child("generate"), old.Spec.Generate, keyPair.Spec.Generate)...)
+	}
+	errs = append(errs, validateKeyPairValue(specPath, keyPair.Spec)...)
+	var warnings admission.Warnings
+	if old != nil && (old.Spec.Value != keyPair.Spec.Value || !equality.Semantic.DeepEqual(old.Spec.ValueFrom, keyPair.Spec.ValueFrom)) {
+		rotationWarnings, err := v.keyRotationWarnings(ctx, old)
+		if err != nil {
+			return nil, err
+		}
+		warnings = rotationWarnings
+	}
+
+	refs := newReferenceValidator(v.Client, keyPair.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", keyPair.Spec.ProjectReference, project)
+	errs = append(errs, refs.errs...)
+	errs = append(errs, validateTenant(ctx, v.Tenants, keyPair, specPath.Child("tenant"), keyPair.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, keyPair, specPath.Child("providerConfigRef"), keyPair.Spec.ProviderConfigRef)...)
+
+	return append(warnings, refs.warnings...), invalidError("KeyPair", keyPair.Name, errs)
+}
+
+// keyRotationWarnings warns that a change of the key of old moves the cloud servers using it to
+// the new remote keypair, and that the rotation is blocked for those the API refuses to update
+func (v *KeyPairCustomValidator) keyRotationWarnings(ctx context.Context, old *arubacloudcomv1alpha1.KeyPair) (admission.Warnings, error) {
+	users, err := reconciler.CloudServersUsingKeyPair(ctx, v.Client, old.Status.ResourceID)
+	if err != nil || len(users) == 0 {
+		return nil, err
+	}
+	return admission.Warnings{fmt.Sprintf(
+		"CloudServers %s will be moved to the new key pair, the previous key pair is kept for those whose key pair the API refuses to change", strings.Join(users, ", "))}, nil
+}
+
+// validateKeyPairValue checks that the public key is either given, read from valueFrom or generated
+func validateKeyPairValue(specPath *field.Path, spec arubacloudcomv1alpha1.KeyPairSpec) field.ErrorList {
+	var errs field.ErrorList
+	var sources []string
+	if spec.Value != "" {
+		sources = append(sources, "value")
+	}
+	if spec.ValueFrom != nil {
+		sources = append(sources, "valueFrom")
+	}
+	if spec.Generate != nil {
+		sources = append(sources, "generate")
+	}
+	switch {
+	case len(sources) == 0:
+		errs = append(errs, field.Required(specPath.Child("value"), "value, valueFrom or generate must be set"))
+	case len(sources) > 1:
+		errs = append(errs, field.Forbidden(specPath.Child(sources[1]), fmt.Sprintf("%s can't be set with %s", sources[1], sources[0])))
+	}
+	errs = append(errs, validateKeyPairValueFrom(specPath.Child("valueFrom"), spec.ValueFrom)...)
+
+	if spec.Generate == nil {
+		return errs
+	}
+
+	genPath := specPath.Child("generate")
+	if spec.Generate.Bits != 0 && spec.Generate.Algorithm != arubacloudcomv1alpha1.KeyPairAlgorithmRSA {
+		errs = append(errs, field.Forbidden(genPath.Child("bits"), "bits is only supported by the rsa algorithm"))
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(spec.Generate.SecretName) {
+		errs = append(errs, field.Invalid(genPath.Child("secretName"), spec.Generate.SecretName, msg))
+	}
+	return errs
+}
+
+// validateKeyPairValueFrom checks that source selects a key of either a Secret or a ConfigMap
+func validateKeyPairValueFrom(path *field.Path, source *arubacloudcomv1alpha1.KeyPairValueSource) field.ErrorList {
+	if source == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	switch {
+	case source.SecretKeyRef == nil && source.ConfigMapKeyRef == nil:
+		errs = append(errs, field.Required(path, "secretKeyRef or configMapKeyRef must be set"))
+	case source.SecretKeyRef != nil && source.ConfigMapKeyRef != nil:
+		errs = append(errs, field.Forbidden(path.Child("configMapKeyRef"), "configMapKeyRef can't be set with secretKeyRef"))
+	}
+	errs = append(errs, validateLocalKeySelector(path.Child("secretKeyRef"), source.SecretKeyRef)...)
+	errs = append(errs, validateLocalKeySelector(path.Child("configMapKeyRef"), source.ConfigMapKeyRef)...)
+	return errs
+}
+
+// validateLocalKeySelector checks the object name and key selected by ref
+func validateLocalKeySelector(path *field.Path, ref *arubacloudcomv1alpha1.LocalKeySelector) field.ErrorList {
+	if ref == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+		errs = append(errs, field.Invalid(path.Child("name"), ref.Name, msg))
+	}
+	for _, msg := range validation.IsConfigMapKey(ref.Key) {
+		errs = append(errs, field.Invalid(path.Child("key"), ref.Key, msg))
+	}
+	return errs
+}
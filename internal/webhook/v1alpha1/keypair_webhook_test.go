@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("KeyPair Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.KeyPair
+		oldObj    *arubacloudcomv1alpha1.KeyPair
+		validator KeyPairCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.KeyPair{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-keypair",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.KeyPairSpec{
+				Tenant:           "test-tenant",
+				Location:         arubacloudcomv1alpha1.Location{Value: "ITBG-Bergamo"},
+				Value:            "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDtest test@example.com",
+				ProjectReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = KeyPairCustomValidator{
+			Client: newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+			),
+		}
+	})
+
+	Context("When creating KeyPair under Validating Webhook", func() {
+		It("Should admit a valid keypair", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should warn when the referenced project does not exist yet", func() {
+			obj.Spec.ProjectReference.Name = "missing-project"
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("Project default/missing-project does not exist yet")))
+		})
+
+		It("Should admit a generated keypair", func() {
+			obj.Spec.Value = ""
+			obj.Spec.Generate = &arubacloudcomv1alpha1.KeyPairGeneration{
+				Algorithm:  arubacloudcomv1alpha1.KeyPairAlgorithmRSA,
+				Bits:       3072,
+				SecretName: "test-keypair-ssh",
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a keypair with both value and generate", func() {
+			obj.Spec.Generate = &arubacloudcomv1alpha1.KeyPairGeneration{SecretName: "test-keypair-ssh"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.generate")))
+		})
+
+		It("Should admit a keypair reading its value from a secret", func() {
+			obj.Spec.Value = ""
+			obj.Spec.ValueFrom = &arubacloudcomv1alpha1.KeyPairValueSource{
+				SecretKeyRef: &arubacloudcomv1alpha1.LocalKeySelector{Name: "ssh-keys", Key: "id_ed25519.pub"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a valueFrom selecting both a secret and a configmap", func() {
+			obj.Spec.Value = ""
+			obj.Spec.ValueFrom = &arubacloudcomv1alpha1.KeyPairValueSource{
+				SecretKeyRef:    &arubacloudcomv1alpha1.LocalKeySelector{Name: "ssh-keys", Key: "id_ed25519.pub"},
+				ConfigMapKeyRef: &arubacloudcomv1alpha1.LocalKeySelector{Name: "ssh-keys", Key: "id_ed25519.pub"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.valueFrom.configMapKeyRef")))
+		})
+
+		It("Should deny bits with the ed25519 algorithm", func() {
+			obj.Spec.Value = ""
+			obj.Spec.Generate = &arubacloudcomv1alpha1.KeyPairGeneration{
+				Algorithm:  arubacloudcomv1alpha1.KeyPairAlgorithmEd25519,
+				Bits:       4096,
+				SecretName: "test-keypair-ssh",
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.generate.bits")))
+		})
+	})
+
+	Context("When updating KeyPair under Validating Webhook", func() {
+		It("Should deny a location change", func() {
+			obj.Spec.Location.Value = "ITMI-Milano"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.location")))
+		})
+
+		It("Should warn of a key change while cloud servers use the keypair", func() {
+			oldObj.Status.ResourceID = "kp-1"
+			validator.Client = newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+				&arubacloudcomv1alpha1.CloudServer{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+					Status:     arubacloudcomv1alpha1.CloudServerStatus{KeyPairID: "kp-1"},
+				},
+			)
+			obj.Spec.Value = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDrotated test@example.com"
+			warnings, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("default/web")))
+		})
+
+		It("Should admit a key change while no cloud server uses the keypair", func() {
+			oldObj.Status.ResourceID = "kp-1"
+			obj.Spec.Value = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDrotated test@example.com"
+			warnings, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should admit a tags change", func() {
+			obj.Spec.Tags = []string{"updated"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var projectlog = logf.Log.WithName("project-resource")
+
+// SetupProjectWebhookWithManager registers the webhook for Project in the manager.
+func SetupProjectWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.Project{}).
+		WithValidator(&ProjectCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&ProjectCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-project,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=projects,verbs=create,versions=v1alpha1,name=mproject-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ProjectCustomDefaulter fills the empty fields of the Project resource from the
+// ArubaDefaults of its namespace when it is created.
+type ProjectCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &ProjectCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type Project.
+func (d *ProjectCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	project, ok := obj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return fmt.Errorf("expected a Project object but got %T", obj)
+	}
+	projectlog.Info("Defaulting for Project", "name", project.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, project))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &project.Spec.Tenant)
+	defaults.record(project)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-project,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=projects,verbs=create;update,versions=v1alpha1,name=vproject-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ProjectCustomValidator validates the Project resource when it is created or updated.
+type ProjectCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &ProjectCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Project.
+func (v *ProjectCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	project, ok := obj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return nil, fmt.Errorf("expected a Project object but got %T", obj)
+	}
+	projectlog.Info("Validation for Project upon creation", "name", project.GetName())
+
+	return v.validateProject(ctx, project, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Project.
+func (v *ProjectCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	project, ok := newObj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return nil, fmt.Errorf("expected a Project object for the newObj but got %T", newObj)
+	}
+	oldProject, ok := oldObj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return nil, fmt.Errorf("expected a Project object for the oldObj but got %T", oldObj)
+	}
+	projectlog.Info("Validation for Project upon update", "name", project.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldProject.Spec, project.Spec) {
+		return nil, nil
+	}
+
+	return v.validateProject(ctx, project, oldProject)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Project.
+func (v *ProjectCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateProject validates the project spec, comparing it with old on update
+func (v *ProjectCustomValidator) validateProject(ctx context.Context, project, old *arubacloudcomv1alpha1.Project) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, project.Spec.Tenant)...)
+	}
+
+	errs = append(errs, validateTenant(ctx, v.Tenants, project, specPath.Child("tenant"), project.Spec.Tenant, nil)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, project, specPath.Child("providerConfigRef"), project.Spec.ProviderConfigRef)...)
+
+	return nil, invalidError("Project", project.Name, errs)
+}
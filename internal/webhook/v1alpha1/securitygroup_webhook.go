@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var securitygrouplog = logf.Log.WithName("securitygroup-resource")
+
+// SetupSecurityGroupWebhookWithManager registers the webhook for SecurityGroup in the manager.
+func SetupSecurityGroupWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.SecurityGroup{}).
+		WithValidator(&SecurityGroupCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&SecurityGroupCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-securitygroup,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=securitygroups,verbs=create,versions=v1alpha1,name=msecuritygroup-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecurityGroupCustomDefaulter fills the empty fields of the SecurityGroup resource from the
+// ArubaDefaults of its namespace when it is created.
+type SecurityGroupCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &SecurityGroupCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type SecurityGroup.
+func (d *SecurityGroupCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	securityGroup, ok := obj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return fmt.Errorf("expected a SecurityGroup object but got %T", obj)
+	}
+	securitygrouplog.Info("Defaulting for SecurityGroup", "name", securityGroup.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, securityGroup))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &securityGroup.Spec.Tenant)
+	defaults.location(specPath.Child("location"), &securityGroup.Spec.Location)
+	defaults.projectReference(specPath.Child("projectReference"), &securityGroup.Spec.ProjectReference)
+	defaults.referenceNamespace(specPath.Child("vpcReference"), &securityGroup.Spec.VpcReference)
+	defaults.record(securityGroup)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-securitygroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=securitygroups,verbs=create;update,versions=v1alpha1,name=vsecuritygroup-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecurityGroupCustomValidator validates the SecurityGroup resource when it is created or updated.
+type SecurityGroupCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &SecurityGroupCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SecurityGroup.
+func (v *SecurityGroupCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	securityGroup, ok := obj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityGroup object but got %T", obj)
+	}
+	securitygrouplog.Info("Validation for SecurityGroup upon creation", "name", securityGroup.GetName())
+
+	return v.validateSecurityGroup(ctx, securityGroup, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SecurityGroup.
+func (v *SecurityGroupCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	securityGroup, ok := newObj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityGroup object for the newObj but got %T", newObj)
+	}
+	oldSecurityGroup, ok := oldObj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityGroup object for the oldObj but got %T", oldObj)
+	}
+	securitygrouplog.Info("Validation for SecurityGroup upon update", "name", securityGroup.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldSecurityGroup.Spec, securityGroup.Spec) {
+		return nil, nil
+	}
+
+	return v.validateSecurityGroup(ctx, securityGroup, oldSecurityGroup)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SecurityGroup.
+func (v *SecurityGroupCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSecurityGroup validates the security group spec, comparing it with old on update
+func (v *SecurityGroupCustomValidator) validateSecurityGroup(ctx context.Context, securityGroup, old *arubacloudcomv1alpha1.SecurityGroup) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, securityGroup.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("location"), old.Spec.Location, securityGroup.Spec.Location)...)
+		errs = append(errs, validateImmutable(specPath.Child("vpcReference"), old.Spec.VpcReference, securityGroup.Spec.VpcReference)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, securityGroup.Spec.ProjectReference)...)
+	}
+
+	refs := newReferenceValidator(v.Client, securityGroup.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", securityGroup.Spec.ProjectReference, project)
+	refs.validate(ctx, specPath.Child("vpcReference"), "Vpc", securityGroup.Spec.VpcReference, &arubacloudcomv1alpha1.Vpc{})
+	errs = append(errs, refs.errs...)
+	errs = append(errs, validateTenant(ctx, v.Tenants, securityGroup, specPath.Child("tenant"), securityGroup.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, securityGroup, specPath.Child("providerConfigRef"), securityGroup.Spec.ProviderConfigRef)...)
+
+	return refs.warnings, invalidError("SecurityGroup", securityGroup.Name, errs)
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var securityrulelog = logf.Log.WithName("securityrule-resource")
+
+// SetupSecurityRuleWebhookWithManager registers the webhook for SecurityRule in the manager.
+func SetupSecurityRuleWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.SecurityRule{}).
+		WithValidator(&SecurityRuleCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&SecurityRuleCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-securityrule,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=securityrules,verbs=create,versions=v1alpha1,name=msecurityrule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecurityRuleCustomDefaulter fills the empty fields of the SecurityRule resource from the
+// ArubaDefaults of its namespace when it is created.
+type SecurityRuleCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &SecurityRuleCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type SecurityRule.
+func (d *SecurityRuleCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	securityRule, ok := obj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return fmt.Errorf("expected a SecurityRule object but got %T", obj)
+	}
+	securityrulelog.Info("Defaulting for SecurityRule", "name", securityRule.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, securityRule))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &securityRule.Spec.Tenant)
+	defaults.location(specPath.Child("location"), &securityRule.Spec.Location)
+	defaults.projectReference(specPath.Child("projectReference"), &securityRule.Spec.ProjectReference)
+	defaults.referenceNamespace(specPath.Child("securityGroupReference"), &securityRule.Spec.SecurityGroupReference)
+	defaults.referenceNamespace(specPath.Child("vpcReference"), &securityRule.Spec.VpcReference)
+	defaults.record(securityRule)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-securityrule,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=securityrules,verbs=create;update,versions=v1alpha1,name=vsecurityrule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecurityRuleCustomValidator validates the SecurityRule resource when it is created or updated.
+type SecurityRuleCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &SecurityRuleCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SecurityRule.
+func (v *SecurityRuleCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	securityRule, ok := obj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityRule object but got %T", obj)
+	}
+	securityrulelog.Info("Validation for SecurityRule upon creation", "name", securityRule.GetName())
+
+	return v.validateSecurityRule(ctx, securityRule, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SecurityRule.
+func (v *SecurityRuleCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	securityRule, ok := newObj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityRule object for the newObj but got %T", newObj)
+	}
+	oldSecurityRule, ok := oldObj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityRule object for the oldObj but got %T", oldObj)
+	}
+	securityrulelog.Info("Validation for SecurityRule upon update", "name", securityRule.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldSecurityRule.Spec, securityRule.Spec) {
+		return nil, nil
+	}
+
+	return v.validateSecurityRule(ctx, securityRule, oldSecurityRule)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SecurityRule.
+func (v *SecurityRuleCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSecurityRule validates the security rule spec, comparing it with old on update
+func (v *SecurityRuleCustomValidator) validateSecurityRule(ctx context.Context, securityRule, old *arubacloudcomv1alpha1.SecurityRule) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, securityRule.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("location"), old.Spec.Location, securityRule.Spec.Location)...)
+		errs = append(errs, validateImmutable(specPath.Child("securityGroupReference"), old.Spec.SecurityGroupReference, securityRule.Spec.SecurityGroupReference)...)
+		errs = append(errs, validateImmutable(specPath.Child("vpcReference"), old.Spec.VpcReference, securityRule.Spec.VpcReference)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, securityRule.Spec.ProjectReference)...)
+	}
+
+	errs = append(errs, validateSecurityRulePort(specPath, securityRule.Spec.Protocol, securityRule.Spec.Port)...)
+	errs = append(errs, validateSecurityRuleTarget(specPath.Child("target"), securityRule.Spec.Target)...)
+
+	refs := newReferenceValidator(v.Client, securityRule.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", securityRule.Spec.ProjectReference, project)
+	refs.validate(ctx, specPath.Child("vpcReference"), "Vpc", securityRule.Spec.VpcReference, &arubacloudcomv1alpha1.Vpc{})
+	refs.validate(ctx, specPath.Child("securityGroupReference"), "SecurityGroup", securityRule.Spec.SecurityGroupReference, &arubacloudcomv1alpha1.SecurityGroup{})
+	errs = append(errs, refs.errs...)
+	errs = append(errs, validateTenant(ctx, v.Tenants, securityRule, specPath.Child("tenant"), securityRule.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, securityRule, specPath.Child("providerConfigRef"), securityRule.Spec.ProviderConfigRef)...)
+
+	return refs.warnings, invalidError("SecurityRule", securityRule.Name, errs)
+}
+
+// securityRulePortAll is the port value matching every port
+const securityRulePortAll = "ALL"
+
+// validateSecurityRulePort checks that port is "ALL", a single port or a port range,
+// and that a specific port is only set for protocols that have ports
+func validateSecurityRulePort(specPath *field.Path, protocol, port string) field.ErrorList {
+	portPath := specPath.Child("port")
+	if port == securityRulePortAll {
+		return nil
+	}
+
+	if protocol == "ICMP" || protocol == "ALL" {
+		return field.ErrorList{field.Invalid(portPath, port,
+			fmt.Sprintf("port must be %q when protocol is %s", securityRulePortAll, protocol))}
+	}
+
+	from, to, isRange := strings.Cut(port, "-")
+	fromPort, err := parsePort(from)
+	if err != nil {
+		return field.ErrorList{field.Invalid(portPath, port, err.Error())}
+	}
+	if !isRange {
+		return nil
+	}
+
+	toPort, err := parsePort(to)
+	if err != nil {
+		return field.ErrorList{field.Invalid(portPath, port, err.Error())}
+	}
+	if fromPort > toPort {
+		return field.ErrorList{field.Invalid(portPath, port, "the first port of a range must not be greater than the last one")}
+	}
+
+	return nil
+}
+
+// parsePort parses a single port number
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("must be %q, a port number or a port range such as \"80-90\"", securityRulePortAll)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d must be between 1 and 65535", port)
+	}
+	return port, nil
+}
+
+// validateSecurityRuleTarget checks that the target value matches the target kind
+func validateSecurityRuleTarget(targetPath *field.Path, target arubacloudcomv1alpha1.SecurityRuleTarget) field.ErrorList {
+	if target.Kind != "Ip" {
+		return nil
+	}
+
+	if _, err := netip.ParsePrefix(target.Value); err == nil {
+		return nil
+	}
+	if _, err := netip.ParseAddr(target.Value); err == nil {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(targetPath.Child("value"), target.Value, "must be an IP address or a CIDR when kind is Ip")}
+}
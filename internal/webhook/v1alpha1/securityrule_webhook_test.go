@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("SecurityRule Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.SecurityRule
+		oldObj    *arubacloudcomv1alpha1.SecurityRule
+		validator SecurityRuleCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.SecurityRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-rule",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.SecurityRuleSpec{
+				Tenant:    "test-tenant",
+				Location:  arubacloudcomv1alpha1.Location{Value: "ITBG-Bergamo"},
+				Protocol:  "TCP",
+				Port:      "80-90",
+				Direction: "Ingress",
+				Target: arubacloudcomv1alpha1.SecurityRuleTarget{
+					Kind:  "Ip",
+					Value: "0.0.0.0/0",
+				},
+				SecurityGroupReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-sg", Namespace: "default"},
+				VpcReference:           arubacloudcomv1alpha1.ResourceReference{Name: "test-vpc", Namespace: "default"},
+				ProjectReference:       arubacloudcomv1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = SecurityRuleCustomValidator{
+			Client: newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+				&arubacloudcomv1alpha1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default"}},
+				&arubacloudcomv1alpha1.SecurityGroup{ObjectMeta: metav1.ObjectMeta{Name: "test-sg", Namespace: "default"}},
+			),
+		}
+	})
+
+	Context("When creating SecurityRule under Validating Webhook", func() {
+		It("Should admit a valid security rule without warnings", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		DescribeTable("Should validate the port",
+			func(protocol, port string, valid bool) {
+				obj.Spec.Protocol = protocol
+				obj.Spec.Port = port
+				_, err := validator.ValidateCreate(ctx, obj)
+				if valid {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring("spec.port")))
+				}
+			},
+			Entry("single port", "TCP", "443", true),
+			Entry("port range", "UDP", "1000-2000", true),
+			Entry("all ports", "TCP", "ALL", true),
+			Entry("open-ended range", "TCP", "80-", false),
+			Entry("port out of range", "TCP", "70000", false),
+			Entry("port zero", "TCP", "0", false),
+			Entry("reversed range", "TCP", "90-80", false),
+			Entry("not a number", "TCP", "http", false),
+			Entry("ICMP with all ports", "ICMP", "ALL", true),
+			Entry("ICMP with a port", "ICMP", "80", false),
+			Entry("all protocols with a port range", "ALL", "80-90", false),
+		)
+
+		It("Should deny an Ip target that is not an IP address or a CIDR", func() {
+			obj.Spec.Target.Value = "not-an-ip"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.target.value")))
+		})
+
+		It("Should deny a malformed reference", func() {
+			obj.Spec.SecurityGroupReference.Name = "Invalid_Name"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.securityGroupReference.name")))
+		})
+
+		It("Should warn when a referenced resource does not exist yet", func() {
+			obj.Spec.SecurityGroupReference.Name = "missing-sg"
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("SecurityGroup default/missing-sg does not exist yet")))
+		})
+	})
+
+	Context("When updating SecurityRule under Validating Webhook", func() {
+		It("Should deny a location change", func() {
+			obj.Spec.Location.Value = "ITMI-Milano"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.location")))
+		})
+
+		It("Should deny moving the rule to another security group", func() {
+			obj.Spec.SecurityGroupReference.Name = "other-sg"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.securityGroupReference")))
+		})
+
+		It("Should admit a port change", func() {
+			obj.Spec.Port = "8080"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should admit metadata-only updates of an invalid resource", func() {
+			oldObj.Spec.Port = "80-"
+			obj = oldObj.DeepCopy()
+			obj.Finalizers = nil
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
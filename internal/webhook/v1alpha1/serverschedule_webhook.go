@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var serverschedulelog = logf.Log.WithName("serverschedule-resource")
+
+// SetupServerScheduleWebhookWithManager registers the webhook for ServerSchedule in the manager.
+func SetupServerScheduleWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.ServerSchedule{}).
+		WithValidator(&ServerScheduleCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-serverschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=serverschedules,verbs=create;update,versions=v1alpha1,name=vserverschedule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ServerScheduleCustomValidator validates the ServerSchedule resource when it is created or updated.
+type ServerScheduleCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ServerScheduleCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ServerSchedule.
+func (v *ServerScheduleCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	serverSchedule, ok := obj.(*arubacloudcomv1alpha1.ServerSchedule)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerSchedule object but got %T", obj)
+	}
+	serverschedulelog.Info("Validation for ServerSchedule upon creation", "name", serverSchedule.GetName())
+
+	return nil, validateServerSchedule(serverSchedule)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ServerSchedule.
+func (v *ServerScheduleCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	serverSchedule, ok := newObj.(*arubacloudcomv1alpha1.ServerSchedule)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerSchedule object for the newObj but got %T", newObj)
+	}
+	oldServerSchedule, ok := oldObj.(*arubacloudcomv1alpha1.ServerSchedule)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerSchedule object for the oldObj but got %T", oldObj)
+	}
+	serverschedulelog.Info("Validation for ServerSchedule upon update", "name", serverSchedule.GetName())
+
+	// Metadata-only updates must never be blocked
+	if equality.Semantic.DeepEqual(oldServerSchedule.Spec, serverSchedule.Spec) {
+		return nil, nil
+	}
+
+	return nil, validateServerSchedule(serverSchedule)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ServerSchedule.
+func (v *ServerScheduleCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateServerSchedule checks that the time zone and the windows of the schedule can be evaluated
+func validateServerSchedule(serverSchedule *arubacloudcomv1alpha1.ServerSchedule) error {
+	specPath := field.NewPath("spec")
+	errs := validateTimeZone(specPath.Child("timeZone"), serverSchedule.Spec.TimeZone)
+	for i, window := range serverSchedule.Spec.Windows {
+		windowPath := specPath.Child("windows").Index(i)
+		errs = append(errs, validateCronExpression(windowPath.Child("powerOff"), window.PowerOff)...)
+		errs = append(errs, validateCronExpression(windowPath.Child("powerOn"), window.PowerOn)...)
+	}
+	return invalidError("ServerSchedule", serverSchedule.Name, errs)
+}
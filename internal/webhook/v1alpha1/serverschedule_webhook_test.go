@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("ServerSchedule Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.ServerSchedule
+		oldObj    *arubacloudcomv1alpha1.ServerSchedule
+		validator ServerScheduleCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.ServerSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-schedule",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.ServerScheduleSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+				TimeZone: "Europe/Rome",
+				Windows:  []arubacloudcomv1alpha1.ServerScheduleWindow{{PowerOff: "0 20 * * 1-5", PowerOn: "0 7 * * 1-5"}},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = ServerScheduleCustomValidator{}
+	})
+
+	Context("When creating ServerSchedule under Validating Webhook", func() {
+		It("Should admit a valid schedule", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should admit a schedule without time zone", func() {
+			obj.Spec.TimeZone = ""
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny an unknown time zone", func() {
+			obj.Spec.TimeZone = "Europe/Atlantis"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.timeZone")))
+		})
+
+		It("Should deny an invalid cron expression", func() {
+			obj.Spec.Windows = append(obj.Spec.Windows, arubacloudcomv1alpha1.ServerScheduleWindow{PowerOff: "0 25 * * *", PowerOn: "0 7 * * *"})
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.windows[1].powerOff")))
+		})
+	})
+
+	Context("When updating ServerSchedule under Validating Webhook", func() {
+		It("Should deny an invalid cron expression", func() {
+			obj.Spec.Windows[0].PowerOn = "every morning"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.windows[0].powerOn")))
+		})
+
+		It("Should admit a metadata-only update of an invalid schedule", func() {
+			oldObj.Spec.TimeZone = "Europe/Atlantis"
+			obj = oldObj.DeepCopy()
+			obj.Labels = map[string]string{"updated": "true"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var snapshotpolicylog = logf.Log.WithName("snapshotpolicy-resource")
+
+// SetupSnapshotPolicyWebhookWithManager registers the webhook for SnapshotPolicy in the manager.
+func SetupSnapshotPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.SnapshotPolicy{}).
+		WithValidator(&SnapshotPolicyCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-snapshotpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=snapshotpolicies,verbs=create;update,versions=v1alpha1,name=vsnapshotpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SnapshotPolicyCustomValidator validates the SnapshotPolicy resource when it is created or updated.
+type SnapshotPolicyCustomValidator struct{}
+
+var _ webhook.CustomValidator = &SnapshotPolicyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SnapshotPolicy.
+func (v *SnapshotPolicyCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	snapshotPolicy, ok := obj.(*arubacloudcomv1alpha1.SnapshotPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a SnapshotPolicy object but got %T", obj)
+	}
+	snapshotpolicylog.Info("Validation for SnapshotPolicy upon creation", "name", snapshotPolicy.GetName())
+
+	return nil, validateSnapshotPolicy(snapshotPolicy)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SnapshotPolicy.
+func (v *SnapshotPolicyCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	snapshotPolicy, ok := newObj.(*arubacloudcomv1alpha1.SnapshotPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a SnapshotPolicy object for the newObj but got %T", newObj)
+	}
+	oldSnapshotPolicy, ok := oldObj.(*arubacloudcomv1alpha1.SnapshotPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a SnapshotPolicy object for the oldObj but got %T", oldObj)
+	}
+	snapshotpolicylog.Info("Validation for SnapshotPolicy upon update", "name", snapshotPolicy.GetName())
+
+	// Metadata-only updates must never be blocked
+	if equality.Semantic.DeepEqual(oldSnapshotPolicy.Spec, snapshotPolicy.Spec) {
+		return nil, nil
+	}
+
+	return nil, validateSnapshotPolicy(snapshotPolicy)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SnapshotPolicy.
+func (v *SnapshotPolicyCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSnapshotPolicy checks that the time zone and the schedule of the policy can be evaluated
+func validateSnapshotPolicy(snapshotPolicy *arubacloudcomv1alpha1.SnapshotPolicy) error {
+	specPath := field.NewPath("spec")
+	errs := validateTimeZone(specPath.Child("timeZone"), snapshotPolicy.Spec.TimeZone)
+	errs = append(errs, validateCronExpression(specPath.Child("schedule"), snapshotPolicy.Spec.Schedule)...)
+	return invalidError("SnapshotPolicy", snapshotPolicy.Name, errs)
+}
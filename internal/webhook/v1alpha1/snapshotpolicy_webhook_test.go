@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("SnapshotPolicy Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.SnapshotPolicy
+		oldObj    *arubacloudcomv1alpha1.SnapshotPolicy
+		validator SnapshotPolicyCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.SnapshotPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policy",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.SnapshotPolicySpec{
+				Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"backup": "daily"}},
+				Schedule:  "0 2 * * *",
+				TimeZone:  "Europe/Rome",
+				Retention: arubacloudcomv1alpha1.SnapshotRetention{KeepLast: 7},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = SnapshotPolicyCustomValidator{}
+	})
+
+	Context("When creating SnapshotPolicy under Validating Webhook", func() {
+		It("Should admit a valid policy", func() {
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny an unknown time zone", func() {
+			obj.Spec.TimeZone = "Europe/Atlantis"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.timeZone")))
+		})
+
+		It("Should deny an invalid schedule", func() {
+			obj.Spec.Schedule = "0 2 * *"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.schedule")))
+		})
+	})
+
+	Context("When updating SnapshotPolicy under Validating Webhook", func() {
+		It("Should deny an invalid schedule", func() {
+			obj.Spec.Schedule = "@daily"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.schedule")))
+		})
+
+		It("Should admit a metadata-only update of an invalid policy", func() {
+			oldObj.Spec.Schedule = "@daily"
+			obj = oldObj.DeepCopy()
+			obj.Labels = map[string]string{"updated": "true"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var subnetlog = logf.Log.WithName("subnet-resource")
+
+// SetupSubnetWebhookWithManager registers the webhook for Subnet in the manager.
+func SetupSubnetWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.Subnet{}).
+		WithValidator(&SubnetCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&SubnetCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-subnet,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=subnets,verbs=create,versions=v1alpha1,name=msubnet-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SubnetCustomDefaulter fills the empty fields of the Subnet resource from the
+// ArubaDefaults of its namespace when it is created.
+type SubnetCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &SubnetCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type Subnet.
+func (d *SubnetCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	subnet, ok := obj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return fmt.Errorf("expected a Subnet object but got %T", obj)
+	}
+	subnetlog.Info("Defaulting for Subnet", "name", subnet.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, subnet))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &subnet.Spec.Tenant)
+	defaults.projectReference(specPath.Child("projectReference"), &subnet.Spec.ProjectReference)
+	defaults.referenceNamespace(specPath.Child("vpcReference"), &subnet.Spec.VpcReference)
+	defaults.record(subnet)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-subnet,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=subnets,verbs=create;update,versions=v1alpha1,name=vsubnet-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SubnetCustomValidator validates the Subnet resource when it is created or updated.
+type SubnetCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &SubnetCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Subnet.
+func (v *SubnetCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	subnet, ok := obj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subnet object but got %T", obj)
+	}
+	subnetlog.Info("Validation for Subnet upon creation", "name", subnet.GetName())
+
+	return v.validateSubnet(ctx, subnet, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Subnet.
+func (v *SubnetCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	subnet, ok := newObj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subnet object for the newObj but got %T", newObj)
+	}
+	oldSubnet, ok := oldObj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subnet object for the oldObj but got %T", oldObj)
+	}
+	subnetlog.Info("Validation for Subnet upon update", "name", subnet.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldSubnet.Spec, subnet.Spec) {
+		return nil, nil
+	}
+
+	return v.validateSubnet(ctx, subnet, oldSubnet)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Subnet.
+func (v *SubnetCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSubnet validates the subnet spec, comparing it with old on update
+func (v *SubnetCustomValidator) validateSubnet(ctx context.Context, subnet, old *arubacloudcomv1alpha1.Subnet) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, subnet.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("vpcReference"), old.Spec.VpcReference, subnet.Spec.VpcReference)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, subnet.Spec.ProjectReference)...)
+	}
+
+	errs = append(errs, validateSubnetAddress(specPath.Child("network", "address"), subnet.Spec.Network.Address)...)
+
+	refs := newReferenceValidator(v.Client, subnet.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", subnet.Spec.ProjectReference, project)
+	refs.validate(ctx, specPath.Child("vpcReference"), "Vpc", subnet.Spec.VpcReference, &arubacloudcomv1alpha1.Vpc{})
+	errs = append(errs, refs.errs...)
+	errs = append(errs, validateTenant(ctx, v.Tenants, subnet, specPath.Child("tenant"), subnet.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, subnet, specPath.Child("providerConfigRef"), subnet.Spec.ProviderConfigRef)...)
+
+	return refs.warnings, invalidError("Subnet", subnet.Name, errs)
+}
+
+// validateSubnetAddress checks that address is an IPv4 CIDR without host bits set
+func validateSubnetAddress(addressPath *field.Path, address string) field.ErrorList {
+	prefix, err := netip.ParsePrefix(address)
+	if err != nil || !prefix.Addr().Is4() {
+		return field.ErrorList{field.Invalid(addressPath, address, "must be an IPv4 CIDR such as 192.168.1.0/24")}
+	}
+	if masked := prefix.Masked(); masked != prefix {
+		return field.ErrorList{field.Invalid(addressPath, address, fmt.Sprintf("host bits must not be set, did you mean %s?", masked))}
+	}
+	return nil
+}
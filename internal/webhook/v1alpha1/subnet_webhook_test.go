@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Subnet Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.Subnet
+		oldObj    *arubacloudcomv1alpha1.Subnet
+		validator SubnetCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.Subnet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-subnet",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.SubnetSpec{
+				Tenant:           "test-tenant",
+				Type:             "Advanced",
+				Network:          arubacloudcomv1alpha1.SubnetNetwork{Address: "192.168.1.0/25"},
+				DHCP:             arubacloudcomv1alpha1.SubnetDHCP{Enabled: true},
+				VpcReference:     arubacloudcomv1alpha1.ResourceReference{Name: "test-vpc"},
+				ProjectReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-project"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = SubnetCustomValidator{
+			Client: newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+				&arubacloudcomv1alpha1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "test-vpc", Namespace: "default"}},
+			),
+		}
+	})
+
+	Context("When creating Subnet under Validating Webhook", func() {
+		It("Should admit a valid subnet resolving references in its own namespace", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny a CIDR with host bits set", func() {
+			obj.Spec.Network.Address = "192.168.1.10/24"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(And(
+				ContainSubstring("spec.network.address"),
+				ContainSubstring("192.168.1.0/24"),
+			)))
+		})
+
+		It("Should deny an address that is not a CIDR", func() {
+			obj.Spec.Network.Address = "300.168.1.0/24"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.network.address")))
+		})
+	})
+
+	Context("When updating Subnet under Validating Webhook", func() {
+		It("Should deny moving the subnet to another VPC", func() {
+			obj.Spec.VpcReference.Name = "other-vpc"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.vpcReference")))
+		})
+
+		It("Should admit a DHCP change", func() {
+			obj.Spec.DHCP.Enabled = false
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
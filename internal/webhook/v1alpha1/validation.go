@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/schedule"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// referenceValidator checks the references of a resource against the cluster
+type referenceValidator struct {
+	client    client.Client
+	namespace string
+
+	errs     field.ErrorList
+	warnings admission.Warnings
+}
+
+// newReferenceValidator creates a referenceValidator resolving references without namespace in namespace
+func newReferenceValidator(c client.Client, namespace string) *referenceValidator {
+	return &referenceValidator{client: c, namespace: namespace}
+}
+
+// validate checks that ref is well formed and points to an installed kind, fetching the
+// referenced object into obj. A referenced object that doesn't exist yet is only reported
+// as a warning, so that a whole set of manifests can still be applied at once. References
+// by ID are checked by the controllers with the remote API.
+func (v *referenceValidator) validate(ctx context.Context, path *field.Path, kind string, ref arubacloudcomv1alpha1.ResourceReference, obj client.Object) {
+	errCount := len(v.errs)
+
+	if ref.ID != "" {
+		if ref.Name != "" {
+			v.errs = append(v.errs, field.Forbidden(path.Child("name"), "name can't be set with id"))
+		}
+		if ref.Namespace != "" {
+			v.errs = append(v.errs, field.Forbidden(path.Child("namespace"), "namespace can't be set with id"))
+		}
+		return
+	}
+	if ref.Name == "" {
+		v.errs = append(v.errs, field.Required(path.Child("name"), fmt.Sprintf("name or id of the referenced %s is required", kind)))
+		return
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+		v.errs = append(v.errs, field.Invalid(path.Child("name"), ref.Name, msg))
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = v.namespace
+	} else {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			v.errs = append(v.errs, field.Invalid(path.Child("namespace"), namespace, msg))
+		}
+	}
+	if len(v.errs) > errCount || v.client == nil {
+		return
+	}
+
+	err := v.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, obj)
+	switch {
+	case err == nil:
+	case meta.IsNoMatchError(err):
+		v.errs = append(v.errs, field.Invalid(path, ref.Name, fmt.Sprintf("kind %s is not installed in the cluster", kind)))
+	case apierrors.IsNotFound(err):
+		v.warnings = append(v.warnings, fmt.Sprintf("%s: referenced %s %s/%s does not exist yet", path, kind, namespace, ref.Name))
+	default:
+		v.warnings = append(v.warnings, fmt.Sprintf("%s: unable to verify referenced %s %s/%s: %v", path, kind, namespace, ref.Name, err))
+	}
+}
+
+// validateImmutable forbids changes to a field the remote API can't update
+func validateImmutable(path *field.Path, oldValue, newValue any) field.ErrorList {
+	if equality.Semantic.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(path, "field is immutable")}
+}
+
+// validateTenant forbids a tenant not bound to the namespace of obj. An empty tenant
+// is resolved from project, the referenced Project, when it was found.
+func validateTenant(ctx context.Context, tenants *tenancy.Policy, obj client.Object, path *field.Path, tenant string, project *arubacloudcomv1alpha1.Project) field.ErrorList {
+	if tenant == "" && project != nil {
+		tenant = project.Spec.Tenant
+	}
+
+	err := tenants.Authorize(ctx, obj, tenant)
+	var notBound *tenancy.NotBoundError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &notBound):
+		return field.ErrorList{field.Forbidden(path, err.Error())}
+	default:
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+}
+
+// validateProviderConfig forbids a reference to a ProviderConfig that doesn't allow the namespace
+// of obj. A ProviderConfig that doesn't exist yet is checked by the controllers.
+func validateProviderConfig(ctx context.Context, c client.Client, obj client.Object, path *field.Path, ref *arubacloudcomv1alpha1.ProviderConfigReference) field.ErrorList {
+	if ref == nil || c == nil {
+		return nil
+	}
+
+	pc := &arubacloudcomv1alpha1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+
+	err := tenancy.AuthorizeProviderConfig(ctx, c, pc, namespaceOf(ctx, obj))
+	var notAllowed *tenancy.ProviderConfigNotAllowedError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &notAllowed):
+		return field.ErrorList{field.Forbidden(path, err.Error())}
+	default:
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+}
+
+// validateTimeZone checks that timeZone, when set, is a time zone of the IANA database
+func validateTimeZone(path *field.Path, timeZone string) field.ErrorList {
+	if timeZone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return field.ErrorList{field.Invalid(path, timeZone, "unknown time zone")}
+	}
+	return nil
+}
+
+// validateCronExpression checks that expr is a cron expression the schedulers can evaluate
+func validateCronExpression(path *field.Path, expr string) field.ErrorList {
+	if _, err := schedule.Parse(expr); err != nil {
+		return field.ErrorList{field.Invalid(path, expr, err.Error())}
+	}
+	return nil
+}
+
+// invalidError wraps errs into an Invalid API error, or returns nil when errs is empty
+func invalidError(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: arubacloudcomv1alpha1.GroupVersion.Group, Kind: kind}, name, errs)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+// log is for logging in this package.
+var vpclog = logf.Log.WithName("vpc-resource")
+
+// SetupVpcWebhookWithManager registers the webhook for Vpc in the manager.
+func SetupVpcWebhookWithManager(mgr ctrl.Manager, tenants *tenancy.Policy) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.Vpc{}).
+		WithValidator(&VpcCustomValidator{Client: mgr.GetClient(), Tenants: tenants}).
+		WithDefaulter(&VpcCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-vpc,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=vpcs,verbs=create,versions=v1alpha1,name=mvpc-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// VpcCustomDefaulter fills the empty fields of the Vpc resource from the
+// ArubaDefaults of its namespace when it is created.
+type VpcCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &VpcCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type Vpc.
+func (d *VpcCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	vpc, ok := obj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return fmt.Errorf("expected a Vpc object but got %T", obj)
+	}
+	vpclog.Info("Defaulting for Vpc", "name", vpc.GetName())
+
+	defaults, err := loadNamespaceDefaults(ctx, d.Client, namespaceOf(ctx, vpc))
+	if err != nil {
+		return err
+	}
+	specPath := field.NewPath("spec")
+	defaults.tenant(specPath.Child("tenant"), &vpc.Spec.Tenant)
+	defaults.location(specPath.Child("location"), &vpc.Spec.Location)
+	defaults.projectReference(specPath.Child("projectReference"), &vpc.Spec.ProjectReference)
+	defaults.record(vpc)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-vpc,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=vpcs,verbs=create;update,versions=v1alpha1,name=vvpc-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// VpcCustomValidator validates the Vpc resource when it is created or updated.
+type VpcCustomValidator struct {
+	Client  client.Client
+	Tenants *tenancy.Policy
+}
+
+var _ webhook.CustomValidator = &VpcCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Vpc.
+func (v *VpcCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	vpc, ok := obj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return nil, fmt.Errorf("expected a Vpc object but got %T", obj)
+	}
+	vpclog.Info("Validation for Vpc upon creation", "name", vpc.GetName())
+
+	return v.validateVpc(ctx, vpc, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Vpc.
+func (v *VpcCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	vpc, ok := newObj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return nil, fmt.Errorf("expected a Vpc object for the newObj but got %T", newObj)
+	}
+	oldVpc, ok := oldObj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return nil, fmt.Errorf("expected a Vpc object for the oldObj but got %T", oldObj)
+	}
+	vpclog.Info("Validation for Vpc upon update", "name", vpc.GetName())
+
+	// Metadata-only updates, e.g. finalizer removal during deletion, must never be blocked
+	if equality.Semantic.DeepEqual(oldVpc.Spec, vpc.Spec) {
+		return nil, nil
+	}
+
+	return v.validateVpc(ctx, vpc, oldVpc)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Vpc.
+func (v *VpcCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateVpc validates the vpc spec, comparing it with old on update
+func (v *VpcCustomValidator) validateVpc(ctx context.Context, vpc, old *arubacloudcomv1alpha1.Vpc) (admission.Warnings, error) {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if old != nil {
+		errs = append(errs, validateImmutable(specPath.Child("tenant"), old.Spec.Tenant, vpc.Spec.Tenant)...)
+		errs = append(errs, validateImmutable(specPath.Child("location"), old.Spec.Location, vpc.Spec.Location)...)
+		errs = append(errs, validateImmutable(specPath.Child("projectReference"), old.Spec.ProjectReference, vpc.Spec.ProjectReference)...)
+	}
+
+	refs := newReferenceValidator(v.Client, vpc.Namespace)
+	project := &arubacloudcomv1alpha1.Project{}
+	refs.validate(ctx, specPath.Child("projectReference"), "Project", vpc.Spec.ProjectReference, project)
+	errs = append(errs, refs.errs...)
+	errs = append(errs, validateTenant(ctx, v.Tenants, vpc, specPath.Child("tenant"), vpc.Spec.Tenant, project)...)
+	errs = append(errs, validateProviderConfig(ctx, v.Client, vpc, specPath.Child("providerConfigRef"), vpc.Spec.ProviderConfigRef)...)
+
+	return refs.warnings, invalidError("Vpc", vpc.Name, errs)
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/tenancy"
+)
+
+var _ = Describe("Vpc Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.Vpc
+		oldObj    *arubacloudcomv1alpha1.Vpc
+		validator VpcCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.Vpc{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vpc",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.VpcSpec{
+				Tenant:           "test-tenant",
+				Location:         arubacloudcomv1alpha1.Location{Value: "ITBG-Bergamo"},
+				ProjectReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-project", Namespace: "default"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = VpcCustomValidator{
+			Client: newFakeClient(
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+			),
+		}
+	})
+
+	Context("When creating Vpc under Validating Webhook", func() {
+		It("Should admit a valid vpc", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should warn when the referenced project does not exist yet", func() {
+			obj.Spec.ProjectReference.Name = "missing-project"
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("Project default/missing-project does not exist yet")))
+		})
+
+		It("Should admit a project referenced by ID without looking it up", func() {
+			obj.Spec.ProjectReference = arubacloudcomv1alpha1.ResourceReference{ID: "66a10244f62b99c686572a9f"}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny a reference with both a name and an ID", func() {
+			obj.Spec.ProjectReference.ID = "66a10244f62b99c686572a9f"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.projectReference.name")))
+		})
+	})
+
+	Context("When updating Vpc under Validating Webhook", func() {
+		It("Should deny a projectReference change", func() {
+			obj.Spec.ProjectReference.Name = "other-project"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.projectReference")))
+		})
+
+		It("Should admit a tags change", func() {
+			obj.Spec.Tags = []string{"updated"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When creating Vpc with tenant isolation", func() {
+		var recorder *record.FakeRecorder
+
+		newValidator := func(objs ...client.Object) VpcCustomValidator {
+			c := newFakeClient(append(objs,
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"customer": "acme"}}},
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+			)...)
+			return VpcCustomValidator{Client: c, Tenants: &tenancy.Policy{Reader: c, Recorder: recorder, Enabled: true}}
+		}
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+		})
+
+		It("Should admit a tenant bound to the namespace by name", func() {
+			validator := newValidator(&arubacloudcomv1alpha1.TenantBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "acme"},
+				Spec:       arubacloudcomv1alpha1.TenantBindingSpec{Tenants: []string{"test-tenant"}, Namespaces: []string{"default"}},
+			})
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("Should admit a tenant bound to the namespace by selector", func() {
+			validator := newValidator(&arubacloudcomv1alpha1.TenantBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "acme"},
+				Spec: arubacloudcomv1alpha1.TenantBindingSpec{
+					Tenants:           []string{"test-tenant"},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"customer": "acme"}},
+				},
+			})
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny and record a tenant not bound to the namespace", func() {
+			validator := newValidator(&arubacloudcomv1alpha1.TenantBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				Spec:       arubacloudcomv1alpha1.TenantBindingSpec{Tenants: []string{"test-tenant"}, Namespaces: []string{"other"}},
+			})
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring(`tenant "test-tenant" is not bound to namespace "default"`)))
+			Expect(recorder.Events).To(Receive(ContainSubstring(tenancy.ReasonTenantNotBound)))
+		})
+
+		It("Should check the tenant of the referenced project", func() {
+			obj.Spec.Tenant = ""
+			validator := newValidator()
+			Expect(validator.Client.Delete(ctx, &arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}})).To(Succeed())
+			Expect(validator.Client.Create(ctx, &arubacloudcomv1alpha1.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"},
+				Spec:       arubacloudcomv1alpha1.ProjectSpec{Tenant: "project-tenant"},
+			})).To(Succeed())
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring(`tenant "project-tenant"`)))
+		})
+	})
+
+	Context("When creating Vpc with a ProviderConfig", func() {
+		newValidator := func(allowed arubacloudcomv1alpha1.ProviderConfigNamespaces) VpcCustomValidator {
+			return VpcCustomValidator{Client: newFakeClient(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"customer": "acme"}}},
+				&arubacloudcomv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: "test-project", Namespace: "default"}},
+				&arubacloudcomv1alpha1.ProviderConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "staging"},
+					Spec:       arubacloudcomv1alpha1.ProviderConfigSpec{AllowedNamespaces: allowed},
+				},
+			)}
+		}
+
+		BeforeEach(func() {
+			obj.Spec.ProviderConfigRef = &arubacloudcomv1alpha1.ProviderConfigReference{Name: "staging"}
+		})
+
+		It("Should admit a namespace allowed by name", func() {
+			validator := newValidator(arubacloudcomv1alpha1.ProviderConfigNamespaces{Namespaces: []string{"default"}})
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should admit a namespace allowed by selector", func() {
+			validator := newValidator(arubacloudcomv1alpha1.ProviderConfigNamespaces{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"customer": "acme"}},
+			})
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a namespace outside of the allowed namespaces", func() {
+			validator := newValidator(arubacloudcomv1alpha1.ProviderConfigNamespaces{
+				Namespaces:        []string{"team-a"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"customer": "other"}},
+			})
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(And(
+				ContainSubstring("spec.providerConfigRef"),
+				ContainSubstring(`ProviderConfig "staging" does not allow namespace "default"`),
+			)))
+		})
+
+		It("Should admit a ProviderConfig that does not exist yet", func() {
+			obj.Spec.ProviderConfigRef.Name = "missing"
+			validator := newValidator(arubacloudcomv1alpha1.ProviderConfigNamespaces{Namespaces: []string{"team-a"}})
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When creating Vpc under Defaulting Webhook", func() {
+		var defaults *arubacloudcomv1alpha1.ArubaDefaults
+
+		BeforeEach(func() {
+			defaults = &arubacloudcomv1alpha1.ArubaDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: arubacloudcomv1alpha1.ArubaDefaultsName, Namespace: "default"},
+				Spec: arubacloudcomv1alpha1.ArubaDefaultsSpec{
+					Tenant:           "default-tenant",
+					Location:         &arubacloudcomv1alpha1.Location{Value: "ITMI-Milano"},
+					ProjectReference: &arubacloudcomv1alpha1.ResourceReference{Name: "default-project", Namespace: "shared"},
+				},
+			}
+		})
+
+		It("Should fill the missing fields from the namespace defaults", func() {
+			obj.Spec = arubacloudcomv1alpha1.VpcSpec{}
+			defaulter := VpcCustomDefaulter{Client: newFakeClient(defaults)}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(obj.Spec.Tenant).To(Equal("default-tenant"))
+			Expect(obj.Spec.Location.Value).To(Equal("ITMI-Milano"))
+			Expect(obj.Spec.ProjectReference).To(Equal(arubacloudcomv1alpha1.ResourceReference{Name: "default-project", Namespace: "shared"}))
+			Expect(obj.Annotations).To(HaveKeyWithValue(arubacloudcomv1alpha1.DefaultedFieldsAnnotation,
+				"spec.tenant,spec.location,spec.projectReference"))
+		})
+
+		It("Should keep the fields set in the manifest", func() {
+			defaulter := VpcCustomDefaulter{Client: newFakeClient(defaults)}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(obj.Spec).To(Equal(oldObj.Spec))
+			Expect(obj.Annotations).NotTo(HaveKey(arubacloudcomv1alpha1.DefaultedFieldsAnnotation))
+		})
+
+		It("Should default the reference namespace without namespace defaults", func() {
+			obj.Spec.ProjectReference.Namespace = ""
+			defaulter := VpcCustomDefaulter{Client: newFakeClient()}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(obj.Spec.ProjectReference.Namespace).To(Equal("default"))
+			Expect(obj.Spec.Tenant).To(Equal("test-tenant"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(arubacloudcomv1alpha1.DefaultedFieldsAnnotation, "spec.projectReference.namespace"))
+		})
+	})
+})